@@ -0,0 +1,293 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Character limits App Store Connect enforces on localized metadata fields.
+//
+// https://developer.apple.com/help/app-store-connect/reference/platform-version-information
+// https://developer.apple.com/help/app-store-connect/reference/app-information
+const (
+	MaxAppNameLength           = 30
+	MaxSubtitleLength          = 30
+	MaxKeywordsLength          = 100
+	MaxPromotionalTextLength   = 170
+	MaxDescriptionLength       = 4000
+	MaxWhatsNewLength          = 4000
+	MaxPrivacyPolicyTextLength = 4000
+	MinAppNameLength           = 2
+)
+
+// LocalizationFieldError describes a single problem found with a localized metadata field.
+type LocalizationFieldError struct {
+	// Field is the JSON name of the attribute that failed validation, such as "keywords".
+	Field string
+	// Reason is a human-readable explanation of the problem.
+	Reason string
+}
+
+func (e LocalizationFieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// ErrInvalidLocalization is returned from the Validate methods on localization request attributes
+// when one or more fields would be rejected by App Store Connect. Every offending field is listed.
+type ErrInvalidLocalization struct {
+	Fields []LocalizationFieldError
+}
+
+func (e ErrInvalidLocalization) Error() string {
+	report := strings.Builder{}
+	report.WriteString("localization failed validation:")
+
+	for _, field := range e.Fields {
+		report.WriteString(fmt.Sprintf("\n* %s", field.Error()))
+	}
+
+	return report.String()
+}
+
+// localizationValidator accumulates field errors across a sequence of checks.
+type localizationValidator struct {
+	fields []LocalizationFieldError
+}
+
+func (v *localizationValidator) fail(field string, format string, args ...interface{}) {
+	v.fields = append(v.fields, LocalizationFieldError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *localizationValidator) maxLength(field string, value *string, limit int) {
+	if value == nil {
+		return
+	}
+
+	if length := GraphemeLength(*value); length > limit {
+		v.fail(field, "length %d exceeds the limit of %d characters", length, limit)
+	}
+}
+
+func (v *localizationValidator) minLength(field string, value *string, limit int) {
+	if value == nil {
+		return
+	}
+
+	if length := GraphemeLength(strings.TrimSpace(*value)); length < limit {
+		v.fail(field, "length %d is below the minimum of %d characters", length, limit)
+	}
+}
+
+func (v *localizationValidator) url(field string, value *string) {
+	if value == nil || *value == "" {
+		return
+	}
+
+	u, err := url.Parse(*value)
+	if err != nil {
+		v.fail(field, "%q is not a valid URL", *value)
+
+		return
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		v.fail(field, "%q must use the http or https scheme", *value)
+
+		return
+	}
+
+	if u.Host == "" {
+		v.fail(field, "%q is missing a host", *value)
+	}
+}
+
+func (v *localizationValidator) keywords(field string, value *string) {
+	if value == nil || *value == "" {
+		return
+	}
+
+	v.maxLength(field, value, MaxKeywordsLength)
+
+	seen := make(map[string]bool)
+
+	for i, keyword := range strings.Split(*value, ",") {
+		normalized := strings.ToLower(strings.TrimSpace(keyword))
+		if normalized == "" {
+			v.fail(field, "keyword %d is empty", i+1)
+
+			continue
+		}
+
+		if seen[normalized] {
+			v.fail(field, "keyword %q is duplicated", strings.TrimSpace(keyword))
+
+			continue
+		}
+
+		seen[normalized] = true
+	}
+}
+
+func (v *localizationValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return ErrInvalidLocalization{Fields: v.fields}
+}
+
+// Validate checks the attributes against App Store Connect's length, URL and keyword rules before
+// they are sent to the API. A non-nil error is always of type ErrInvalidLocalization.
+func (a AppStoreVersionLocalizationCreateRequestAttributes) Validate() error {
+	var v localizationValidator
+
+	if strings.TrimSpace(a.Locale) == "" {
+		v.fail("locale", "is required")
+	}
+
+	v.maxLength("description", a.Description, MaxDescriptionLength)
+	v.keywords("keywords", a.Keywords)
+	v.url("marketingUrl", a.MarketingURL)
+	v.maxLength("promotionalText", a.PromotionalText, MaxPromotionalTextLength)
+	v.url("supportUrl", a.SupportURL)
+	v.maxLength("whatsNew", a.WhatsNew, MaxWhatsNewLength)
+
+	return v.err()
+}
+
+// Validate checks the attributes against App Store Connect's length, URL and keyword rules before
+// they are sent to the API. A non-nil error is always of type ErrInvalidLocalization.
+func (a AppStoreVersionLocalizationUpdateRequestAttributes) Validate() error {
+	var v localizationValidator
+
+	v.maxLength("description", a.Description, MaxDescriptionLength)
+	v.keywords("keywords", a.Keywords)
+	v.url("marketingUrl", a.MarketingURL)
+	v.maxLength("promotionalText", a.PromotionalText, MaxPromotionalTextLength)
+	v.url("supportUrl", a.SupportURL)
+	v.maxLength("whatsNew", a.WhatsNew, MaxWhatsNewLength)
+
+	return v.err()
+}
+
+// Validate checks the attributes against App Store Connect's length and URL rules before
+// they are sent to the API. A non-nil error is always of type ErrInvalidLocalization.
+func (a AppInfoLocalizationCreateRequestAttributes) Validate() error {
+	var v localizationValidator
+
+	if strings.TrimSpace(a.Locale) == "" {
+		v.fail("locale", "is required")
+	}
+
+	v.minLength("name", a.Name, MinAppNameLength)
+	v.maxLength("name", a.Name, MaxAppNameLength)
+	v.maxLength("privacyPolicyText", a.PrivacyPolicyText, MaxPrivacyPolicyTextLength)
+	v.url("privacyPolicyUrl", a.PrivacyPolicyURL)
+	v.maxLength("subtitle", a.Subtitle, MaxSubtitleLength)
+
+	return v.err()
+}
+
+// Validate checks the attributes against App Store Connect's length and URL rules before
+// they are sent to the API. A non-nil error is always of type ErrInvalidLocalization.
+func (a AppInfoLocalizationUpdateRequestAttributes) Validate() error {
+	var v localizationValidator
+
+	v.minLength("name", a.Name, MinAppNameLength)
+	v.maxLength("name", a.Name, MaxAppNameLength)
+	v.maxLength("privacyPolicyText", a.PrivacyPolicyText, MaxPrivacyPolicyTextLength)
+	v.url("privacyPolicyUrl", a.PrivacyPolicyURL)
+	v.maxLength("subtitle", a.Subtitle, MaxSubtitleLength)
+
+	return v.err()
+}
+
+// GraphemeLength counts the user-perceived characters in s the way App Store Connect does,
+// so that an emoji with skin tone modifiers, a flag, or a letter with combining accents
+// each count as a single character.
+func GraphemeLength(s string) int {
+	var (
+		count         int
+		joinNext      bool
+		regionalCount int
+		prev          rune
+	)
+
+	for _, r := range s {
+		switch {
+		case prev == '\r' && r == '\n':
+			// CRLF is a single grapheme.
+		case joinNext:
+			joinNext = false
+		case r == zeroWidthJoiner:
+			joinNext = true
+		case isGraphemeExtender(r):
+			// Extends the previous cluster.
+		case isRegionalIndicator(r):
+			if regionalCount%2 == 0 {
+				count++
+			}
+
+			regionalCount++
+		default:
+			count++
+		}
+
+		if !isRegionalIndicator(r) {
+			regionalCount = 0
+		}
+
+		prev = r
+	}
+
+	return count
+}
+
+const zeroWidthJoiner = '\u200d'
+
+func isGraphemeExtender(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF:
+		// Variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		// Emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F:
+		// Emoji tag sequences
+		return true
+	}
+
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
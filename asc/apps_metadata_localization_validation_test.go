@@ -0,0 +1,129 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphemeLength(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value string
+		want  int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"h\u00e9llo", 5},
+		{"he\u0301llo", 5},
+		{"\U0001F44D\U0001F3FD", 1},
+		{"\U0001F1EF\U0001F1F5\U0001F1FA\U0001F1F8", 2},
+		{"\U0001F469\u200d\U0001F469\u200d\U0001F467", 1},
+		{"\u2764\ufe0f", 1},
+		{"a\r\nb", 3},
+		{"日本語", 3},
+	}
+
+	for _, c := range testCases {
+		assert.Equal(t, c.want, GraphemeLength(c.value), c.value)
+	}
+}
+
+func TestAppStoreVersionLocalizationUpdateRequestAttributesValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := AppStoreVersionLocalizationUpdateRequestAttributes{
+		Description:     String("A fine app."),
+		Keywords:        String("photo, camera,filters"),
+		MarketingURL:    String("https://example.com"),
+		PromotionalText: String(strings.Repeat("\U0001F44D\U0001F3FD", MaxPromotionalTextLength)),
+		SupportURL:      String("http://example.com/support"),
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, AppStoreVersionLocalizationUpdateRequestAttributes{}.Validate())
+
+	invalid := AppStoreVersionLocalizationUpdateRequestAttributes{
+		Description:     String(strings.Repeat("a", MaxDescriptionLength+1)),
+		Keywords:        String("photo,,Camera,camera"),
+		MarketingURL:    String("example.com"),
+		PromotionalText: String(strings.Repeat("a", MaxPromotionalTextLength+1)),
+		SupportURL:      String("https://"),
+	}
+	err := invalid.Validate()
+
+	var validationErr ErrInvalidLocalization
+
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []LocalizationFieldError{
+		{Field: "description", Reason: "length 4001 exceeds the limit of 4000 characters"},
+		{Field: "keywords", Reason: "keyword 2 is empty"},
+		{Field: "keywords", Reason: "keyword \"camera\" is duplicated"},
+		{Field: "marketingUrl", Reason: "\"example.com\" must use the http or https scheme"},
+		{Field: "promotionalText", Reason: "length 171 exceeds the limit of 170 characters"},
+		{Field: "supportUrl", Reason: "\"https://\" is missing a host"},
+	}, validationErr.Fields)
+	assert.Contains(t, err.Error(), "* keywords: keyword 2 is empty")
+}
+
+func TestAppStoreVersionLocalizationCreateRequestAttributesValidate(t *testing.T) {
+	t.Parallel()
+
+	err := AppStoreVersionLocalizationCreateRequestAttributes{
+		Keywords: String(strings.Repeat("a", MaxKeywordsLength+1)),
+	}.Validate()
+
+	var validationErr ErrInvalidLocalization
+
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Fields, 2)
+	assert.Equal(t, "locale", validationErr.Fields[0].Field)
+	assert.Equal(t, "keywords", validationErr.Fields[1].Field)
+}
+
+func TestAppInfoLocalizationRequestAttributesValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, AppInfoLocalizationCreateRequestAttributes{
+		Locale:   "en-US",
+		Name:     String("My App"),
+		Subtitle: String("The best app"),
+	}.Validate())
+
+	err := AppInfoLocalizationUpdateRequestAttributes{
+		Name:             String("A"),
+		PrivacyPolicyURL: String("ftp://example.com"),
+		Subtitle:         String(strings.Repeat("a", MaxSubtitleLength+1)),
+	}.Validate()
+
+	var validationErr ErrInvalidLocalization
+
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []LocalizationFieldError{
+		{Field: "name", Reason: "length 1 is below the minimum of 2 characters"},
+		{Field: "privacyPolicyUrl", Reason: "\"ftp://example.com\" must use the http or https scheme"},
+		{Field: "subtitle", Reason: "length 31 exceeds the limit of 30 characters"},
+	}, validationErr.Fields)
+}
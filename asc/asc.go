@@ -59,6 +59,7 @@ type Client struct {
 	Apps          *AppsService
 	Subscriptions *SubscriptionsService
 	Builds        *BuildsService
+	CI            *CIService
 	Pricing       *PricingService
 	Provisioning  *ProvisioningService
 	Publishing    *PublishingService
@@ -91,6 +92,7 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Apps = (*AppsService)(&c.common)
 	c.Subscriptions = (*SubscriptionsService)(&c.common)
 	c.Builds = (*BuildsService)(&c.common)
+	c.CI = (*CIService)(&c.common)
 	c.Pricing = (*PricingService)(&c.common)
 	c.Provisioning = (*ProvisioningService)(&c.common)
 	c.Publishing = (*PublishingService)(&c.common)
@@ -259,6 +261,59 @@ func (c *Client) get(ctx context.Context, url string, query interface{}, v inter
 	return resp, err
 }
 
+// download fetches a presigned URL, such as a screenshot or artifact URL, and writes the body to w.
+// The URL carries its own authorization, so the request is sent without the API token: the token
+// must not reach the storage host, which may also reject a request with a second credential.
+func (c *Client) download(ctx context.Context, url string, w io.Writer) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := c.downloadClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeDesc(resp.Body)
+
+	response := newResponse(resp)
+
+	if status := resp.StatusCode; status < 200 || status > 299 {
+		return response, &ErrorResponse{Response: resp}
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return response, err
+}
+
+// downloadClient returns an http.Client that sends requests like the API client, minus the
+// Authorization header added by an AuthTransport.
+func (c *Client) downloadClient() *http.Client {
+	var transport http.RoundTripper
+
+	switch t := c.client.Transport.(type) {
+	case *AuthTransport:
+		transport = t.transport()
+	case AuthTransport:
+		transport = t.transport()
+	default:
+		return c.client
+	}
+
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: c.client.CheckRedirect,
+		Jar:           c.client.Jar,
+		Timeout:       c.client.Timeout,
+	}
+}
+
 // post sends a POST request to the API as configured.
 func (c *Client) post(ctx context.Context, url string, body *requestBody, v interface{}) (*Response, error) {
 	req, err := c.newRequest(ctx, "POST", url, body, withContentType("application/json"))
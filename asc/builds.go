@@ -28,10 +28,23 @@ import (
 // BuildsService handles communication with build-related methods of the App Store Connect API
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/builds
+// https://developer.apple.com/documentation/appstoreconnectapi/build_bundles
 // https://developer.apple.com/documentation/appstoreconnectapi/build_icons
 // https://developer.apple.com/documentation/appstoreconnectapi/app_encryption_declarations
 type BuildsService service
 
+// Processing states reported in BuildAttributes.ProcessingState.
+const (
+	// BuildProcessingStateProcessing is a build that is still being processed by App Store Connect.
+	BuildProcessingStateProcessing = "PROCESSING"
+	// BuildProcessingStateFailed is a build whose processing failed.
+	BuildProcessingStateFailed = "FAILED"
+	// BuildProcessingStateInvalid is a build that was rejected as invalid during processing.
+	BuildProcessingStateInvalid = "INVALID"
+	// BuildProcessingStateValid is a build that finished processing and can be used.
+	BuildProcessingStateValid = "VALID"
+)
+
 // Build defines model for Build.
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/build
@@ -67,6 +80,7 @@ type BuildRelationships struct {
 	BetaAppReviewSubmission  *Relationship      `json:"betaAppReviewSubmission,omitempty"`
 	BetaBuildLocalizations   *PagedRelationship `json:"betaBuildLocalizations,omitempty"`
 	BuildBetaDetail          *Relationship      `json:"buildBetaDetail,omitempty"`
+	BuildBundles             *PagedRelationship `json:"buildBundles,omitempty"`
 	Icons                    *PagedRelationship `json:"icons,omitempty"`
 	IndividualTesters        *PagedRelationship `json:"individualTesters,omitempty"`
 	PreReleaseVersion        *Relationship      `json:"preReleaseVersion,omitempty"`
@@ -153,6 +167,7 @@ type ListBuildsQuery struct {
 	FieldsAppStoreVersions                   []string `url:"fields[appStoreVersions],omitempty"`
 	FieldsPerfPowerMetrics                   []string `url:"fields[perfPowerMetrics],omitempty"`
 	FieldsBuildIcons                         []string `url:"fields[buildIcons],omitempty"`
+	FieldsBuildBundles                       []string `url:"fields[buildBundles],omitempty"`
 	FilterApp                                []string `url:"filter[app],omitempty"`
 	FilterExpired                            []string `url:"filter[expired],omitempty"`
 	FilterID                                 []string `url:"filter[id],omitempty"`
@@ -171,6 +186,7 @@ type ListBuildsQuery struct {
 	LimitIndividualTesters                   int      `url:"limit[individualTesters],omitempty"`
 	LimitBetaBuildLocalizations              int      `url:"limit[betaBuildLocalizations],omitempty"`
 	LimitIcons                               int      `url:"limit[icons],omitempty"`
+	LimitBuildBundles                        int      `url:"limit[buildBundles],omitempty"`
 	Cursor                                   string   `url:"cursor,omitempty"`
 }
 
@@ -199,10 +215,12 @@ type GetBuildQuery struct {
 	FieldsAppStoreVersions          []string `url:"fields[appStoreVersions],omitempty"`
 	FieldsPerfPowerMetrics          []string `url:"fields[perfPowerMetrics],omitempty"`
 	FieldsBuildIcons                []string `url:"fields[buildIcons],omitempty"`
+	FieldsBuildBundles              []string `url:"fields[buildBundles],omitempty"`
 	Include                         []string `url:"include,omitempty"`
 	LimitIndividualTesters          int      `url:"limit[individualTesters],omitempty"`
 	LimitBetaBuildLocalizations     int      `url:"limit[betaBuildLocalizations],omitempty"`
 	LimitIcons                      int      `url:"limit[icons],omitempty"`
+	LimitBuildBundles               int      `url:"limit[buildBundles],omitempty"`
 }
 
 // GetAppForBuildQuery are query options for GetAppForBuild
@@ -246,7 +264,7 @@ type GetAppEncryptionDeclarationForBuildQuery struct {
 // https://developer.apple.com/documentation/appstoreconnectapi/list_builds
 func (s *BuildsService) ListBuilds(ctx context.Context, params *ListBuildsQuery) (*BuildsResponse, *Response, error) {
 	res := new(BuildsResponse)
-	resp, err := s.client.get(ctx, "builds", params, res)
+	resp, err := s.client.get(ctx, "v1/builds", params, res)
 
 	return res, resp, err
 }
@@ -330,7 +348,7 @@ func (s *BuildsService) UpdateBuild(ctx context.Context, id string, expired *boo
 
 	url := fmt.Sprintf("v1/builds/%s", id)
 	res := new(BuildResponse)
-	resp, err := s.client.post(ctx, url, newRequestBody(req), res)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
 
 	return res, resp, err
 }
@@ -472,6 +490,11 @@ func (i *BuildResponseIncluded) BuildIcon() *BuildIcon {
 	return extractIncludedBuildIcon(i.inner)
 }
 
+// BuildBundle returns the BuildBundle stored within, if one is present.
+func (i *BuildResponseIncluded) BuildBundle() *BuildBundle {
+	return extractIncludedBuildBundle(i.inner)
+}
+
 // PerfPowerMetric returns the PerfPowerMetric stored within, if one is present.
 func (i *BuildResponseIncluded) PerfPowerMetric() *PerfPowerMetric {
 	return extractIncludedPerfPowerMetric(i.inner)
@@ -0,0 +1,161 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// AppEncryptionDeclarationDocument defines model for AppEncryptionDeclarationDocument.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocument
+type AppEncryptionDeclarationDocument struct {
+	Attributes *AppEncryptionDeclarationDocumentAttributes `json:"attributes,omitempty"`
+	ID         string                                      `json:"id"`
+	Links      ResourceLinks                               `json:"links"`
+	Type       string                                      `json:"type"`
+}
+
+// AppEncryptionDeclarationDocumentAttributes defines model for AppEncryptionDeclarationDocument.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocument/attributes
+type AppEncryptionDeclarationDocumentAttributes struct {
+	AssetDeliveryState *AppMediaAssetState `json:"assetDeliveryState,omitempty"`
+	AssetToken         *string             `json:"assetToken,omitempty"`
+	DownloadURL        *string             `json:"downloadUrl,omitempty"`
+	FileName           *string             `json:"fileName,omitempty"`
+	FileSize           *int64              `json:"fileSize,omitempty"`
+	SourceFileChecksum *string             `json:"sourceFileChecksum,omitempty"`
+	UploadOperations   []UploadOperation   `json:"uploadOperations,omitempty"`
+}
+
+// appEncryptionDeclarationDocumentCreateRequest defines model for AppEncryptionDeclarationDocumentCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentcreaterequest/data
+type appEncryptionDeclarationDocumentCreateRequest struct {
+	Attributes    appEncryptionDeclarationDocumentCreateRequestAttributes    `json:"attributes"`
+	Relationships appEncryptionDeclarationDocumentCreateRequestRelationships `json:"relationships"`
+	Type          string                                                     `json:"type"`
+}
+
+// appEncryptionDeclarationDocumentCreateRequestAttributes are attributes for AppEncryptionDeclarationDocumentCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentcreaterequest/data/attributes
+type appEncryptionDeclarationDocumentCreateRequestAttributes struct {
+	FileName string `json:"fileName"`
+	FileSize int64  `json:"fileSize"`
+}
+
+// appEncryptionDeclarationDocumentCreateRequestRelationships are relationships for AppEncryptionDeclarationDocumentCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentcreaterequest/data/relationships
+type appEncryptionDeclarationDocumentCreateRequestRelationships struct {
+	AppEncryptionDeclaration relationshipDeclaration `json:"appEncryptionDeclaration"`
+}
+
+// appEncryptionDeclarationDocumentUpdateRequest defines model for AppEncryptionDeclarationDocumentUpdateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentupdaterequest/data
+type appEncryptionDeclarationDocumentUpdateRequest struct {
+	Attributes *appEncryptionDeclarationDocumentUpdateRequestAttributes `json:"attributes,omitempty"`
+	ID         string                                                   `json:"id"`
+	Type       string                                                   `json:"type"`
+}
+
+// appEncryptionDeclarationDocumentUpdateRequestAttributes are attributes for AppEncryptionDeclarationDocumentUpdateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentupdaterequest/data/attributes
+type appEncryptionDeclarationDocumentUpdateRequestAttributes struct {
+	SourceFileChecksum *string `json:"sourceFileChecksum,omitempty"`
+	Uploaded           *bool   `json:"uploaded,omitempty"`
+}
+
+// AppEncryptionDeclarationDocumentResponse defines model for AppEncryptionDeclarationDocumentResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationdocumentresponse
+type AppEncryptionDeclarationDocumentResponse struct {
+	Data  AppEncryptionDeclarationDocument `json:"data"`
+	Links DocumentLinks                    `json:"links"`
+}
+
+// GetAppEncryptionDeclarationDocumentQuery are query options for GetAppEncryptionDeclarationDocument
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_an_app_encryption_declaration_document
+type GetAppEncryptionDeclarationDocumentQuery struct {
+	FieldsAppEncryptionDeclarationDocuments []string `url:"fields[appEncryptionDeclarationDocuments],omitempty"`
+}
+
+// GetAppEncryptionDeclarationDocument gets information about a document attached to an app
+// encryption declaration and its upload and processing status.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_an_app_encryption_declaration_document
+func (s *BuildsService) GetAppEncryptionDeclarationDocument(ctx context.Context, id string, params *GetAppEncryptionDeclarationDocumentQuery) (*AppEncryptionDeclarationDocumentResponse, *Response, error) {
+	url := fmt.Sprintf("v1/appEncryptionDeclarationDocuments/%s", id)
+	res := new(AppEncryptionDeclarationDocumentResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// CreateAppEncryptionDeclarationDocument reserves space for a document, such as a French import
+// declaration or a CCATS, to attach to an app encryption declaration.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/upload_an_app_encryption_declaration_document
+func (s *BuildsService) CreateAppEncryptionDeclarationDocument(ctx context.Context, fileName string, fileSize int64, appEncryptionDeclarationID string) (*AppEncryptionDeclarationDocumentResponse, *Response, error) {
+	req := appEncryptionDeclarationDocumentCreateRequest{
+		Attributes: appEncryptionDeclarationDocumentCreateRequestAttributes{
+			FileName: fileName,
+			FileSize: fileSize,
+		},
+		Relationships: appEncryptionDeclarationDocumentCreateRequestRelationships{
+			AppEncryptionDeclaration: *newRelationshipDeclaration(&appEncryptionDeclarationID, "appEncryptionDeclarations"),
+		},
+		Type: "appEncryptionDeclarationDocuments",
+	}
+	res := new(AppEncryptionDeclarationDocumentResponse)
+	resp, err := s.client.post(ctx, "v1/appEncryptionDeclarationDocuments", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// CommitAppEncryptionDeclarationDocument commits a document after uploading it.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/commit_an_app_encryption_declaration_document
+func (s *BuildsService) CommitAppEncryptionDeclarationDocument(ctx context.Context, id string, uploaded *bool, sourceFileChecksum *string) (*AppEncryptionDeclarationDocumentResponse, *Response, error) {
+	req := appEncryptionDeclarationDocumentUpdateRequest{
+		ID:   id,
+		Type: "appEncryptionDeclarationDocuments",
+	}
+
+	if uploaded != nil || sourceFileChecksum != nil {
+		req.Attributes = &appEncryptionDeclarationDocumentUpdateRequestAttributes{
+			Uploaded:           uploaded,
+			SourceFileChecksum: sourceFileChecksum,
+		}
+	}
+
+	url := fmt.Sprintf("v1/appEncryptionDeclarationDocuments/%s", id)
+	res := new(AppEncryptionDeclarationDocumentResponse)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
@@ -33,6 +33,8 @@ type AppEncryptionDeclarationState string
 const (
 	// AppEncryptionDeclarationStateApproved is an app encryption declaration state type for Approved.
 	AppEncryptionDeclarationStateApproved AppEncryptionDeclarationState = "APPROVED"
+	// AppEncryptionDeclarationStateCreated is an app encryption declaration state type for Created.
+	AppEncryptionDeclarationStateCreated AppEncryptionDeclarationState = "CREATED"
 	// AppEncryptionDeclarationStateExpired is an app encryption declaration state type for Expired.
 	AppEncryptionDeclarationStateExpired AppEncryptionDeclarationState = "EXPIRED"
 	// AppEncryptionDeclarationStateInvalid is an app encryption declaration state type for Invalid.
@@ -58,6 +60,7 @@ type AppEncryptionDeclaration struct {
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclaration/attributes
 type AppEncryptionDeclarationAttributes struct {
+	AppDescription                  *string                        `json:"appDescription,omitempty"`
 	AppEncryptionDeclarationState   *AppEncryptionDeclarationState `json:"appEncryptionDeclarationState,omitempty"`
 	AvailableOnFrenchStore          *bool                          `json:"availableOnFrenchStore,omitempty"`
 	CodeValue                       *string                        `json:"codeValue,omitempty"`
@@ -79,6 +82,32 @@ type AppEncryptionDeclarationRelationships struct {
 	App *Relationship `json:"app,omitempty"`
 }
 
+// appEncryptionDeclarationCreateRequest defines model for AppEncryptionDeclarationCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationcreaterequest/data
+type appEncryptionDeclarationCreateRequest struct {
+	Attributes    AppEncryptionDeclarationCreateRequestAttributes    `json:"attributes"`
+	Relationships appEncryptionDeclarationCreateRequestRelationships `json:"relationships"`
+	Type          string                                             `json:"type"`
+}
+
+// AppEncryptionDeclarationCreateRequestAttributes are attributes for AppEncryptionDeclarationCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationcreaterequest/data/attributes
+type AppEncryptionDeclarationCreateRequestAttributes struct {
+	AppDescription                  string `json:"appDescription"`
+	AvailableOnFrenchStore          bool   `json:"availableOnFrenchStore"`
+	ContainsProprietaryCryptography bool   `json:"containsProprietaryCryptography"`
+	ContainsThirdPartyCryptography  bool   `json:"containsThirdPartyCryptography"`
+}
+
+// appEncryptionDeclarationCreateRequestRelationships are relationships for AppEncryptionDeclarationCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationcreaterequest/data/relationships
+type appEncryptionDeclarationCreateRequestRelationships struct {
+	App relationshipDeclaration `json:"app"`
+}
+
 // AppEncryptionDeclarationResponse defines model for AppEncryptionDeclarationResponse.
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/appencryptiondeclarationresponse
@@ -133,7 +162,7 @@ type GetAppForEncryptionDeclarationQuery struct {
 // https://developer.apple.com/documentation/appstoreconnectapi/list_app_encryption_declarations
 func (s *BuildsService) ListAppEncryptionDeclarations(ctx context.Context, params *ListAppEncryptionDeclarationsQuery) (*AppEncryptionDeclarationsResponse, *Response, error) {
 	res := new(AppEncryptionDeclarationsResponse)
-	resp, err := s.client.get(ctx, "appEncryptionDeclarations", params, res)
+	resp, err := s.client.get(ctx, "v1/appEncryptionDeclarations", params, res)
 
 	return res, resp, err
 }
@@ -165,7 +194,25 @@ func (s *BuildsService) GetAppForAppEncryptionDeclaration(ctx context.Context, i
 // https://developer.apple.com/documentation/appstoreconnectapi/assign_builds_to_an_app_encryption_declaration
 func (s *BuildsService) AssignBuildsToAppEncryptionDeclaration(ctx context.Context, id string, buildIDs []string) (*Response, error) {
 	linkages := newPagedRelationshipDeclaration(buildIDs, "builds")
-	url := fmt.Sprintf("v1/appStoreVersionSubmissions/%s", id)
+	url := fmt.Sprintf("v1/appEncryptionDeclarations/%s/relationships/builds", id)
 
 	return s.client.post(ctx, url, newRequestBody(linkages.Data), nil)
 }
+
+// CreateAppEncryptionDeclaration declares how an app uses encryption. The declaration is reviewed
+// by Apple before builds assigned to it can be distributed.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/create_an_app_encryption_declaration
+func (s *BuildsService) CreateAppEncryptionDeclaration(ctx context.Context, attributes AppEncryptionDeclarationCreateRequestAttributes, appID string) (*AppEncryptionDeclarationResponse, *Response, error) {
+	req := appEncryptionDeclarationCreateRequest{
+		Attributes: attributes,
+		Relationships: appEncryptionDeclarationCreateRequestRelationships{
+			App: *newRelationshipDeclaration(&appID, "apps"),
+		},
+		Type: "appEncryptionDeclarations",
+	}
+	res := new(AppEncryptionDeclarationResponse)
+	resp, err := s.client.post(ctx, "v1/appEncryptionDeclarations", newRequestBody(req), res)
+
+	return res, resp, err
+}
@@ -56,3 +56,11 @@ func TestAssignBuildsToAppEncryptionDeclaration(t *testing.T) {
 		return client.Builds.AssignBuildsToAppEncryptionDeclaration(ctx, "10", []string{"10"})
 	})
 }
+
+func TestCreateAppEncryptionDeclaration(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &AppEncryptionDeclarationResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Builds.CreateAppEncryptionDeclaration(ctx, AppEncryptionDeclarationCreateRequestAttributes{}, "10")
+	})
+}
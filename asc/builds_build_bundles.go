@@ -0,0 +1,413 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BuildBundleType defines model for BuildBundleType.
+type BuildBundleType string
+
+const (
+	// BuildBundleTypeApp is a build bundle type for the app itself.
+	BuildBundleTypeApp BuildBundleType = "APP"
+	// BuildBundleTypeAppClip is a build bundle type for an App Clip.
+	BuildBundleTypeAppClip BuildBundleType = "APP_CLIP"
+)
+
+// ErrNoAppBundle happens when a build has no build bundle of type APP, which is the case until it
+// has finished processing.
+type ErrNoAppBundle struct {
+	BuildID string
+}
+
+func (e ErrNoAppBundle) Error() string {
+	return fmt.Sprintf("build %s has no app bundle", e.BuildID)
+}
+
+// BuildBundle defines model for BuildBundle.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundle
+type BuildBundle struct {
+	Attributes    *BuildBundleAttributes    `json:"attributes,omitempty"`
+	ID            string                    `json:"id"`
+	Links         ResourceLinks             `json:"links"`
+	Relationships *BuildBundleRelationships `json:"relationships,omitempty"`
+	Type          string                    `json:"type"`
+}
+
+// BuildBundleAttributes defines model for BuildBundle.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundle/attributes
+type BuildBundleAttributes struct {
+	BADownloadAllowance             *int             `json:"baDownloadAllowance,omitempty"`
+	BAMaxInstallSize                *int             `json:"baMaxInstallSize,omitempty"`
+	BundleID                        *string          `json:"bundleId,omitempty"`
+	BundleType                      *BuildBundleType `json:"bundleType,omitempty"`
+	DeviceProtocols                 []string         `json:"deviceProtocols,omitempty"`
+	DSYMURL                         *string          `json:"dSYMUrl,omitempty"`
+	FileName                        *string          `json:"fileName,omitempty"`
+	HasOnDemandResources            *bool            `json:"hasOnDemandResources,omitempty"`
+	HasPrerenderedIcon              *bool            `json:"hasPrerenderedIcon,omitempty"`
+	HasSirikit                      *bool            `json:"hasSirikit,omitempty"`
+	IncludesSymbols                 *bool            `json:"includesSymbols,omitempty"`
+	IsIosBuildMacAppStoreCompatible *bool            `json:"isIosBuildMacAppStoreCompatible,omitempty"`
+	Locales                         []string         `json:"locales,omitempty"`
+	PlatformBuild                   *string          `json:"platformBuild,omitempty"`
+	RequiredCapabilities            []string         `json:"requiredCapabilities,omitempty"`
+	SDKBuild                        *string          `json:"sdkBuild,omitempty"`
+	SupportedArchitectures          []string         `json:"supportedArchitectures,omitempty"`
+	UsesLocationServices            *bool            `json:"usesLocationServices,omitempty"`
+}
+
+// BuildBundleRelationships defines model for BuildBundle.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundle/relationships
+type BuildBundleRelationships struct {
+	AppClipDomainCacheStatus *Relationship      `json:"appClipDomainCacheStatus,omitempty"`
+	AppClipDomainDebugStatus *Relationship      `json:"appClipDomainDebugStatus,omitempty"`
+	BetaAppClipInvocations   *PagedRelationship `json:"betaAppClipInvocations,omitempty"`
+	BuildBundleFileSizes     *PagedRelationship `json:"buildBundleFileSizes,omitempty"`
+}
+
+// BuildBundleFileSize defines model for BuildBundleFileSize.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundlefilesize
+type BuildBundleFileSize struct {
+	Attributes *BuildBundleFileSizeAttributes `json:"attributes,omitempty"`
+	ID         string                         `json:"id"`
+	Links      ResourceLinks                  `json:"links"`
+	Type       string                         `json:"type"`
+}
+
+// BuildBundleFileSizeAttributes defines model for BuildBundleFileSize.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundlefilesize/attributes
+type BuildBundleFileSizeAttributes struct {
+	DeviceModel   *string `json:"deviceModel,omitempty"`
+	DownloadBytes *int64  `json:"downloadBytes,omitempty"`
+	InstallBytes  *int64  `json:"installBytes,omitempty"`
+	OSVersion     *string `json:"osVersion,omitempty"`
+}
+
+// BuildBundleFileSizesResponse defines model for BuildBundleFileSizesResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/buildbundlefilesizesresponse
+type BuildBundleFileSizesResponse struct {
+	Data  []BuildBundleFileSize `json:"data"`
+	Links PagedDocumentLinks    `json:"links"`
+	Meta  *PagingInformation    `json:"meta,omitempty"`
+}
+
+// AppClipDomainStatus defines model for AppClipDomainStatus.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appclipdomainstatus
+type AppClipDomainStatus struct {
+	Attributes *AppClipDomainStatusAttributes `json:"attributes,omitempty"`
+	ID         string                         `json:"id"`
+	Links      ResourceLinks                  `json:"links"`
+	Type       string                         `json:"type"`
+}
+
+// AppClipDomainStatusAttributes defines model for AppClipDomainStatus.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appclipdomainstatus/attributes
+type AppClipDomainStatusAttributes struct {
+	Domains         []AppClipDomainStatusDomain `json:"domains,omitempty"`
+	LastUpdatedDate *DateTime                   `json:"lastUpdatedDate,omitempty"`
+}
+
+// AppClipDomainStatusDomain defines model for AppClipDomainStatus.Attributes.Domains
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appclipdomainstatus/attributes/domains
+type AppClipDomainStatusDomain struct {
+	Domain          *string   `json:"domain,omitempty"`
+	ErrorCode       *string   `json:"errorCode,omitempty"`
+	IsValid         *bool     `json:"isValid,omitempty"`
+	LastUpdatedDate *DateTime `json:"lastUpdatedDate,omitempty"`
+}
+
+// AppClipDomainStatusResponse defines model for AppClipDomainStatusResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appclipdomainstatusresponse
+type AppClipDomainStatusResponse struct {
+	Data  AppClipDomainStatus `json:"data"`
+	Links DocumentLinks       `json:"links"`
+}
+
+// ListBuildBundleFileSizesQuery are query options for ListBuildBundleFileSizes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_file_sizes_for_a_build_bundle
+type ListBuildBundleFileSizesQuery struct {
+	FieldsBuildBundleFileSizes []string `url:"fields[buildBundleFileSizes],omitempty"`
+	Limit                      int      `url:"limit,omitempty"`
+	Cursor                     string   `url:"cursor,omitempty"`
+}
+
+// GetAppClipDomainStatusQuery are query options for GetAppClipDomainCacheStatusForBuildBundle and
+// GetAppClipDomainDebugStatusForBuildBundle
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_app_clip_domain_cache_status_for_a_build_bundle
+type GetAppClipDomainStatusQuery struct {
+	FieldsAppClipDomainStatuses []string `url:"fields[appClipDomainStatuses],omitempty"`
+}
+
+// ListBuildBundleFileSizes lists the estimated download and install sizes of a build bundle for each device variant.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_file_sizes_for_a_build_bundle
+func (s *BuildsService) ListBuildBundleFileSizes(ctx context.Context, id string, params *ListBuildBundleFileSizesQuery) (*BuildBundleFileSizesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/buildBundles/%s/buildBundleFileSizes", id)
+	res := new(BuildBundleFileSizesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetAppClipDomainCacheStatusForBuildBundle gets the status of the App Clip domains cached by Apple for an App Clip build bundle.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_app_clip_domain_cache_status_for_a_build_bundle
+func (s *BuildsService) GetAppClipDomainCacheStatusForBuildBundle(ctx context.Context, id string, params *GetAppClipDomainStatusQuery) (*AppClipDomainStatusResponse, *Response, error) {
+	url := fmt.Sprintf("v1/buildBundles/%s/appClipDomainCacheStatus", id)
+	res := new(AppClipDomainStatusResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetAppClipDomainDebugStatusForBuildBundle gets the debug status of the App Clip domains for an App Clip build bundle.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_app_clip_domain_debug_status_for_a_build_bundle
+func (s *BuildsService) GetAppClipDomainDebugStatusForBuildBundle(ctx context.Context, id string, params *GetAppClipDomainStatusQuery) (*AppClipDomainStatusResponse, *Response, error) {
+	url := fmt.Sprintf("v1/buildBundles/%s/appClipDomainDebugStatus", id)
+	res := new(AppClipDomainStatusResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// BuildSizeDelta compares the size of one device variant between a base build and a newer build.
+// Sizes are zero when the variant is missing from that build.
+type BuildSizeDelta struct {
+	DeviceModel       string
+	OSVersion         string
+	BaseDownloadBytes int64
+	DownloadBytes     int64
+	BaseInstallBytes  int64
+	InstallBytes      int64
+}
+
+// DownloadGrowth returns how many bytes the download size grew by. It is negative when the size shrank.
+func (d BuildSizeDelta) DownloadGrowth() int64 {
+	return d.DownloadBytes - d.BaseDownloadBytes
+}
+
+// InstallGrowth returns how many bytes the install size grew by. It is negative when the size shrank.
+func (d BuildSizeDelta) InstallGrowth() int64 {
+	return d.InstallBytes - d.BaseInstallBytes
+}
+
+// SizeBudget limits how large a build may be, or how much it may grow, per device variant. Zero fields are not checked.
+type SizeBudget struct {
+	// MaxDownloadBytes is the largest download size allowed for any variant.
+	MaxDownloadBytes int64
+	// MaxDownloadGrowthBytes is the largest growth in download size allowed for any variant.
+	MaxDownloadGrowthBytes int64
+	// MaxDownloadGrowthPercent is the largest growth in download size allowed for any variant, as a
+	// percentage of the base build's size.
+	MaxDownloadGrowthPercent float64
+}
+
+// ErrSizeBudgetExceeded happens when one or more device variants exceed a SizeBudget.
+type ErrSizeBudgetExceeded struct {
+	Violations []BuildSizeDelta
+}
+
+func (e ErrSizeBudgetExceeded) Error() string {
+	variants := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		variants = append(variants, fmt.Sprintf("%s/%s (%+d bytes)", v.DeviceModel, v.OSVersion, v.DownloadGrowth()))
+	}
+
+	return fmt.Sprintf("download size budget exceeded for %s", strings.Join(variants, ", "))
+}
+
+// Check returns an ErrSizeBudgetExceeded listing every variant over budget, or nil. Variants missing
+// from the newer build are ignored, and growth limits only apply to variants present in both builds.
+func (b SizeBudget) Check(deltas []BuildSizeDelta) error {
+	var violations []BuildSizeDelta
+
+	for _, delta := range deltas {
+		if delta.DownloadBytes == 0 {
+			continue
+		}
+
+		exceeded := b.MaxDownloadBytes > 0 && delta.DownloadBytes > b.MaxDownloadBytes
+
+		if delta.BaseDownloadBytes > 0 {
+			growth := delta.DownloadGrowth()
+			exceeded = exceeded || (b.MaxDownloadGrowthBytes > 0 && growth > b.MaxDownloadGrowthBytes)
+			exceeded = exceeded || (b.MaxDownloadGrowthPercent > 0 && float64(growth)*100/float64(delta.BaseDownloadBytes) > b.MaxDownloadGrowthPercent)
+		}
+
+		if exceeded {
+			violations = append(violations, delta)
+		}
+	}
+
+	if len(violations) > 0 {
+		return ErrSizeBudgetExceeded{Violations: violations}
+	}
+
+	return nil
+}
+
+// CompareBuildBundleFileSizes pairs the file sizes of two build bundles by device model and OS version.
+// The result is sorted by device model, then OS version.
+func CompareBuildBundleFileSizes(base, head []BuildBundleFileSize) []BuildSizeDelta {
+	type variant struct {
+		deviceModel string
+		osVersion   string
+	}
+
+	deltas := make(map[variant]*BuildSizeDelta)
+	get := func(size BuildBundleFileSize) (*BuildSizeDelta, int64, int64) {
+		var key variant
+
+		var download, install int64
+
+		if a := size.Attributes; a != nil {
+			if a.DeviceModel != nil {
+				key.deviceModel = *a.DeviceModel
+			}
+
+			if a.OSVersion != nil {
+				key.osVersion = *a.OSVersion
+			}
+
+			if a.DownloadBytes != nil {
+				download = *a.DownloadBytes
+			}
+
+			if a.InstallBytes != nil {
+				install = *a.InstallBytes
+			}
+		}
+
+		delta, ok := deltas[key]
+		if !ok {
+			delta = &BuildSizeDelta{DeviceModel: key.deviceModel, OSVersion: key.osVersion}
+			deltas[key] = delta
+		}
+
+		return delta, download, install
+	}
+
+	for _, size := range base {
+		delta, download, install := get(size)
+		delta.BaseDownloadBytes = download
+		delta.BaseInstallBytes = install
+	}
+
+	for _, size := range head {
+		delta, download, install := get(size)
+		delta.DownloadBytes = download
+		delta.InstallBytes = install
+	}
+
+	result := make([]BuildSizeDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		result = append(result, *delta)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DeviceModel != result[j].DeviceModel {
+			return result[i].DeviceModel < result[j].DeviceModel
+		}
+
+		return result[i].OSVersion < result[j].OSVersion
+	})
+
+	return result
+}
+
+// CompareBuildSizes compares the per-device sizes of the app bundle of two builds, such as the last
+// release and a candidate, so that the result can be checked against a SizeBudget.
+func (s *BuildsService) CompareBuildSizes(ctx context.Context, baseBuildID, buildID string) ([]BuildSizeDelta, error) {
+	base, err := s.listAppBundleFileSizes(ctx, baseBuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := s.listAppBundleFileSizes(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareBuildBundleFileSizes(base, head), nil
+}
+
+func (s *BuildsService) listAppBundleFileSizes(ctx context.Context, buildID string) ([]BuildBundleFileSize, error) {
+	build, _, err := s.GetBuild(ctx, buildID, &GetBuildQuery{
+		Include:           []string{"buildBundles"},
+		LimitBuildBundles: 50,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var bundleID string
+
+	for i := range build.Included {
+		bundle := build.Included[i].BuildBundle()
+		if bundle != nil && bundle.Attributes != nil && bundle.Attributes.BundleType != nil && *bundle.Attributes.BundleType == BuildBundleTypeApp {
+			bundleID = bundle.ID
+
+			break
+		}
+	}
+
+	if bundleID == "" {
+		return nil, ErrNoAppBundle{BuildID: buildID}
+	}
+
+	var sizes []BuildBundleFileSize
+
+	query := &ListBuildBundleFileSizesQuery{Limit: 200}
+
+	for {
+		res, _, err := s.ListBuildBundleFileSizes(ctx, bundleID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		sizes = append(sizes, res.Data...)
+
+		if res.Links.Next == nil {
+			return sizes, nil
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+}
@@ -0,0 +1,140 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBuildBundleFileSizes(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BuildBundleFileSizesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Builds.ListBuildBundleFileSizes(ctx, "10", &ListBuildBundleFileSizesQuery{})
+	})
+}
+
+func TestGetAppClipDomainCacheStatusForBuildBundle(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &AppClipDomainStatusResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Builds.GetAppClipDomainCacheStatusForBuildBundle(ctx, "10", &GetAppClipDomainStatusQuery{})
+	})
+}
+
+func TestGetAppClipDomainDebugStatusForBuildBundle(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &AppClipDomainStatusResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Builds.GetAppClipDomainDebugStatusForBuildBundle(ctx, "10", &GetAppClipDomainStatusQuery{})
+	})
+}
+
+func testFileSize(deviceModel, osVersion string, download, install int64) BuildBundleFileSize {
+	return BuildBundleFileSize{Attributes: &BuildBundleFileSizeAttributes{
+		DeviceModel:   String(deviceModel),
+		OSVersion:     String(osVersion),
+		DownloadBytes: &download,
+		InstallBytes:  &install,
+	}}
+}
+
+func TestCompareBuildBundleFileSizes(t *testing.T) {
+	t.Parallel()
+
+	base := []BuildBundleFileSize{
+		testFileSize("iPhone15,2", "17.0", 100, 200),
+		testFileSize("Universal", "", 150, 300),
+		testFileSize("iPad13,4", "16.0", 90, 180),
+	}
+	head := []BuildBundleFileSize{
+		testFileSize("Universal", "", 160, 320),
+		testFileSize("iPhone15,2", "17.0", 130, 260),
+		testFileSize("iPhone16,1", "18.0", 110, 220),
+	}
+
+	deltas := CompareBuildBundleFileSizes(base, head)
+	assert.Equal(t, []BuildSizeDelta{
+		{DeviceModel: "Universal", BaseDownloadBytes: 150, DownloadBytes: 160, BaseInstallBytes: 300, InstallBytes: 320},
+		{DeviceModel: "iPad13,4", OSVersion: "16.0", BaseDownloadBytes: 90, BaseInstallBytes: 180},
+		{DeviceModel: "iPhone15,2", OSVersion: "17.0", BaseDownloadBytes: 100, DownloadBytes: 130, BaseInstallBytes: 200, InstallBytes: 260},
+		{DeviceModel: "iPhone16,1", OSVersion: "18.0", DownloadBytes: 110, InstallBytes: 220},
+	}, deltas)
+	assert.Equal(t, int64(30), deltas[2].DownloadGrowth())
+	assert.Equal(t, int64(60), deltas[2].InstallGrowth())
+}
+
+func TestSizeBudgetCheck(t *testing.T) {
+	t.Parallel()
+
+	deltas := []BuildSizeDelta{
+		{DeviceModel: "Universal", BaseDownloadBytes: 150, DownloadBytes: 160},
+		{DeviceModel: "iPad13,4", OSVersion: "16.0", BaseDownloadBytes: 90},
+		{DeviceModel: "iPhone15,2", OSVersion: "17.0", BaseDownloadBytes: 100, DownloadBytes: 130},
+		{DeviceModel: "iPhone16,1", OSVersion: "18.0", DownloadBytes: 110},
+	}
+
+	assert.NoError(t, SizeBudget{}.Check(deltas))
+	assert.NoError(t, SizeBudget{MaxDownloadBytes: 200, MaxDownloadGrowthBytes: 30, MaxDownloadGrowthPercent: 30}.Check(deltas))
+
+	err := SizeBudget{MaxDownloadGrowthPercent: 10}.Check(deltas)
+	assert.Equal(t, ErrSizeBudgetExceeded{Violations: deltas[2:3]}, err)
+	assert.Contains(t, err.Error(), "iPhone15,2/17.0 (+30 bytes)")
+
+	err = SizeBudget{MaxDownloadBytes: 120}.Check(deltas)
+	assert.Equal(t, ErrSizeBudgetExceeded{Violations: []BuildSizeDelta{deltas[0], deltas[2]}}, err)
+}
+
+func TestCompareBuildSizes(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds/base": {`{"data":{"id":"base"},"included":[{"type":"buildBundles","id":"clip-1","attributes":{"bundleType":"APP_CLIP"}},{"type":"buildBundles","id":"app-1","attributes":{"bundleType":"APP"}}]}`},
+		"GET /v1/builds/head": {`{"data":{"id":"head"},"included":[{"type":"buildBundles","id":"app-2","attributes":{"bundleType":"APP"}}]}`},
+		"GET /v1/buildBundles/app-1/buildBundleFileSizes": {
+			`{"data":[{"id":"1","attributes":{"deviceModel":"Universal","downloadBytes":100,"installBytes":200}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/buildBundles/app-1/buildBundleFileSizes?cursor=abc"}}`,
+			`{"data":[{"id":"2","attributes":{"deviceModel":"iPhone15,2","osVersion":"17.0","downloadBytes":90,"installBytes":180}}],"links":{"self":""}}`,
+		},
+		"GET /v1/buildBundles/app-2/buildBundleFileSizes": {
+			`{"data":[{"id":"3","attributes":{"deviceModel":"Universal","downloadBytes":140,"installBytes":260}}],"links":{"self":""}}`,
+		},
+	})
+
+	deltas, err := client.Builds.CompareBuildSizes(context.Background(), "base", "head")
+	assert.NoError(t, err)
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, int64(40), deltas[0].DownloadGrowth())
+	assert.Error(t, SizeBudget{MaxDownloadGrowthBytes: 25}.Check(deltas))
+}
+
+func TestCompareBuildSizesNoAppBundle(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds/base": {`{"data":{"id":"base"}}`},
+	})
+
+	_, err := client.Builds.CompareBuildSizes(context.Background(), "base", "head")
+	assert.Equal(t, ErrNoAppBundle{BuildID: "base"}, err)
+}
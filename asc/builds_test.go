@@ -58,7 +58,7 @@ func TestGetBuildIncludeds(t *testing.T) {
 		{"type":"preReleaseVersions"},{"type":"betaTesters"},{"type":"betaBuildLocalizations"},
 		{"type":"appEncryptionDeclarations"},{"type":"betaAppReviewSubmissions"},{"type":"apps"},
 		{"type":"buildBetaDetails"},{"type":"appStoreVersions"},{"type":"buildIcons"},
-		{"type":"perfPowerMetrics"},{"type":"diagnosticSignatures"}
+		{"type":"perfPowerMetrics"},{"type":"diagnosticSignatures"},{"type":"buildBundles"}
 		]}`, func(ctx context.Context, client *Client) {
 		build, _, err := client.Builds.GetBuild(ctx, "10", &GetBuildQuery{})
 		assert.NoError(t, err)
@@ -75,6 +75,7 @@ func TestGetBuildIncludeds(t *testing.T) {
 		assert.NotNil(t, build.Included[8].BuildIcon())
 		assert.NotNil(t, build.Included[9].PerfPowerMetric())
 		assert.NotNil(t, build.Included[10].DiagnosticSignature())
+		assert.NotNil(t, build.Included[11].BuildBundle())
 
 		assert.Nil(t, build.Included[0].BetaTester())
 		assert.Nil(t, build.Included[0].BetaBuildLocalization())
@@ -86,6 +87,7 @@ func TestGetBuildIncludeds(t *testing.T) {
 		assert.Nil(t, build.Included[0].BuildIcon())
 		assert.Nil(t, build.Included[0].PerfPowerMetric())
 		assert.Nil(t, build.Included[0].DiagnosticSignature())
+		assert.Nil(t, build.Included[0].BuildBundle())
 	})
 }
 
@@ -0,0 +1,115 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+// CIService handles communication with Xcode Cloud methods of the App Store Connect API
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcode_cloud_workflows_and_builds
+type CIService service
+
+// CiActionType defines model for CiActionType.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciactiontype
+type CiActionType string
+
+const (
+	// CiActionTypeBuild is an action type for Build.
+	CiActionTypeBuild CiActionType = "BUILD"
+	// CiActionTypeAnalyze is an action type for Analyze.
+	CiActionTypeAnalyze CiActionType = "ANALYZE"
+	// CiActionTypeTest is an action type for Test.
+	CiActionTypeTest CiActionType = "TEST"
+	// CiActionTypeArchive is an action type for Archive.
+	CiActionTypeArchive CiActionType = "ARCHIVE"
+)
+
+// CiExecutionProgress defines model for CiExecutionProgress.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciexecutionprogress
+type CiExecutionProgress string
+
+const (
+	// CiExecutionProgressPending is an execution progress for Pending.
+	CiExecutionProgressPending CiExecutionProgress = "PENDING"
+	// CiExecutionProgressRunning is an execution progress for Running.
+	CiExecutionProgressRunning CiExecutionProgress = "RUNNING"
+	// CiExecutionProgressComplete is an execution progress for Complete.
+	CiExecutionProgressComplete CiExecutionProgress = "COMPLETE"
+)
+
+// CiCompletionStatus defines model for CiCompletionStatus.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cicompletionstatus
+type CiCompletionStatus string
+
+const (
+	// CiCompletionStatusSucceeded is a completion status for Succeeded.
+	CiCompletionStatusSucceeded CiCompletionStatus = "SUCCEEDED"
+	// CiCompletionStatusFailed is a completion status for Failed.
+	CiCompletionStatusFailed CiCompletionStatus = "FAILED"
+	// CiCompletionStatusErrored is a completion status for Errored.
+	CiCompletionStatusErrored CiCompletionStatus = "ERRORED"
+	// CiCompletionStatusCanceled is a completion status for Canceled.
+	CiCompletionStatusCanceled CiCompletionStatus = "CANCELED"
+	// CiCompletionStatusSkipped is a completion status for Skipped.
+	CiCompletionStatusSkipped CiCompletionStatus = "SKIPPED"
+)
+
+// CiIssueCounts defines model for CiIssueCounts.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissuecounts
+type CiIssueCounts struct {
+	AnalyzerWarnings *int `json:"analyzerWarnings,omitempty"`
+	Errors           *int `json:"errors,omitempty"`
+	TestFailures     *int `json:"testFailures,omitempty"`
+	Warnings         *int `json:"warnings,omitempty"`
+}
+
+// FileLocation defines model for FileLocation.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/filelocation
+type FileLocation struct {
+	LineNumber *int    `json:"lineNumber,omitempty"`
+	Path       *string `json:"path,omitempty"`
+}
+
+// CiTestDestinationKind defines model for CiTestDestinationKind.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestdestinationkind
+type CiTestDestinationKind string
+
+const (
+	// CiTestDestinationKindSimulator is a test destination kind for Simulator.
+	CiTestDestinationKindSimulator CiTestDestinationKind = "SIMULATOR"
+	// CiTestDestinationKindMac is a test destination kind for Mac.
+	CiTestDestinationKindMac CiTestDestinationKind = "MAC"
+)
+
+// CiTestDestination defines model for CiTestDestination.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestdestination
+type CiTestDestination struct {
+	DeviceTypeIdentifier *string                `json:"deviceTypeIdentifier,omitempty"`
+	DeviceTypeName       *string                `json:"deviceTypeName,omitempty"`
+	Kind                 *CiTestDestinationKind `json:"kind,omitempty"`
+	RuntimeIdentifier    *string                `json:"runtimeIdentifier,omitempty"`
+	RuntimeName          *string                `json:"runtimeName,omitempty"`
+}
@@ -0,0 +1,120 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMissingArtifactURL happens when DownloadCiArtifact is called for an artifact that has no download URL,
+// which is the case when the artifact was fetched without its downloadUrl field.
+var ErrMissingArtifactURL = errors.New("artifact has no download URL")
+
+// CiArtifactFileType defines model for CiArtifact.Attributes.FileType
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciartifact/attributes
+type CiArtifactFileType string
+
+const (
+	// CiArtifactFileTypeArchive is an artifact file type for an Xcode archive.
+	CiArtifactFileTypeArchive CiArtifactFileType = "ARCHIVE"
+	// CiArtifactFileTypeArchiveExport is an artifact file type for an exported archive.
+	CiArtifactFileTypeArchiveExport CiArtifactFileType = "ARCHIVE_EXPORT"
+	// CiArtifactFileTypeLogBundle is an artifact file type for build logs.
+	CiArtifactFileTypeLogBundle CiArtifactFileType = "LOG_BUNDLE"
+	// CiArtifactFileTypeResultBundle is an artifact file type for an Xcode result bundle.
+	CiArtifactFileTypeResultBundle CiArtifactFileType = "RESULT_BUNDLE"
+	// CiArtifactFileTypeTestProducts is an artifact file type for test products.
+	CiArtifactFileTypeTestProducts CiArtifactFileType = "TEST_PRODUCTS"
+	// CiArtifactFileTypeXcodebuildProducts is an artifact file type for xcodebuild products.
+	CiArtifactFileTypeXcodebuildProducts CiArtifactFileType = "XCODEBUILD_PRODUCTS"
+	// CiArtifactFileTypeStapledNotarizedArchive is an artifact file type for a notarized archive.
+	CiArtifactFileTypeStapledNotarizedArchive CiArtifactFileType = "STAPLED_NOTARIZED_ARCHIVE"
+)
+
+// CiArtifact defines model for CiArtifact.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciartifact
+type CiArtifact struct {
+	Attributes *CiArtifactAttributes `json:"attributes,omitempty"`
+	ID         string                `json:"id"`
+	Links      ResourceLinks         `json:"links"`
+	Type       string                `json:"type"`
+}
+
+// CiArtifactAttributes defines model for CiArtifact.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciartifact/attributes
+type CiArtifactAttributes struct {
+	DownloadURL *string             `json:"downloadUrl,omitempty"`
+	FileName    *string             `json:"fileName,omitempty"`
+	FileSize    *int64              `json:"fileSize,omitempty"`
+	FileType    *CiArtifactFileType `json:"fileType,omitempty"`
+}
+
+// CiArtifactResponse defines model for CiArtifactResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciartifactresponse
+type CiArtifactResponse struct {
+	Data  CiArtifact    `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// CiArtifactsResponse defines model for CiArtifactsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciartifactsresponse
+type CiArtifactsResponse struct {
+	Data  []CiArtifact       `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// GetCiArtifactQuery are query options for GetCiArtifact
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/download_artifact_information
+type GetCiArtifactQuery struct {
+	FieldsCiArtifacts []string `url:"fields[ciArtifacts],omitempty"`
+}
+
+// GetCiArtifact gets a specific Xcode Cloud artifact, including a fresh download URL.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/download_artifact_information
+func (s *CIService) GetCiArtifact(ctx context.Context, id string, params *GetCiArtifactQuery) (*CiArtifactResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciArtifacts/%s", id)
+	res := new(CiArtifactResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DownloadCiArtifact writes the contents of an artifact to w. Download URLs expire, so the artifact
+// should be fetched shortly before downloading. The URL is presigned, and is requested without the
+// API token.
+func (s *CIService) DownloadCiArtifact(ctx context.Context, artifact *CiArtifact, w io.Writer) (*Response, error) {
+	if artifact.Attributes == nil || artifact.Attributes.DownloadURL == nil {
+		return nil, ErrMissingArtifactURL
+	}
+
+	return s.client.download(ctx, *artifact.Attributes.DownloadURL, w)
+}
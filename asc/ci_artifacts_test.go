@@ -0,0 +1,90 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCiArtifact(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiArtifactResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiArtifact(ctx, "10", &GetCiArtifactQuery{})
+	})
+}
+
+func TestDownloadCiArtifact(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /artifacts/logs.zip": {"zip bytes"},
+	})
+
+	artifact := &CiArtifact{Attributes: &CiArtifactAttributes{
+		DownloadURL: String(client.baseURL.String() + "artifacts/logs.zip"),
+	}}
+
+	var buf bytes.Buffer
+	_, err := client.CI.DownloadCiArtifact(context.Background(), artifact, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "zip bytes", buf.String())
+	assert.Equal(t, 1, server.requests["GET /artifacts/logs.zip"])
+}
+
+func TestDownloadCiArtifactWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("zip bytes"))
+	}))
+	defer server.Close()
+
+	transport := &AuthTransport{jwtGenerator: &mockJWTGenerator{token: "TEST.TEST.TEST"}}
+	client := NewClient(transport.Client())
+
+	artifact := &CiArtifact{Attributes: &CiArtifactAttributes{DownloadURL: String(server.URL + "/logs.zip")}}
+
+	var buf bytes.Buffer
+	_, err := client.CI.DownloadCiArtifact(context.Background(), artifact, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "zip bytes", buf.String())
+	assert.Empty(t, authorization)
+}
+
+func TestDownloadCiArtifactMissingURL(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(nil)
+
+	var buf bytes.Buffer
+	_, err := client.CI.DownloadCiArtifact(context.Background(), &CiArtifact{}, &buf)
+	assert.Equal(t, ErrMissingArtifactURL, err)
+}
@@ -0,0 +1,196 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiBuildAction defines model for CiBuildAction.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildaction
+type CiBuildAction struct {
+	Attributes    *CiBuildActionAttributes    `json:"attributes,omitempty"`
+	ID            string                      `json:"id"`
+	Links         ResourceLinks               `json:"links"`
+	Relationships *CiBuildActionRelationships `json:"relationships,omitempty"`
+	Type          string                      `json:"type"`
+}
+
+// CiBuildActionAttributes defines model for CiBuildAction.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildaction/attributes
+type CiBuildActionAttributes struct {
+	ActionType        *CiActionType        `json:"actionType,omitempty"`
+	CompletionStatus  *CiCompletionStatus  `json:"completionStatus,omitempty"`
+	ExecutionProgress *CiExecutionProgress `json:"executionProgress,omitempty"`
+	FinishedDate      *DateTime            `json:"finishedDate,omitempty"`
+	IsRequiredToPass  *bool                `json:"isRequiredToPass,omitempty"`
+	IssueCounts       *CiIssueCounts       `json:"issueCounts,omitempty"`
+	Name              *string              `json:"name,omitempty"`
+	StartedDate       *DateTime            `json:"startedDate,omitempty"`
+}
+
+// CiBuildActionRelationships defines model for CiBuildAction.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildaction/relationships
+type CiBuildActionRelationships struct {
+	Artifacts   *PagedRelationship `json:"artifacts,omitempty"`
+	BuildRun    *Relationship      `json:"buildRun,omitempty"`
+	Issues      *PagedRelationship `json:"issues,omitempty"`
+	TestResults *PagedRelationship `json:"testResults,omitempty"`
+}
+
+// CiBuildActionResponse defines model for CiBuildActionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildactionresponse
+type CiBuildActionResponse struct {
+	Data     CiBuildAction                   `json:"data"`
+	Included []CiBuildActionResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                   `json:"links"`
+}
+
+// CiBuildActionsResponse defines model for CiBuildActionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildactionsresponse
+type CiBuildActionsResponse struct {
+	Data     []CiBuildAction                 `json:"data"`
+	Included []CiBuildActionResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks              `json:"links"`
+	Meta     *PagingInformation              `json:"meta,omitempty"`
+}
+
+// CiBuildActionResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiBuildActionResponse or CiBuildActionsResponse.
+type CiBuildActionResponseIncluded included
+
+// GetCiBuildActionQuery are query options for GetCiBuildAction
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_build_action_information
+type GetCiBuildActionQuery struct {
+	FieldsCiBuildActions []string `url:"fields[ciBuildActions],omitempty"`
+	FieldsCiBuildRuns    []string `url:"fields[ciBuildRuns],omitempty"`
+	Include              []string `url:"include,omitempty"`
+}
+
+// GetCiBuildRunForCiBuildActionQuery are query options for GetCiBuildRunForCiBuildAction
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_build_run_information_of_a_build_action
+type GetCiBuildRunForCiBuildActionQuery struct {
+	FieldsCiBuildRuns []string `url:"fields[ciBuildRuns],omitempty"`
+}
+
+// ListCiArtifactsForCiBuildActionQuery are query options for ListCiArtifactsForCiBuildAction
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_artifacts_for_a_build_action
+type ListCiArtifactsForCiBuildActionQuery struct {
+	FieldsCiArtifacts []string `url:"fields[ciArtifacts],omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// ListCiIssuesForCiBuildActionQuery are query options for ListCiIssuesForCiBuildAction
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_issues_for_a_build_action
+type ListCiIssuesForCiBuildActionQuery struct {
+	FieldsCiIssues []string `url:"fields[ciIssues],omitempty"`
+	Limit          int      `url:"limit,omitempty"`
+	Cursor         string   `url:"cursor,omitempty"`
+}
+
+// ListCiTestResultsForCiBuildActionQuery are query options for ListCiTestResultsForCiBuildAction
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_test_results_for_a_build_action
+type ListCiTestResultsForCiBuildActionQuery struct {
+	FieldsCiTestResults []string `url:"fields[ciTestResults],omitempty"`
+	Limit               int      `url:"limit,omitempty"`
+	Cursor              string   `url:"cursor,omitempty"`
+}
+
+// GetCiBuildAction gets a specific action of an Xcode Cloud build run.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_build_action_information
+func (s *CIService) GetCiBuildAction(ctx context.Context, id string, params *GetCiBuildActionQuery) (*CiBuildActionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildActions/%s", id)
+	res := new(CiBuildActionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetCiBuildRunForCiBuildAction gets the build run that a build action belongs to.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_build_run_information_of_a_build_action
+func (s *CIService) GetCiBuildRunForCiBuildAction(ctx context.Context, id string, params *GetCiBuildRunForCiBuildActionQuery) (*CiBuildRunResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildActions/%s/buildRun", id)
+	res := new(CiBuildRunResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiArtifactsForCiBuildAction lists the artifacts, such as logs, archives and result bundles, that a build action produced.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_artifacts_for_a_build_action
+func (s *CIService) ListCiArtifactsForCiBuildAction(ctx context.Context, id string, params *ListCiArtifactsForCiBuildActionQuery) (*CiArtifactsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildActions/%s/artifacts", id)
+	res := new(CiArtifactsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiIssuesForCiBuildAction lists the errors, warnings and test failures that a build action reported.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_issues_for_a_build_action
+func (s *CIService) ListCiIssuesForCiBuildAction(ctx context.Context, id string, params *ListCiIssuesForCiBuildActionQuery) (*CiIssuesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildActions/%s/issues", id)
+	res := new(CiIssuesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiTestResultsForCiBuildAction lists the test results of a test action.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_test_results_for_a_build_action
+func (s *CIService) ListCiTestResultsForCiBuildAction(ctx context.Context, id string, params *ListCiTestResultsForCiBuildActionQuery) (*CiTestResultsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildActions/%s/testResults", id)
+	res := new(CiTestResultsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiBuildActionResponseIncluded.
+func (i *CiBuildActionResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// CiBuildRun returns the CiBuildRun stored within, if one is present.
+func (i *CiBuildActionResponseIncluded) CiBuildRun() *CiBuildRun {
+	return extractIncludedCiBuildRun(i.inner)
+}
@@ -0,0 +1,80 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCiBuildAction(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildActionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiBuildAction(ctx, "10", &GetCiBuildActionQuery{})
+	})
+}
+
+func TestGetCiBuildRunForCiBuildAction(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildRunResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiBuildRunForCiBuildAction(ctx, "10", &GetCiBuildRunForCiBuildActionQuery{})
+	})
+}
+
+func TestListCiArtifactsForCiBuildAction(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiArtifactsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiArtifactsForCiBuildAction(ctx, "10", &ListCiArtifactsForCiBuildActionQuery{})
+	})
+}
+
+func TestListCiIssuesForCiBuildAction(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiIssuesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiIssuesForCiBuildAction(ctx, "10", &ListCiIssuesForCiBuildActionQuery{})
+	})
+}
+
+func TestListCiTestResultsForCiBuildAction(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiTestResultsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiTestResultsForCiBuildAction(ctx, "10", &ListCiTestResultsForCiBuildActionQuery{})
+	})
+}
+
+func TestGetCiBuildActionIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"ciBuildRuns"}]}`, func(ctx context.Context, client *Client) {
+		action, _, err := client.CI.GetCiBuildAction(ctx, "10", &GetCiBuildActionQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, action.Included)
+
+		assert.NotNil(t, action.Included[0].CiBuildRun())
+	})
+}
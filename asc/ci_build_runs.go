@@ -0,0 +1,302 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultCiBuildRunPollInterval = 30 * time.Second
+
+// CiBuildRunStartReason defines model for CiBuildRun.Attributes.StartReason
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrun/attributes
+type CiBuildRunStartReason string
+
+const (
+	// CiBuildRunStartReasonGitRefChange is a start reason for a push to a branch or tag.
+	CiBuildRunStartReasonGitRefChange CiBuildRunStartReason = "GIT_REF_CHANGE"
+	// CiBuildRunStartReasonManual is a start reason for a manually started build.
+	CiBuildRunStartReasonManual CiBuildRunStartReason = "MANUAL"
+	// CiBuildRunStartReasonManualRebuild is a start reason for a manual rebuild.
+	CiBuildRunStartReasonManualRebuild CiBuildRunStartReason = "MANUAL_REBUILD"
+	// CiBuildRunStartReasonPullRequestOpen is a start reason for an opened pull request.
+	CiBuildRunStartReasonPullRequestOpen CiBuildRunStartReason = "PULL_REQUEST_OPEN"
+	// CiBuildRunStartReasonPullRequestUpdate is a start reason for an updated pull request.
+	CiBuildRunStartReasonPullRequestUpdate CiBuildRunStartReason = "PULL_REQUEST_UPDATE"
+	// CiBuildRunStartReasonSchedule is a start reason for a scheduled build.
+	CiBuildRunStartReasonSchedule CiBuildRunStartReason = "SCHEDULE"
+)
+
+// CiBuildRun defines model for CiBuildRun.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrun
+type CiBuildRun struct {
+	Attributes    *CiBuildRunAttributes    `json:"attributes,omitempty"`
+	ID            string                   `json:"id"`
+	Links         ResourceLinks            `json:"links"`
+	Relationships *CiBuildRunRelationships `json:"relationships,omitempty"`
+	Type          string                   `json:"type"`
+}
+
+// CiBuildRunAttributes defines model for CiBuildRun.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrun/attributes
+type CiBuildRunAttributes struct {
+	CancelReason       *string                `json:"cancelReason,omitempty"`
+	CompletionStatus   *CiCompletionStatus    `json:"completionStatus,omitempty"`
+	CreatedDate        *DateTime              `json:"createdDate,omitempty"`
+	DestinationCommit  *CiBuildRunCommit      `json:"destinationCommit,omitempty"`
+	ExecutionProgress  *CiExecutionProgress   `json:"executionProgress,omitempty"`
+	FinishedDate       *DateTime              `json:"finishedDate,omitempty"`
+	IsPullRequestBuild *bool                  `json:"isPullRequestBuild,omitempty"`
+	IssueCounts        *CiIssueCounts         `json:"issueCounts,omitempty"`
+	Number             *int                   `json:"number,omitempty"`
+	SourceCommit       *CiBuildRunCommit      `json:"sourceCommit,omitempty"`
+	StartReason        *CiBuildRunStartReason `json:"startReason,omitempty"`
+	StartedDate        *DateTime              `json:"startedDate,omitempty"`
+}
+
+// CiBuildRunCommit defines model for CiBuildRun.Attributes.SourceCommit and CiBuildRun.Attributes.DestinationCommit
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrun/attributes/sourcecommit
+type CiBuildRunCommit struct {
+	Author    *CiGitUser `json:"author,omitempty"`
+	CommitSha *string    `json:"commitSha,omitempty"`
+	Committer *CiGitUser `json:"committer,omitempty"`
+	Message   *string    `json:"message,omitempty"`
+	WebURL    *string    `json:"webUrl,omitempty"`
+}
+
+// CiGitUser defines model for CiGitUser.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cigituser
+type CiGitUser struct {
+	AvatarURL   *string `json:"avatarUrl,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+// CiBuildRunRelationships defines model for CiBuildRun.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrun/relationships
+type CiBuildRunRelationships struct {
+	Actions           *PagedRelationship `json:"actions,omitempty"`
+	Builds            *PagedRelationship `json:"builds,omitempty"`
+	DestinationBranch *Relationship      `json:"destinationBranch,omitempty"`
+	Product           *Relationship      `json:"product,omitempty"`
+	PullRequest       *Relationship      `json:"pullRequest,omitempty"`
+	SourceBranchOrTag *Relationship      `json:"sourceBranchOrTag,omitempty"`
+	Workflow          *Relationship      `json:"workflow,omitempty"`
+}
+
+// IsComplete reports whether the build run has finished, whatever its outcome.
+func (r *CiBuildRun) IsComplete() bool {
+	return r.Attributes != nil && r.Attributes.ExecutionProgress != nil && *r.Attributes.ExecutionProgress == CiExecutionProgressComplete
+}
+
+// CiBuildRunResponse defines model for CiBuildRunResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrunresponse
+type CiBuildRunResponse struct {
+	Data     CiBuildRun                   `json:"data"`
+	Included []CiBuildRunResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                `json:"links"`
+}
+
+// CiBuildRunsResponse defines model for CiBuildRunsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildrunsresponse
+type CiBuildRunsResponse struct {
+	Data     []CiBuildRun                 `json:"data"`
+	Included []CiBuildRunResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks           `json:"links"`
+	Meta     *PagingInformation           `json:"meta,omitempty"`
+}
+
+// CiBuildRunResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiBuildRunResponse or CiBuildRunsResponse.
+type CiBuildRunResponseIncluded included
+
+// ciBuildRunCreateRequest defines model for CiBuildRunCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildruncreaterequest/data
+type ciBuildRunCreateRequest struct {
+	Attributes    *CiBuildRunCreateRequestAttributes   `json:"attributes,omitempty"`
+	Relationships ciBuildRunCreateRequestRelationships `json:"relationships"`
+	Type          string                               `json:"type"`
+}
+
+// CiBuildRunCreateRequestAttributes are attributes for CiBuildRunCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildruncreaterequest/data/attributes
+type CiBuildRunCreateRequestAttributes struct {
+	Clean *bool `json:"clean,omitempty"`
+}
+
+// ciBuildRunCreateRequestRelationships are relationships for CiBuildRunCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibuildruncreaterequest/data/relationships
+type ciBuildRunCreateRequestRelationships struct {
+	BuildRun          *relationshipDeclaration `json:"buildRun,omitempty"`
+	PullRequest       *relationshipDeclaration `json:"pullRequest,omitempty"`
+	SourceBranchOrTag *relationshipDeclaration `json:"sourceBranchOrTag,omitempty"`
+	Workflow          *relationshipDeclaration `json:"workflow,omitempty"`
+}
+
+// GetCiBuildRunQuery are query options for GetCiBuildRun
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_build_run_information
+type GetCiBuildRunQuery struct {
+	FieldsBuilds      []string `url:"fields[builds],omitempty"`
+	FieldsCiBuildRuns []string `url:"fields[ciBuildRuns],omitempty"`
+	Include           []string `url:"include,omitempty"`
+	LimitBuilds       int      `url:"limit[builds],omitempty"`
+}
+
+// ListCiBuildActionsForCiBuildRunQuery are query options for ListCiBuildActionsForCiBuildRun
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_actions_for_a_build_run
+type ListCiBuildActionsForCiBuildRunQuery struct {
+	FieldsCiBuildActions []string `url:"fields[ciBuildActions],omitempty"`
+	FieldsCiBuildRuns    []string `url:"fields[ciBuildRuns],omitempty"`
+	Include              []string `url:"include,omitempty"`
+	Limit                int      `url:"limit,omitempty"`
+	Cursor               string   `url:"cursor,omitempty"`
+}
+
+// ListBuildsForCiBuildRunQuery are query options for ListBuildsForCiBuildRun
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_builds_for_a_build_run
+type ListBuildsForCiBuildRunQuery struct {
+	FieldsBuilds []string `url:"fields[builds],omitempty"`
+	Include      []string `url:"include,omitempty"`
+	Limit        int      `url:"limit,omitempty"`
+	Cursor       string   `url:"cursor,omitempty"`
+}
+
+// GetCiBuildRun gets a specific Xcode Cloud build run.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_build_run_information
+func (s *CIService) GetCiBuildRun(ctx context.Context, id string, params *GetCiBuildRunQuery) (*CiBuildRunResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildRuns/%s", id)
+	res := new(CiBuildRunResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// CreateCiBuildRun starts an Xcode Cloud build. Either start a workflow, optionally on a specific branch or
+// tag (an scmGitReferences ID) or pull request (an scmPullRequests ID), or rebuild an earlier build run by
+// passing its ID as buildRunID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/start_a_build
+func (s *CIService) CreateCiBuildRun(ctx context.Context, attributes *CiBuildRunCreateRequestAttributes, workflowID *string, sourceBranchOrTagID *string, pullRequestID *string, buildRunID *string) (*CiBuildRunResponse, *Response, error) {
+	req := ciBuildRunCreateRequest{
+		Attributes: attributes,
+		Relationships: ciBuildRunCreateRequestRelationships{
+			BuildRun:          newRelationshipDeclaration(buildRunID, "ciBuildRuns"),
+			PullRequest:       newRelationshipDeclaration(pullRequestID, "scmPullRequests"),
+			SourceBranchOrTag: newRelationshipDeclaration(sourceBranchOrTagID, "scmGitReferences"),
+			Workflow:          newRelationshipDeclaration(workflowID, "ciWorkflows"),
+		},
+		Type: "ciBuildRuns",
+	}
+	res := new(CiBuildRunResponse)
+	resp, err := s.client.post(ctx, "v1/ciBuildRuns", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// ListCiBuildActionsForCiBuildRun lists the actions, such as build, test and archive, of an Xcode Cloud build run.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_actions_for_a_build_run
+func (s *CIService) ListCiBuildActionsForCiBuildRun(ctx context.Context, id string, params *ListCiBuildActionsForCiBuildRunQuery) (*CiBuildActionsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildRuns/%s/actions", id)
+	res := new(CiBuildActionsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListBuildsForCiBuildRun lists the App Store Connect builds created by an Xcode Cloud build run.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_builds_for_a_build_run
+func (s *CIService) ListBuildsForCiBuildRun(ctx context.Context, id string, params *ListBuildsForCiBuildRunQuery) (*BuildsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciBuildRuns/%s/builds", id)
+	res := new(BuildsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// WaitForCiBuildRun polls a build run every pollInterval until it is complete, and returns it. A
+// pollInterval of zero polls every 30 seconds. Use a context deadline to bound the wait.
+func (s *CIService) WaitForCiBuildRun(ctx context.Context, id string, pollInterval time.Duration) (*CiBuildRunResponse, *Response, error) {
+	if pollInterval == 0 {
+		pollInterval = defaultCiBuildRunPollInterval
+	}
+
+	for {
+		res, resp, err := s.GetCiBuildRun(ctx, id, nil)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		if res.Data.IsComplete() {
+			return res, resp, nil
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return res, resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiBuildRunResponseIncluded.
+func (i *CiBuildRunResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// Build returns the Build stored within, if one is present.
+func (i *CiBuildRunResponseIncluded) Build() *Build {
+	return extractIncludedBuild(i.inner)
+}
+
+// CiWorkflow returns the CiWorkflow stored within, if one is present.
+func (i *CiBuildRunResponseIncluded) CiWorkflow() *CiWorkflow {
+	return extractIncludedCiWorkflow(i.inner)
+}
+
+// CiProduct returns the CiProduct stored within, if one is present.
+func (i *CiBuildRunResponseIncluded) CiProduct() *CiProduct {
+	return extractIncludedCiProduct(i.inner)
+}
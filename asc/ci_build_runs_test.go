@@ -0,0 +1,96 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCiBuildRun(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildRunResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiBuildRun(ctx, "10", &GetCiBuildRunQuery{})
+	})
+}
+
+func TestCreateCiBuildRun(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildRunResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.CreateCiBuildRun(ctx, &CiBuildRunCreateRequestAttributes{}, String("10"), String("20"), nil, nil)
+	})
+}
+
+func TestListCiBuildActionsForCiBuildRun(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildActionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiBuildActionsForCiBuildRun(ctx, "10", &ListCiBuildActionsForCiBuildRunQuery{})
+	})
+}
+
+func TestListBuildsForCiBuildRun(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BuildsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListBuildsForCiBuildRun(ctx, "10", &ListBuildsForCiBuildRunQuery{})
+	})
+}
+
+func TestGetCiBuildRunIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"builds"},{"type":"ciWorkflows"},{"type":"ciProducts"}]}`, func(ctx context.Context, client *Client) {
+		run, _, err := client.CI.GetCiBuildRun(ctx, "10", &GetCiBuildRunQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, run.Included)
+
+		assert.NotNil(t, run.Included[0].Build())
+		assert.NotNil(t, run.Included[1].CiWorkflow())
+		assert.NotNil(t, run.Included[2].CiProduct())
+
+		assert.Nil(t, run.Included[0].CiProduct())
+		assert.Nil(t, run.Included[1].Build())
+		assert.Nil(t, run.Included[2].CiWorkflow())
+	})
+}
+
+func TestWaitForCiBuildRun(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/ciBuildRuns/run": {
+			`{"data":{"id":"run","attributes":{"executionProgress":"PENDING"}}}`,
+			`{"data":{"id":"run","attributes":{"executionProgress":"RUNNING"}}}`,
+			`{"data":{"id":"run","attributes":{"executionProgress":"COMPLETE","completionStatus":"SUCCEEDED"}}}`,
+		},
+	})
+
+	run, _, err := client.CI.WaitForCiBuildRun(context.Background(), "run", time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, CiCompletionStatusSucceeded, *run.Data.Attributes.CompletionStatus)
+	assert.Equal(t, 3, server.requests["GET /v1/ciBuildRuns/run"])
+}
@@ -0,0 +1,97 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiIssueType defines model for CiIssue.Attributes.IssueType
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissue/attributes
+type CiIssueType string
+
+const (
+	// CiIssueTypeAnalyzerWarning is an issue type for an analyzer warning.
+	CiIssueTypeAnalyzerWarning CiIssueType = "ANALYZER_WARNING"
+	// CiIssueTypeError is an issue type for an error.
+	CiIssueTypeError CiIssueType = "ERROR"
+	// CiIssueTypeTestFailure is an issue type for a test failure.
+	CiIssueTypeTestFailure CiIssueType = "TEST_FAILURE"
+	// CiIssueTypeWarning is an issue type for a warning.
+	CiIssueTypeWarning CiIssueType = "WARNING"
+)
+
+// CiIssue defines model for CiIssue.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissue
+type CiIssue struct {
+	Attributes *CiIssueAttributes `json:"attributes,omitempty"`
+	ID         string             `json:"id"`
+	Links      ResourceLinks      `json:"links"`
+	Type       string             `json:"type"`
+}
+
+// CiIssueAttributes defines model for CiIssue.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissue/attributes
+type CiIssueAttributes struct {
+	Category   *string       `json:"category,omitempty"`
+	FileSource *FileLocation `json:"fileSource,omitempty"`
+	IssueType  *CiIssueType  `json:"issueType,omitempty"`
+	Message    *string       `json:"message,omitempty"`
+}
+
+// CiIssueResponse defines model for CiIssueResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissueresponse
+type CiIssueResponse struct {
+	Data  CiIssue       `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// CiIssuesResponse defines model for CiIssuesResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciissuesresponse
+type CiIssuesResponse struct {
+	Data  []CiIssue          `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// GetCiIssueQuery are query options for GetCiIssue
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_issue_information
+type GetCiIssueQuery struct {
+	FieldsCiIssues []string `url:"fields[ciIssues],omitempty"`
+}
+
+// GetCiIssue gets a specific issue reported by an Xcode Cloud build action.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_issue_information
+func (s *CIService) GetCiIssue(ctx context.Context, id string, params *GetCiIssueQuery) (*CiIssueResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciIssues/%s", id)
+	res := new(CiIssueResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
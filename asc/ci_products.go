@@ -0,0 +1,228 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiProductType defines model for CiProduct.Attributes.ProductType
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproduct/attributes
+type CiProductType string
+
+const (
+	// CiProductTypeApp is a product type for App.
+	CiProductTypeApp CiProductType = "APP"
+	// CiProductTypeFramework is a product type for Framework.
+	CiProductTypeFramework CiProductType = "FRAMEWORK"
+)
+
+// CiProduct defines model for CiProduct.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproduct
+type CiProduct struct {
+	Attributes    *CiProductAttributes    `json:"attributes,omitempty"`
+	ID            string                  `json:"id"`
+	Links         ResourceLinks           `json:"links"`
+	Relationships *CiProductRelationships `json:"relationships,omitempty"`
+	Type          string                  `json:"type"`
+}
+
+// CiProductAttributes defines model for CiProduct.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproduct/attributes
+type CiProductAttributes struct {
+	CreatedDate *DateTime      `json:"createdDate,omitempty"`
+	Name        *string        `json:"name,omitempty"`
+	ProductType *CiProductType `json:"productType,omitempty"`
+}
+
+// CiProductRelationships defines model for CiProduct.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproduct/relationships
+type CiProductRelationships struct {
+	AdditionalRepositories *PagedRelationship `json:"additionalRepositories,omitempty"`
+	App                    *Relationship      `json:"app,omitempty"`
+	BuildRuns              *PagedRelationship `json:"buildRuns,omitempty"`
+	BundleID               *Relationship      `json:"bundleId,omitempty"`
+	PrimaryRepositories    *PagedRelationship `json:"primaryRepositories,omitempty"`
+	Workflows              *PagedRelationship `json:"workflows,omitempty"`
+}
+
+// CiProductResponse defines model for CiProductResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproductresponse
+type CiProductResponse struct {
+	Data     CiProduct                   `json:"data"`
+	Included []CiProductResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks               `json:"links"`
+}
+
+// CiProductsResponse defines model for CiProductsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciproductsresponse
+type CiProductsResponse struct {
+	Data     []CiProduct                 `json:"data"`
+	Included []CiProductResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks          `json:"links"`
+	Meta     *PagingInformation          `json:"meta,omitempty"`
+}
+
+// CiProductResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiProductResponse or CiProductsResponse.
+type CiProductResponseIncluded included
+
+// ListCiProductsQuery are query options for ListCiProducts
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_products
+type ListCiProductsQuery struct {
+	FieldsApps        []string `url:"fields[apps],omitempty"`
+	FieldsBundleIds   []string `url:"fields[bundleIds],omitempty"`
+	FieldsCiProducts  []string `url:"fields[ciProducts],omitempty"`
+	FilterApp         []string `url:"filter[app],omitempty"`
+	FilterProductType []string `url:"filter[productType],omitempty"`
+	Include           []string `url:"include,omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// GetCiProductQuery are query options for GetCiProduct
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_product_information
+type GetCiProductQuery struct {
+	FieldsApps       []string `url:"fields[apps],omitempty"`
+	FieldsBundleIds  []string `url:"fields[bundleIds],omitempty"`
+	FieldsCiProducts []string `url:"fields[ciProducts],omitempty"`
+	Include          []string `url:"include,omitempty"`
+}
+
+// GetCiProductForAppQuery are query options for GetCiProductForApp
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_product_for_an_app
+type GetCiProductForAppQuery struct {
+	FieldsCiProducts []string `url:"fields[ciProducts],omitempty"`
+}
+
+// ListCiWorkflowsForCiProductQuery are query options for ListCiWorkflowsForCiProduct
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_workflows_for_a_product
+type ListCiWorkflowsForCiProductQuery struct {
+	FieldsCiWorkflows []string `url:"fields[ciWorkflows],omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// ListCiBuildRunsForCiProductQuery are query options for ListCiBuildRunsForCiProduct
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_runs_for_a_product
+type ListCiBuildRunsForCiProductQuery struct {
+	FieldsBuilds      []string `url:"fields[builds],omitempty"`
+	FieldsCiBuildRuns []string `url:"fields[ciBuildRuns],omitempty"`
+	FilterBuilds      []string `url:"filter[builds],omitempty"`
+	Include           []string `url:"include,omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	LimitBuilds       int      `url:"limit[builds],omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// ListCiProducts lists the apps and frameworks that Xcode Cloud builds.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_products
+func (s *CIService) ListCiProducts(ctx context.Context, params *ListCiProductsQuery) (*CiProductsResponse, *Response, error) {
+	res := new(CiProductsResponse)
+	resp, err := s.client.get(ctx, "v1/ciProducts", params, res)
+
+	return res, resp, err
+}
+
+// GetCiProduct gets a specific Xcode Cloud product.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_product_information
+func (s *CIService) GetCiProduct(ctx context.Context, id string, params *GetCiProductQuery) (*CiProductResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciProducts/%s", id)
+	res := new(CiProductResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetCiProductForApp gets the Xcode Cloud product of an app.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_the_product_for_an_app
+func (s *CIService) GetCiProductForApp(ctx context.Context, id string, params *GetCiProductForAppQuery) (*CiProductResponse, *Response, error) {
+	url := fmt.Sprintf("v1/apps/%s/ciProduct", id)
+	res := new(CiProductResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DeleteCiProduct removes a product from Xcode Cloud, along with its workflows and build data.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete_a_product
+func (s *CIService) DeleteCiProduct(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/ciProducts/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// ListCiWorkflowsForCiProduct lists the workflows of an Xcode Cloud product.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_workflows_for_a_product
+func (s *CIService) ListCiWorkflowsForCiProduct(ctx context.Context, id string, params *ListCiWorkflowsForCiProductQuery) (*CiWorkflowsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciProducts/%s/workflows", id)
+	res := new(CiWorkflowsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiBuildRunsForCiProduct lists the build runs of every workflow of an Xcode Cloud product.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_runs_for_a_product
+func (s *CIService) ListCiBuildRunsForCiProduct(ctx context.Context, id string, params *ListCiBuildRunsForCiProductQuery) (*CiBuildRunsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciProducts/%s/buildRuns", id)
+	res := new(CiBuildRunsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiProductResponseIncluded.
+func (i *CiProductResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// App returns the App stored within, if one is present.
+func (i *CiProductResponseIncluded) App() *App {
+	return extractIncludedApp(i.inner)
+}
+
+// BundleID returns the BundleID stored within, if one is present.
+func (i *CiProductResponseIncluded) BundleID() *BundleID {
+	return extractIncludedBundleID(i.inner)
+}
@@ -0,0 +1,92 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCiProducts(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiProductsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiProducts(ctx, &ListCiProductsQuery{})
+	})
+}
+
+func TestGetCiProduct(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiProductResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiProduct(ctx, "10", &GetCiProductQuery{})
+	})
+}
+
+func TestGetCiProductForApp(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiProductResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiProductForApp(ctx, "10", &GetCiProductForAppQuery{})
+	})
+}
+
+func TestDeleteCiProduct(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.CI.DeleteCiProduct(ctx, "10")
+	})
+}
+
+func TestListCiWorkflowsForCiProduct(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiWorkflowsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiWorkflowsForCiProduct(ctx, "10", &ListCiWorkflowsForCiProductQuery{})
+	})
+}
+
+func TestListCiBuildRunsForCiProduct(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildRunsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiBuildRunsForCiProduct(ctx, "10", &ListCiBuildRunsForCiProductQuery{})
+	})
+}
+
+func TestGetCiProductIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"apps"},{"type":"bundleIds"}]}`, func(ctx context.Context, client *Client) {
+		product, _, err := client.CI.GetCiProduct(ctx, "10", &GetCiProductQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, product.Included)
+
+		assert.NotNil(t, product.Included[0].App())
+		assert.NotNil(t, product.Included[1].BundleID())
+
+		assert.Nil(t, product.Included[0].BundleID())
+		assert.Nil(t, product.Included[1].App())
+	})
+}
@@ -0,0 +1,112 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiTestStatus defines model for CiTestStatus.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citeststatus
+type CiTestStatus string
+
+const (
+	// CiTestStatusSuccess is a test status for Success.
+	CiTestStatusSuccess CiTestStatus = "SUCCESS"
+	// CiTestStatusFailure is a test status for Failure.
+	CiTestStatusFailure CiTestStatus = "FAILURE"
+	// CiTestStatusMixed is a test status for a test that passed on some destinations and failed on others.
+	CiTestStatusMixed CiTestStatus = "MIXED"
+	// CiTestStatusSkipped is a test status for Skipped.
+	CiTestStatusSkipped CiTestStatus = "SKIPPED"
+	// CiTestStatusExpectedFailure is a test status for ExpectedFailure.
+	CiTestStatusExpectedFailure CiTestStatus = "EXPECTED_FAILURE"
+)
+
+// CiTestResult defines model for CiTestResult.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestresult
+type CiTestResult struct {
+	Attributes *CiTestResultAttributes `json:"attributes,omitempty"`
+	ID         string                  `json:"id"`
+	Links      ResourceLinks           `json:"links"`
+	Type       string                  `json:"type"`
+}
+
+// CiTestResultAttributes defines model for CiTestResult.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestresult/attributes
+type CiTestResultAttributes struct {
+	ClassName              *string                   `json:"className,omitempty"`
+	DestinationTestResults []CiDestinationTestResult `json:"destinationTestResults,omitempty"`
+	FileSource             *FileLocation             `json:"fileSource,omitempty"`
+	Message                *string                   `json:"message,omitempty"`
+	Name                   *string                   `json:"name,omitempty"`
+	Status                 *CiTestStatus             `json:"status,omitempty"`
+}
+
+// CiDestinationTestResult defines model for CiTestResult.Attributes.DestinationTestResults
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestresult/attributes/destinationtestresults
+type CiDestinationTestResult struct {
+	DeviceName *string       `json:"deviceName,omitempty"`
+	Duration   *float64      `json:"duration,omitempty"`
+	OSVersion  *string       `json:"osVersion,omitempty"`
+	Status     *CiTestStatus `json:"status,omitempty"`
+	UUID       *string       `json:"uuid,omitempty"`
+}
+
+// CiTestResultResponse defines model for CiTestResultResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestresultresponse
+type CiTestResultResponse struct {
+	Data  CiTestResult  `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// CiTestResultsResponse defines model for CiTestResultsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citestresultsresponse
+type CiTestResultsResponse struct {
+	Data  []CiTestResult     `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// GetCiTestResultQuery are query options for GetCiTestResult
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_test_result_information
+type GetCiTestResultQuery struct {
+	FieldsCiTestResults []string `url:"fields[ciTestResults],omitempty"`
+}
+
+// GetCiTestResult gets a specific test result of an Xcode Cloud test action.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_test_result_information
+func (s *CIService) GetCiTestResult(ctx context.Context, id string, params *GetCiTestResultQuery) (*CiTestResultResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciTestResults/%s", id)
+	res := new(CiTestResultResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
@@ -0,0 +1,49 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCiTestResult(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiTestResultResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiTestResult(ctx, "10", &GetCiTestResultQuery{})
+	})
+}
+
+func TestGetCiTestResultDecodesAttributes(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"data":{"id":"10","attributes":{"className":"LoginTests","name":"testSignIn()","status":"MIXED",
+		"destinationTestResults":[{"uuid":"a","deviceName":"iPhone 15","osVersion":"17.0","status":"FAILURE","duration":1.5}]}}}`, func(ctx context.Context, client *Client) {
+		result, _, err := client.CI.GetCiTestResult(ctx, "10", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, CiTestStatusMixed, *result.Data.Attributes.Status)
+		assert.Equal(t, CiTestStatusFailure, *result.Data.Attributes.DestinationTestResults[0].Status)
+		assert.Equal(t, 1.5, *result.Data.Attributes.DestinationTestResults[0].Duration)
+	})
+}
@@ -0,0 +1,297 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiMacOsVersion defines model for CiMacOsVersion.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cimacosversion
+type CiMacOsVersion struct {
+	Attributes    *CiMacOsVersionAttributes    `json:"attributes,omitempty"`
+	ID            string                       `json:"id"`
+	Links         ResourceLinks                `json:"links"`
+	Relationships *CiMacOsVersionRelationships `json:"relationships,omitempty"`
+	Type          string                       `json:"type"`
+}
+
+// CiMacOsVersionAttributes defines model for CiMacOsVersion.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cimacosversion/attributes
+type CiMacOsVersionAttributes struct {
+	Name    *string `json:"name,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// CiMacOsVersionRelationships defines model for CiMacOsVersion.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cimacosversion/relationships
+type CiMacOsVersionRelationships struct {
+	XcodeVersions *PagedRelationship `json:"xcodeVersions,omitempty"`
+}
+
+// CiMacOsVersionResponse defines model for CiMacOsVersionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cimacosversionresponse
+type CiMacOsVersionResponse struct {
+	Data     CiMacOsVersion                   `json:"data"`
+	Included []CiMacOsVersionResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                    `json:"links"`
+}
+
+// CiMacOsVersionsResponse defines model for CiMacOsVersionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cimacosversionsresponse
+type CiMacOsVersionsResponse struct {
+	Data     []CiMacOsVersion                 `json:"data"`
+	Included []CiMacOsVersionResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks               `json:"links"`
+	Meta     *PagingInformation               `json:"meta,omitempty"`
+}
+
+// CiMacOsVersionResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiMacOsVersionResponse or CiMacOsVersionsResponse.
+type CiMacOsVersionResponseIncluded included
+
+// CiXcodeVersion defines model for CiXcodeVersion.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversion
+type CiXcodeVersion struct {
+	Attributes    *CiXcodeVersionAttributes    `json:"attributes,omitempty"`
+	ID            string                       `json:"id"`
+	Links         ResourceLinks                `json:"links"`
+	Relationships *CiXcodeVersionRelationships `json:"relationships,omitempty"`
+	Type          string                       `json:"type"`
+}
+
+// CiXcodeVersionAttributes defines model for CiXcodeVersion.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversion/attributes
+type CiXcodeVersionAttributes struct {
+	Name             *string                     `json:"name,omitempty"`
+	TestDestinations []CiXcodeTestDestinationKit `json:"testDestinations,omitempty"`
+	Version          *string                     `json:"version,omitempty"`
+}
+
+// CiXcodeTestDestinationKit defines model for CiXcodeVersion.Attributes.TestDestinations
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversion/attributes/testdestinations
+type CiXcodeTestDestinationKit struct {
+	AvailableRuntimes    []CiXcodeTestDestinationRuntime `json:"availableRuntimes,omitempty"`
+	DeviceTypeIdentifier *string                         `json:"deviceTypeIdentifier,omitempty"`
+	DeviceTypeName       *string                         `json:"deviceTypeName,omitempty"`
+	Kind                 *CiTestDestinationKind          `json:"kind,omitempty"`
+}
+
+// CiXcodeTestDestinationRuntime defines model for CiXcodeVersion.Attributes.TestDestinations.AvailableRuntimes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversion/attributes/testdestinations/availableruntimes
+type CiXcodeTestDestinationRuntime struct {
+	RuntimeIdentifier *string `json:"runtimeIdentifier,omitempty"`
+	RuntimeName       *string `json:"runtimeName,omitempty"`
+}
+
+// CiXcodeVersionRelationships defines model for CiXcodeVersion.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversion/relationships
+type CiXcodeVersionRelationships struct {
+	MacOsVersions *PagedRelationship `json:"macOsVersions,omitempty"`
+}
+
+// CiXcodeVersionResponse defines model for CiXcodeVersionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversionresponse
+type CiXcodeVersionResponse struct {
+	Data     CiXcodeVersion                   `json:"data"`
+	Included []CiXcodeVersionResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                    `json:"links"`
+}
+
+// CiXcodeVersionsResponse defines model for CiXcodeVersionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cixcodeversionsresponse
+type CiXcodeVersionsResponse struct {
+	Data     []CiXcodeVersion                 `json:"data"`
+	Included []CiXcodeVersionResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks               `json:"links"`
+	Meta     *PagingInformation               `json:"meta,omitempty"`
+}
+
+// CiXcodeVersionResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiXcodeVersionResponse or CiXcodeVersionsResponse.
+type CiXcodeVersionResponseIncluded included
+
+// ListCiMacOsVersionsQuery are query options for ListCiMacOsVersions
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_macos_versions_available_in_xcode_cloud
+type ListCiMacOsVersionsQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Include               []string `url:"include,omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	LimitXcodeVersions    int      `url:"limit[xcodeVersions],omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// GetCiMacOsVersionQuery are query options for GetCiMacOsVersion
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_macos_version_information
+type GetCiMacOsVersionQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Include               []string `url:"include,omitempty"`
+	LimitXcodeVersions    int      `url:"limit[xcodeVersions],omitempty"`
+}
+
+// ListCiXcodeVersionsForCiMacOsVersionQuery are query options for ListCiXcodeVersionsForCiMacOsVersion
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_xcode_versions_for_a_macos_version
+type ListCiXcodeVersionsForCiMacOsVersionQuery struct {
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// ListCiXcodeVersionsQuery are query options for ListCiXcodeVersions
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_xcode_versions_available_in_xcode_cloud
+type ListCiXcodeVersionsQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Include               []string `url:"include,omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	LimitMacOsVersions    int      `url:"limit[macOsVersions],omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// GetCiXcodeVersionQuery are query options for GetCiXcodeVersion
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_xcode_version_information
+type GetCiXcodeVersionQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Include               []string `url:"include,omitempty"`
+	LimitMacOsVersions    int      `url:"limit[macOsVersions],omitempty"`
+}
+
+// ListCiMacOsVersionsForCiXcodeVersionQuery are query options for ListCiMacOsVersionsForCiXcodeVersion
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_macos_versions_for_an_xcode_version
+type ListCiMacOsVersionsForCiXcodeVersionQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// ListCiMacOsVersions lists the macOS versions that Xcode Cloud can run workflows on.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_macos_versions_available_in_xcode_cloud
+func (s *CIService) ListCiMacOsVersions(ctx context.Context, params *ListCiMacOsVersionsQuery) (*CiMacOsVersionsResponse, *Response, error) {
+	res := new(CiMacOsVersionsResponse)
+	resp, err := s.client.get(ctx, "v1/ciMacOsVersions", params, res)
+
+	return res, resp, err
+}
+
+// GetCiMacOsVersion gets a specific macOS version available in Xcode Cloud.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_macos_version_information
+func (s *CIService) GetCiMacOsVersion(ctx context.Context, id string, params *GetCiMacOsVersionQuery) (*CiMacOsVersionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciMacOsVersions/%s", id)
+	res := new(CiMacOsVersionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiXcodeVersionsForCiMacOsVersion lists the Xcode versions that Xcode Cloud supports on a macOS version.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_xcode_versions_for_a_macos_version
+func (s *CIService) ListCiXcodeVersionsForCiMacOsVersion(ctx context.Context, id string, params *ListCiXcodeVersionsForCiMacOsVersionQuery) (*CiXcodeVersionsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciMacOsVersions/%s/xcodeVersions", id)
+	res := new(CiXcodeVersionsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiXcodeVersions lists the Xcode versions that Xcode Cloud can build with.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_xcode_versions_available_in_xcode_cloud
+func (s *CIService) ListCiXcodeVersions(ctx context.Context, params *ListCiXcodeVersionsQuery) (*CiXcodeVersionsResponse, *Response, error) {
+	res := new(CiXcodeVersionsResponse)
+	resp, err := s.client.get(ctx, "v1/ciXcodeVersions", params, res)
+
+	return res, resp, err
+}
+
+// GetCiXcodeVersion gets a specific Xcode version available in Xcode Cloud.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_xcode_version_information
+func (s *CIService) GetCiXcodeVersion(ctx context.Context, id string, params *GetCiXcodeVersionQuery) (*CiXcodeVersionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciXcodeVersions/%s", id)
+	res := new(CiXcodeVersionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCiMacOsVersionsForCiXcodeVersion lists the macOS versions that Xcode Cloud supports for an Xcode version.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_macos_versions_for_an_xcode_version
+func (s *CIService) ListCiMacOsVersionsForCiXcodeVersion(ctx context.Context, id string, params *ListCiMacOsVersionsForCiXcodeVersionQuery) (*CiMacOsVersionsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciXcodeVersions/%s/macOsVersions", id)
+	res := new(CiMacOsVersionsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiMacOsVersionResponseIncluded.
+func (i *CiMacOsVersionResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// CiXcodeVersion returns the CiXcodeVersion stored within, if one is present.
+func (i *CiMacOsVersionResponseIncluded) CiXcodeVersion() *CiXcodeVersion {
+	return extractIncludedCiXcodeVersion(i.inner)
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiXcodeVersionResponseIncluded.
+func (i *CiXcodeVersionResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// CiMacOsVersion returns the CiMacOsVersion stored within, if one is present.
+func (i *CiXcodeVersionResponseIncluded) CiMacOsVersion() *CiMacOsVersion {
+	return extractIncludedCiMacOsVersion(i.inner)
+}
@@ -0,0 +1,92 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCiMacOsVersions(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiMacOsVersionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiMacOsVersions(ctx, &ListCiMacOsVersionsQuery{})
+	})
+}
+
+func TestGetCiMacOsVersion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiMacOsVersionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiMacOsVersion(ctx, "10", &GetCiMacOsVersionQuery{})
+	})
+}
+
+func TestListCiXcodeVersionsForCiMacOsVersion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiXcodeVersionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiXcodeVersionsForCiMacOsVersion(ctx, "10", &ListCiXcodeVersionsForCiMacOsVersionQuery{})
+	})
+}
+
+func TestListCiXcodeVersions(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiXcodeVersionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiXcodeVersions(ctx, &ListCiXcodeVersionsQuery{})
+	})
+}
+
+func TestGetCiXcodeVersion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiXcodeVersionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiXcodeVersion(ctx, "10", &GetCiXcodeVersionQuery{})
+	})
+}
+
+func TestListCiMacOsVersionsForCiXcodeVersion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiMacOsVersionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiMacOsVersionsForCiXcodeVersion(ctx, "10", &ListCiMacOsVersionsForCiXcodeVersionQuery{})
+	})
+}
+
+func TestGetCiVersionIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"ciXcodeVersions"}]}`, func(ctx context.Context, client *Client) {
+		version, _, err := client.CI.GetCiMacOsVersion(ctx, "10", &GetCiMacOsVersionQuery{})
+		assert.NoError(t, err)
+		assert.NotNil(t, version.Included[0].CiXcodeVersion())
+	})
+
+	testEndpointCustomBehavior(`{"included":[{"type":"ciMacOsVersions"}]}`, func(ctx context.Context, client *Client) {
+		version, _, err := client.CI.GetCiXcodeVersion(ctx, "10", &GetCiXcodeVersionQuery{})
+		assert.NoError(t, err)
+		assert.NotNil(t, version.Included[0].CiMacOsVersion())
+	})
+}
@@ -0,0 +1,280 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// CiWorkflow defines model for CiWorkflow.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciworkflow
+type CiWorkflow struct {
+	Attributes    *CiWorkflowAttributes    `json:"attributes,omitempty"`
+	ID            string                   `json:"id"`
+	Links         ResourceLinks            `json:"links"`
+	Relationships *CiWorkflowRelationships `json:"relationships,omitempty"`
+	Type          string                   `json:"type"`
+}
+
+// CiWorkflowAttributes defines model for CiWorkflow.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciworkflow/attributes
+type CiWorkflowAttributes struct {
+	Actions                   []CiAction                   `json:"actions,omitempty"`
+	BranchStartCondition      *CiBranchStartCondition      `json:"branchStartCondition,omitempty"`
+	Clean                     *bool                        `json:"clean,omitempty"`
+	ContainerFilePath         *string                      `json:"containerFilePath,omitempty"`
+	Description               *string                      `json:"description,omitempty"`
+	IsEnabled                 *bool                        `json:"isEnabled,omitempty"`
+	IsLockedForEditing        *bool                        `json:"isLockedForEditing,omitempty"`
+	LastModifiedDate          *DateTime                    `json:"lastModifiedDate,omitempty"`
+	Name                      *string                      `json:"name,omitempty"`
+	PullRequestStartCondition *CiPullRequestStartCondition `json:"pullRequestStartCondition,omitempty"`
+	ScheduledStartCondition   *CiScheduledStartCondition   `json:"scheduledStartCondition,omitempty"`
+	TagStartCondition         *CiTagStartCondition         `json:"tagStartCondition,omitempty"`
+}
+
+// CiWorkflowRelationships defines model for CiWorkflow.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciworkflow/relationships
+type CiWorkflowRelationships struct {
+	BuildRuns    *PagedRelationship `json:"buildRuns,omitempty"`
+	MacOsVersion *Relationship      `json:"macOsVersion,omitempty"`
+	Product      *Relationship      `json:"product,omitempty"`
+	Repository   *Relationship      `json:"repository,omitempty"`
+	XcodeVersion *Relationship      `json:"xcodeVersion,omitempty"`
+}
+
+// CiAction defines model for CiAction.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciaction
+type CiAction struct {
+	ActionType                *CiActionType        `json:"actionType,omitempty"`
+	BuildDistributionAudience *string              `json:"buildDistributionAudience,omitempty"`
+	Destination               *string              `json:"destination,omitempty"`
+	IsRequiredToPass          *bool                `json:"isRequiredToPass,omitempty"`
+	Name                      *string              `json:"name,omitempty"`
+	Platform                  *string              `json:"platform,omitempty"`
+	Scheme                    *string              `json:"scheme,omitempty"`
+	TestConfiguration         *CiTestConfiguration `json:"testConfiguration,omitempty"`
+}
+
+// CiTestConfiguration defines model for CiAction.TestConfiguration
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciaction/testconfiguration
+type CiTestConfiguration struct {
+	Kind             *string             `json:"kind,omitempty"`
+	TestDestinations []CiTestDestination `json:"testDestinations,omitempty"`
+	TestPlanName     *string             `json:"testPlanName,omitempty"`
+}
+
+// CiStartConditionPattern defines model for CiBranchPatterns.Patterns
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibranchpatterns/patterns
+type CiStartConditionPattern struct {
+	IsPrefix *bool   `json:"isPrefix,omitempty"`
+	Pattern  *string `json:"pattern,omitempty"`
+}
+
+// CiBranchPatterns defines model for CiBranchPatterns.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibranchpatterns
+type CiBranchPatterns struct {
+	IsAllMatch *bool                     `json:"isAllMatch,omitempty"`
+	Patterns   []CiStartConditionPattern `json:"patterns,omitempty"`
+}
+
+// CiTagPatterns defines model for CiTagPatterns.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citagpatterns
+type CiTagPatterns struct {
+	IsAllMatch *bool                     `json:"isAllMatch,omitempty"`
+	Patterns   []CiStartConditionPattern `json:"patterns,omitempty"`
+}
+
+// CiFilesAndFoldersRule defines model for CiFilesAndFoldersRule.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cifilesandfoldersrule
+type CiFilesAndFoldersRule struct {
+	Matchers []CiStartConditionFileMatcher `json:"matchers,omitempty"`
+	Mode     *string                       `json:"mode,omitempty"`
+}
+
+// CiStartConditionFileMatcher defines model for CiStartConditionFileMatcher.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cistartconditionfilematcher
+type CiStartConditionFileMatcher struct {
+	Directory     *string `json:"directory,omitempty"`
+	FileExtension *string `json:"fileExtension,omitempty"`
+	FileName      *string `json:"fileName,omitempty"`
+}
+
+// CiBranchStartCondition defines model for CiBranchStartCondition.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cibranchstartcondition
+type CiBranchStartCondition struct {
+	AutoCancel          *bool                  `json:"autoCancel,omitempty"`
+	FilesAndFoldersRule *CiFilesAndFoldersRule `json:"filesAndFoldersRule,omitempty"`
+	Source              *CiBranchPatterns      `json:"source,omitempty"`
+}
+
+// CiTagStartCondition defines model for CiTagStartCondition.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/citagstartcondition
+type CiTagStartCondition struct {
+	AutoCancel          *bool                  `json:"autoCancel,omitempty"`
+	FilesAndFoldersRule *CiFilesAndFoldersRule `json:"filesAndFoldersRule,omitempty"`
+	Source              *CiTagPatterns         `json:"source,omitempty"`
+}
+
+// CiPullRequestStartCondition defines model for CiPullRequestStartCondition.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cipullrequeststartcondition
+type CiPullRequestStartCondition struct {
+	AutoCancel          *bool                  `json:"autoCancel,omitempty"`
+	Destination         *CiBranchPatterns      `json:"destination,omitempty"`
+	FilesAndFoldersRule *CiFilesAndFoldersRule `json:"filesAndFoldersRule,omitempty"`
+	Source              *CiBranchPatterns      `json:"source,omitempty"`
+}
+
+// CiScheduledStartCondition defines model for CiScheduledStartCondition.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cischeduledstartcondition
+type CiScheduledStartCondition struct {
+	Schedule *CiSchedule       `json:"schedule,omitempty"`
+	Source   *CiBranchPatterns `json:"source,omitempty"`
+}
+
+// CiSchedule defines model for CiSchedule.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/cischedule
+type CiSchedule struct {
+	Days      []string `json:"days,omitempty"`
+	Frequency *string  `json:"frequency,omitempty"`
+	Hour      *int     `json:"hour,omitempty"`
+	Minute    *int     `json:"minute,omitempty"`
+	Timezone  *string  `json:"timezone,omitempty"`
+}
+
+// CiWorkflowResponse defines model for CiWorkflowResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciworkflowresponse
+type CiWorkflowResponse struct {
+	Data     CiWorkflow                   `json:"data"`
+	Included []CiWorkflowResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                `json:"links"`
+}
+
+// CiWorkflowsResponse defines model for CiWorkflowsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/ciworkflowsresponse
+type CiWorkflowsResponse struct {
+	Data     []CiWorkflow                 `json:"data"`
+	Included []CiWorkflowResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks           `json:"links"`
+	Meta     *PagingInformation           `json:"meta,omitempty"`
+}
+
+// CiWorkflowResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a CiWorkflowResponse or CiWorkflowsResponse.
+type CiWorkflowResponseIncluded included
+
+// GetCiWorkflowQuery are query options for GetCiWorkflow
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_workflow_information
+type GetCiWorkflowQuery struct {
+	FieldsCiMacOsVersions []string `url:"fields[ciMacOsVersions],omitempty"`
+	FieldsCiProducts      []string `url:"fields[ciProducts],omitempty"`
+	FieldsCiWorkflows     []string `url:"fields[ciWorkflows],omitempty"`
+	FieldsCiXcodeVersions []string `url:"fields[ciXcodeVersions],omitempty"`
+	Include               []string `url:"include,omitempty"`
+}
+
+// ListCiBuildRunsForCiWorkflowQuery are query options for ListCiBuildRunsForCiWorkflow
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_runs_for_a_workflow
+type ListCiBuildRunsForCiWorkflowQuery struct {
+	FieldsBuilds      []string `url:"fields[builds],omitempty"`
+	FieldsCiBuildRuns []string `url:"fields[ciBuildRuns],omitempty"`
+	FilterBuilds      []string `url:"filter[builds],omitempty"`
+	Include           []string `url:"include,omitempty"`
+	Sort              []string `url:"sort,omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	LimitBuilds       int      `url:"limit[builds],omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// GetCiWorkflow gets a specific Xcode Cloud workflow.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_workflow_information
+func (s *CIService) GetCiWorkflow(ctx context.Context, id string, params *GetCiWorkflowQuery) (*CiWorkflowResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciWorkflows/%s", id)
+	res := new(CiWorkflowResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DeleteCiWorkflow deletes an Xcode Cloud workflow.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete_a_workflow
+func (s *CIService) DeleteCiWorkflow(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/ciWorkflows/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// ListCiBuildRunsForCiWorkflow lists the build runs of an Xcode Cloud workflow.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_build_runs_for_a_workflow
+func (s *CIService) ListCiBuildRunsForCiWorkflow(ctx context.Context, id string, params *ListCiBuildRunsForCiWorkflowQuery) (*CiBuildRunsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/ciWorkflows/%s/buildRuns", id)
+	res := new(CiBuildRunsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in CiWorkflowResponseIncluded.
+func (i *CiWorkflowResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// CiProduct returns the CiProduct stored within, if one is present.
+func (i *CiWorkflowResponseIncluded) CiProduct() *CiProduct {
+	return extractIncludedCiProduct(i.inner)
+}
+
+// CiMacOsVersion returns the CiMacOsVersion stored within, if one is present.
+func (i *CiWorkflowResponseIncluded) CiMacOsVersion() *CiMacOsVersion {
+	return extractIncludedCiMacOsVersion(i.inner)
+}
+
+// CiXcodeVersion returns the CiXcodeVersion stored within, if one is present.
+func (i *CiWorkflowResponseIncluded) CiXcodeVersion() *CiXcodeVersion {
+	return extractIncludedCiXcodeVersion(i.inner)
+}
@@ -0,0 +1,70 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCiWorkflow(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiWorkflowResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.GetCiWorkflow(ctx, "10", &GetCiWorkflowQuery{})
+	})
+}
+
+func TestDeleteCiWorkflow(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.CI.DeleteCiWorkflow(ctx, "10")
+	})
+}
+
+func TestListCiBuildRunsForCiWorkflow(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CiBuildRunsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.CI.ListCiBuildRunsForCiWorkflow(ctx, "10", &ListCiBuildRunsForCiWorkflowQuery{})
+	})
+}
+
+func TestGetCiWorkflowIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"ciProducts"},{"type":"ciMacOsVersions"},{"type":"ciXcodeVersions"}]}`, func(ctx context.Context, client *Client) {
+		workflow, _, err := client.CI.GetCiWorkflow(ctx, "10", &GetCiWorkflowQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, workflow.Included)
+
+		assert.NotNil(t, workflow.Included[0].CiProduct())
+		assert.NotNil(t, workflow.Included[1].CiMacOsVersion())
+		assert.NotNil(t, workflow.Included[2].CiXcodeVersion())
+
+		assert.Nil(t, workflow.Included[0].CiXcodeVersion())
+		assert.Nil(t, workflow.Included[1].CiProduct())
+		assert.Nil(t, workflow.Included[2].CiMacOsVersion())
+	})
+}
@@ -233,6 +233,14 @@ func extractIncludedBuildBetaDetail(i interface{}) *BuildBetaDetail {
 	return nil
 }
 
+func extractIncludedBuildBundle(i interface{}) *BuildBundle {
+	if v, ok := i.(BuildBundle); ok {
+		return &v
+	}
+
+	return nil
+}
+
 func extractIncludedBuildIcon(i interface{}) *BuildIcon {
 	if v, ok := i.(BuildIcon); ok {
 		return &v
@@ -265,6 +273,46 @@ func extractIncludedCertificate(i interface{}) *Certificate {
 	return nil
 }
 
+func extractIncludedCiBuildRun(i interface{}) *CiBuildRun {
+	if v, ok := i.(CiBuildRun); ok {
+		return &v
+	}
+
+	return nil
+}
+
+func extractIncludedCiMacOsVersion(i interface{}) *CiMacOsVersion {
+	if v, ok := i.(CiMacOsVersion); ok {
+		return &v
+	}
+
+	return nil
+}
+
+func extractIncludedCiProduct(i interface{}) *CiProduct {
+	if v, ok := i.(CiProduct); ok {
+		return &v
+	}
+
+	return nil
+}
+
+func extractIncludedCiWorkflow(i interface{}) *CiWorkflow {
+	if v, ok := i.(CiWorkflow); ok {
+		return &v
+	}
+
+	return nil
+}
+
+func extractIncludedCiXcodeVersion(i interface{}) *CiXcodeVersion {
+	if v, ok := i.(CiXcodeVersion); ok {
+		return &v
+	}
+
+	return nil
+}
+
 func extractIncludedDevice(i interface{}) *Device {
 	if v, ok := i.(Device); ok {
 		return &v
@@ -337,6 +385,22 @@ func extractIncludedProfile(i interface{}) *Profile {
 	return nil
 }
 
+func extractIncludedReviewSubmission(i interface{}) *ReviewSubmission {
+	if v, ok := i.(ReviewSubmission); ok {
+		return &v
+	}
+
+	return nil
+}
+
+func extractIncludedReviewSubmissionItem(i interface{}) *ReviewSubmissionItem {
+	if v, ok := i.(ReviewSubmissionItem); ok {
+		return &v
+	}
+
+	return nil
+}
+
 func extractIncludedRoutingAppCoverage(i interface{}) *RoutingAppCoverage {
 	if v, ok := i.(RoutingAppCoverage); ok {
 		return &v
@@ -516,6 +580,12 @@ func supportedIncludeTypes() func(string, []byte) (string, interface{}, error) {
 
 			return v.Type, v, err
 		},
+		"buildBundles": func(b []byte) (string, interface{}, error) {
+			var v BuildBundle
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
 		"buildIcons": func(b []byte) (string, interface{}, error) {
 			var v BuildIcon
 			err := json.Unmarshal(b, &v)
@@ -540,6 +610,36 @@ func supportedIncludeTypes() func(string, []byte) (string, interface{}, error) {
 
 			return v.Type, v, err
 		},
+		"ciBuildRuns": func(b []byte) (string, interface{}, error) {
+			var v CiBuildRun
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
+		"ciMacOsVersions": func(b []byte) (string, interface{}, error) {
+			var v CiMacOsVersion
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
+		"ciProducts": func(b []byte) (string, interface{}, error) {
+			var v CiProduct
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
+		"ciWorkflows": func(b []byte) (string, interface{}, error) {
+			var v CiWorkflow
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
+		"ciXcodeVersions": func(b []byte) (string, interface{}, error) {
+			var v CiXcodeVersion
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
 		"devices": func(b []byte) (string, interface{}, error) {
 			var v Device
 			err := json.Unmarshal(b, &v)
@@ -594,6 +694,18 @@ func supportedIncludeTypes() func(string, []byte) (string, interface{}, error) {
 
 			return v.Type, v, err
 		},
+		"reviewSubmissions": func(b []byte) (string, interface{}, error) {
+			var v ReviewSubmission
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
+		"reviewSubmissionItems": func(b []byte) (string, interface{}, error) {
+			var v ReviewSubmissionItem
+			err := json.Unmarshal(b, &v)
+
+			return v.Type, v, err
+		},
 		"routingAppCoverages": func(b []byte) (string, interface{}, error) {
 			var v RoutingAppCoverage
 			err := json.Unmarshal(b, &v)
@@ -78,9 +78,10 @@ func TestKnownIncludeTypes(t *testing.T) {
 		"appStoreReviewDetails", "appStoreVersions", "appStoreVersionLocalizations", "appStoreVersionPhasedReleases",
 		"appStoreVersionSubmissions", "betaAppLocalizations", "betaAppReviewDetails", "betaAppReviewSubmissions",
 		"betaBuildLocalizations", "betaGroups", "betaLicenseAgreements", "betaTesters", "builds", "buildBetaDetails",
-		"buildIcons", "bundleIds", "bundleIdCapabilities", "certificates", "devices", "diagnosticSignatures",
+		"buildBundles", "buildIcons", "bundleIds", "bundleIdCapabilities", "certificates", "ciBuildRuns", "ciMacOsVersions",
+		"ciProducts", "ciWorkflows", "ciXcodeVersions", "devices", "diagnosticSignatures",
 		"endUserLicenseAgreements", "gameCenterEnabledVersions", "idfaDeclarations", "inAppPurchases", "perfPowerMetrics",
-		"preReleaseVersions", "profiles", "routingAppCoverages", "territories"}
+		"preReleaseVersions", "profiles", "reviewSubmissions", "reviewSubmissionItems", "routingAppCoverages", "territories"}
 
 	var payload *mockPayloadIncluded
 
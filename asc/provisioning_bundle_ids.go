@@ -233,7 +233,7 @@ func (s *ProvisioningService) DeleteBundleID(ctx context.Context, id string) (*R
 // https://developer.apple.com/documentation/appstoreconnectapi/list_bundle_ids
 func (s *ProvisioningService) ListBundleIDs(ctx context.Context, params *ListBundleIDsQuery) (*BundleIDsResponse, *Response, error) {
 	res := new(BundleIDsResponse)
-	resp, err := s.client.get(ctx, "bundleIds", params, res)
+	resp, err := s.client.get(ctx, "v1/bundleIds", params, res)
 
 	return res, resp, err
 }
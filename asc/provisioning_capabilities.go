@@ -165,6 +165,40 @@ type BundleIDCapabilitiesResponse struct {
 	Meta  *PagingInformation   `json:"meta,omitempty"`
 }
 
+// BundleIDCapabilityMerchantIDsLinkagesResponse defines model for BundleIdCapabilityMerchantIdsLinkagesResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/bundleidcapabilitymerchantidslinkagesresponse
+type BundleIDCapabilityMerchantIDsLinkagesResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// BundleIDCapabilityPassTypeIDsLinkagesResponse defines model for BundleIdCapabilityPassTypeIdsLinkagesResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/bundleidcapabilitypasstypeidslinkagesresponse
+type BundleIDCapabilityPassTypeIDsLinkagesResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// ListMerchantIDsForCapabilityQuery are query options for ListMerchantIDsForCapability
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_all_merchant_id_ids_for_a_capability
+type ListMerchantIDsForCapabilityQuery struct {
+	Limit  int    `url:"limit,omitempty"`
+	Cursor string `url:"cursor,omitempty"`
+}
+
+// ListPassTypeIDsForCapabilityQuery are query options for ListPassTypeIDsForCapability
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_all_pass_type_id_ids_for_a_capability
+type ListPassTypeIDsForCapabilityQuery struct {
+	Limit  int    `url:"limit,omitempty"`
+	Cursor string `url:"cursor,omitempty"`
+}
+
 // CapabilityOption defines model for CapabilityOption.
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/capabilityoption
@@ -211,7 +245,7 @@ func (s *ProvisioningService) EnableCapability(ctx context.Context, capabilityTy
 		Type: "bundleIdCapabilities",
 	}
 	res := new(BundleIDCapabilityResponse)
-	resp, err := s.client.patch(ctx, "v1/bundleIdCapabilities", newRequestBody(req), res)
+	resp, err := s.client.post(ctx, "v1/bundleIdCapabilities", newRequestBody(req), res)
 
 	return res, resp, err
 }
@@ -247,3 +281,67 @@ func (s *ProvisioningService) UpdateCapability(ctx context.Context, id string, c
 
 	return res, resp, err
 }
+
+// ListMerchantIDsForCapability gets the IDs of the merchant IDs linked to an Apple Pay capability.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_all_merchant_id_ids_for_a_capability
+func (s *ProvisioningService) ListMerchantIDsForCapability(ctx context.Context, id string, params *ListMerchantIDsForCapabilityQuery) (*BundleIDCapabilityMerchantIDsLinkagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/merchantIds", id)
+	res := new(BundleIDCapabilityMerchantIDsLinkagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// AddMerchantIDsToCapability links one or more merchant IDs to an Apple Pay capability, so that the
+// bundle ID's provisioning profiles grant them.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/add_merchant_ids_to_a_capability
+func (s *ProvisioningService) AddMerchantIDsToCapability(ctx context.Context, id string, merchantIDs []string) (*Response, error) {
+	linkages := newPagedRelationshipDeclaration(merchantIDs, "merchantIds")
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/merchantIds", id)
+
+	return s.client.post(ctx, url, newRequestBody(linkages.Data), nil)
+}
+
+// RemoveMerchantIDsFromCapability unlinks one or more merchant IDs from an Apple Pay capability.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/remove_merchant_ids_from_a_capability
+func (s *ProvisioningService) RemoveMerchantIDsFromCapability(ctx context.Context, id string, merchantIDs []string) (*Response, error) {
+	linkages := newPagedRelationshipDeclaration(merchantIDs, "merchantIds")
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/merchantIds", id)
+
+	return s.client.delete(ctx, url, newRequestBody(linkages.Data))
+}
+
+// ListPassTypeIDsForCapability gets the IDs of the pass type IDs linked to a Wallet capability.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_all_pass_type_id_ids_for_a_capability
+func (s *ProvisioningService) ListPassTypeIDsForCapability(ctx context.Context, id string, params *ListPassTypeIDsForCapabilityQuery) (*BundleIDCapabilityPassTypeIDsLinkagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/passTypeIds", id)
+	res := new(BundleIDCapabilityPassTypeIDsLinkagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// AddPassTypeIDsToCapability links one or more pass type IDs to a Wallet capability, so that the
+// bundle ID's provisioning profiles grant them.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/add_pass_type_ids_to_a_capability
+func (s *ProvisioningService) AddPassTypeIDsToCapability(ctx context.Context, id string, passTypeIDs []string) (*Response, error) {
+	linkages := newPagedRelationshipDeclaration(passTypeIDs, "passTypeIds")
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/passTypeIds", id)
+
+	return s.client.post(ctx, url, newRequestBody(linkages.Data), nil)
+}
+
+// RemovePassTypeIDsFromCapability unlinks one or more pass type IDs from a Wallet capability.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/remove_pass_type_ids_from_a_capability
+func (s *ProvisioningService) RemovePassTypeIDsFromCapability(ctx context.Context, id string, passTypeIDs []string) (*Response, error) {
+	linkages := newPagedRelationshipDeclaration(passTypeIDs, "passTypeIds")
+	url := fmt.Sprintf("v1/bundleIdCapabilities/%s/relationships/passTypeIds", id)
+
+	return s.client.delete(ctx, url, newRequestBody(linkages.Data))
+}
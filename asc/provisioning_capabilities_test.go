@@ -50,3 +50,51 @@ func TestUpdateCapability(t *testing.T) {
 		return client.Provisioning.UpdateCapability(ctx, "10", &capability, []CapabilitySetting{})
 	})
 }
+
+func TestListMerchantIDsForCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BundleIDCapabilityMerchantIDsLinkagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListMerchantIDsForCapability(ctx, "10", &ListMerchantIDsForCapabilityQuery{})
+	})
+}
+
+func TestAddMerchantIDsToCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.AddMerchantIDsToCapability(ctx, "10", []string{"10"})
+	})
+}
+
+func TestRemoveMerchantIDsFromCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.RemoveMerchantIDsFromCapability(ctx, "10", []string{"10"})
+	})
+}
+
+func TestListPassTypeIDsForCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BundleIDCapabilityPassTypeIDsLinkagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListPassTypeIDsForCapability(ctx, "10", &ListPassTypeIDsForCapabilityQuery{})
+	})
+}
+
+func TestAddPassTypeIDsToCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.AddPassTypeIDsToCapability(ctx, "10", []string{"10"})
+	})
+}
+
+func TestRemovePassTypeIDsFromCapability(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.RemovePassTypeIDsFromCapability(ctx, "10", []string{"10"})
+	})
+}
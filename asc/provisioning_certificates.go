@@ -166,7 +166,7 @@ func (s *ProvisioningService) CreateCertificate(ctx context.Context, certificate
 // https://developer.apple.com/documentation/appstoreconnectapi/list_and_download_certificates
 func (s *ProvisioningService) ListCertificates(ctx context.Context, params *ListCertificatesQuery) (*CertificatesResponse, *Response, error) {
 	res := new(CertificatesResponse)
-	resp, err := s.client.get(ctx, "certificates", params, res)
+	resp, err := s.client.get(ctx, "v1/certificates", params, res)
 
 	return res, resp, err
 }
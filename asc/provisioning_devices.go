@@ -144,7 +144,7 @@ func (s *ProvisioningService) CreateDevice(ctx context.Context, name string, udi
 // https://developer.apple.com/documentation/appstoreconnectapi/list_devices
 func (s *ProvisioningService) ListDevices(ctx context.Context, params *ListDevicesQuery) (*DevicesResponse, *Response, error) {
 	res := new(DevicesResponse)
-	resp, err := s.client.get(ctx, "devices", params, res)
+	resp, err := s.client.get(ctx, "v1/devices", params, res)
 
 	return res, resp, err
 }
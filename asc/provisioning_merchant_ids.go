@@ -0,0 +1,217 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// MerchantID defines model for MerchantId. A merchant ID identifies a merchant to Apple Pay, and
+// is listed in an app's com.apple.developer.in-app-payments entitlement.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantid
+type MerchantID struct {
+	Attributes    *MerchantIDAttributes    `json:"attributes,omitempty"`
+	ID            string                   `json:"id"`
+	Links         ResourceLinks            `json:"links"`
+	Relationships *MerchantIDRelationships `json:"relationships,omitempty"`
+	Type          string                   `json:"type"`
+}
+
+// MerchantIDAttributes defines model for MerchantId.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantid/attributes
+type MerchantIDAttributes struct {
+	Identifier *string `json:"identifier,omitempty"`
+	Name       *string `json:"name,omitempty"`
+}
+
+// MerchantIDRelationships defines model for MerchantId.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantid/relationships
+type MerchantIDRelationships struct {
+	Certificates *PagedRelationship `json:"certificates,omitempty"`
+}
+
+// merchantIDCreateRequest defines model for MerchantIdCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidcreaterequest/data
+type merchantIDCreateRequest struct {
+	Attributes merchantIDCreateRequestAttributes `json:"attributes"`
+	Type       string                            `json:"type"`
+}
+
+// merchantIDCreateRequestAttributes are attributes for MerchantIDCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidcreaterequest/data/attributes
+type merchantIDCreateRequestAttributes struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+}
+
+// merchantIDUpdateRequest defines model for MerchantIdUpdateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidupdaterequest/data
+type merchantIDUpdateRequest struct {
+	Attributes *merchantIDUpdateRequestAttributes `json:"attributes,omitempty"`
+	ID         string                             `json:"id"`
+	Type       string                             `json:"type"`
+}
+
+// merchantIDUpdateRequestAttributes are attributes for MerchantIDUpdateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidupdaterequest/data/attributes
+type merchantIDUpdateRequestAttributes struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// MerchantIDResponse defines model for MerchantIdResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidresponse
+type MerchantIDResponse struct {
+	Data  MerchantID    `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// MerchantIDsResponse defines model for MerchantIdsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/merchantidsresponse
+type MerchantIDsResponse struct {
+	Data  []MerchantID       `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// ListMerchantIDsQuery are query options for ListMerchantIDs
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_merchant_ids
+type ListMerchantIDsQuery struct {
+	FieldsMerchantIDs []string `url:"fields[merchantIds],omitempty"`
+	FilterIdentifier  []string `url:"filter[identifier],omitempty"`
+	FilterName        []string `url:"filter[name],omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	Sort              []string `url:"sort,omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// GetMerchantIDQuery are query options for GetMerchantID
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_merchant_id_information
+type GetMerchantIDQuery struct {
+	FieldsMerchantIDs []string `url:"fields[merchantIds],omitempty"`
+}
+
+// ListCertificatesForMerchantIDQuery are query options for ListCertificatesForMerchantID
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_certificates_for_a_merchant_id
+type ListCertificatesForMerchantIDQuery struct {
+	FieldsCertificates    []string `url:"fields[certificates],omitempty"`
+	FilterCertificateType []string `url:"filter[certificateType],omitempty"`
+	FilterDisplayName     []string `url:"filter[displayName],omitempty"`
+	FilterID              []string `url:"filter[id],omitempty"`
+	FilterSerialNumber    []string `url:"filter[serialNumber],omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	Sort                  []string `url:"sort,omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// CreateMerchantID registers a new merchant ID for Apple Pay. The identifier conventionally starts
+// with "merchant.".
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/register_a_new_merchant_id
+func (s *ProvisioningService) CreateMerchantID(ctx context.Context, name string, identifier string) (*MerchantIDResponse, *Response, error) {
+	req := merchantIDCreateRequest{
+		Attributes: merchantIDCreateRequestAttributes{
+			Identifier: identifier,
+			Name:       name,
+		},
+		Type: "merchantIds",
+	}
+	res := new(MerchantIDResponse)
+	resp, err := s.client.post(ctx, "v1/merchantIds", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// UpdateMerchantID updates a specific merchant ID's name.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/modify_a_merchant_id
+func (s *ProvisioningService) UpdateMerchantID(ctx context.Context, id string, name *string) (*MerchantIDResponse, *Response, error) {
+	req := merchantIDUpdateRequest{
+		ID:   id,
+		Type: "merchantIds",
+	}
+
+	if name != nil {
+		req.Attributes = &merchantIDUpdateRequestAttributes{
+			Name: name,
+		}
+	}
+
+	url := fmt.Sprintf("v1/merchantIds/%s", id)
+	res := new(MerchantIDResponse)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// DeleteMerchantID deletes a merchant ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete_a_merchant_id
+func (s *ProvisioningService) DeleteMerchantID(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/merchantIds/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// ListMerchantIDs finds and lists merchant IDs that are registered to your team.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_merchant_ids
+func (s *ProvisioningService) ListMerchantIDs(ctx context.Context, params *ListMerchantIDsQuery) (*MerchantIDsResponse, *Response, error) {
+	res := new(MerchantIDsResponse)
+	resp, err := s.client.get(ctx, "v1/merchantIds", params, res)
+
+	return res, resp, err
+}
+
+// GetMerchantID gets information about a specific merchant ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_merchant_id_information
+func (s *ProvisioningService) GetMerchantID(ctx context.Context, id string, params *GetMerchantIDQuery) (*MerchantIDResponse, *Response, error) {
+	url := fmt.Sprintf("v1/merchantIds/%s", id)
+	res := new(MerchantIDResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCertificatesForMerchantID lists the Apple Pay payment processing and merchant identity
+// certificates of a merchant ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_certificates_for_a_merchant_id
+func (s *ProvisioningService) ListCertificatesForMerchantID(ctx context.Context, id string, params *ListCertificatesForMerchantIDQuery) (*CertificatesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/merchantIds/%s/certificates", id)
+	res := new(CertificatesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
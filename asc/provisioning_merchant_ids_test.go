@@ -0,0 +1,74 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreateMerchantID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &MerchantIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.CreateMerchantID(ctx, "", "")
+	})
+}
+
+func TestUpdateMerchantID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &MerchantIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.UpdateMerchantID(ctx, "10", String(""))
+	})
+}
+
+func TestDeleteMerchantID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.DeleteMerchantID(ctx, "10")
+	})
+}
+
+func TestListMerchantIDs(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &MerchantIDsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListMerchantIDs(ctx, &ListMerchantIDsQuery{})
+	})
+}
+
+func TestGetMerchantID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &MerchantIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.GetMerchantID(ctx, "10", &GetMerchantIDQuery{})
+	})
+}
+
+func TestListCertificatesForMerchantID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CertificatesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListCertificatesForMerchantID(ctx, "10", &ListCertificatesForMerchantIDQuery{})
+	})
+}
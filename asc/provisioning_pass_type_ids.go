@@ -0,0 +1,216 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// PassTypeID defines model for PassTypeId. A pass type ID identifies a kind of Wallet pass, and is
+// listed in an app's com.apple.developer.pass-type-identifiers entitlement.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeid
+type PassTypeID struct {
+	Attributes    *PassTypeIDAttributes    `json:"attributes,omitempty"`
+	ID            string                   `json:"id"`
+	Links         ResourceLinks            `json:"links"`
+	Relationships *PassTypeIDRelationships `json:"relationships,omitempty"`
+	Type          string                   `json:"type"`
+}
+
+// PassTypeIDAttributes defines model for PassTypeId.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeid/attributes
+type PassTypeIDAttributes struct {
+	Identifier *string `json:"identifier,omitempty"`
+	Name       *string `json:"name,omitempty"`
+}
+
+// PassTypeIDRelationships defines model for PassTypeId.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeid/relationships
+type PassTypeIDRelationships struct {
+	Certificates *PagedRelationship `json:"certificates,omitempty"`
+}
+
+// passTypeIDCreateRequest defines model for PassTypeIdCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidcreaterequest/data
+type passTypeIDCreateRequest struct {
+	Attributes passTypeIDCreateRequestAttributes `json:"attributes"`
+	Type       string                            `json:"type"`
+}
+
+// passTypeIDCreateRequestAttributes are attributes for PassTypeIDCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidcreaterequest/data/attributes
+type passTypeIDCreateRequestAttributes struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+}
+
+// passTypeIDUpdateRequest defines model for PassTypeIdUpdateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidupdaterequest/data
+type passTypeIDUpdateRequest struct {
+	Attributes *passTypeIDUpdateRequestAttributes `json:"attributes,omitempty"`
+	ID         string                             `json:"id"`
+	Type       string                             `json:"type"`
+}
+
+// passTypeIDUpdateRequestAttributes are attributes for PassTypeIDUpdateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidupdaterequest/data/attributes
+type passTypeIDUpdateRequestAttributes struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// PassTypeIDResponse defines model for PassTypeIdResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidresponse
+type PassTypeIDResponse struct {
+	Data  PassTypeID    `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// PassTypeIDsResponse defines model for PassTypeIdsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/passtypeidsresponse
+type PassTypeIDsResponse struct {
+	Data  []PassTypeID       `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// ListPassTypeIDsQuery are query options for ListPassTypeIDs
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_pass_type_ids
+type ListPassTypeIDsQuery struct {
+	FieldsPassTypeIDs []string `url:"fields[passTypeIds],omitempty"`
+	FilterIdentifier  []string `url:"filter[identifier],omitempty"`
+	FilterName        []string `url:"filter[name],omitempty"`
+	Limit             int      `url:"limit,omitempty"`
+	Sort              []string `url:"sort,omitempty"`
+	Cursor            string   `url:"cursor,omitempty"`
+}
+
+// GetPassTypeIDQuery are query options for GetPassTypeID
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_pass_type_id_information
+type GetPassTypeIDQuery struct {
+	FieldsPassTypeIDs []string `url:"fields[passTypeIds],omitempty"`
+}
+
+// ListCertificatesForPassTypeIDQuery are query options for ListCertificatesForPassTypeID
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_certificates_for_a_pass_type_id
+type ListCertificatesForPassTypeIDQuery struct {
+	FieldsCertificates    []string `url:"fields[certificates],omitempty"`
+	FilterCertificateType []string `url:"filter[certificateType],omitempty"`
+	FilterDisplayName     []string `url:"filter[displayName],omitempty"`
+	FilterID              []string `url:"filter[id],omitempty"`
+	FilterSerialNumber    []string `url:"filter[serialNumber],omitempty"`
+	Limit                 int      `url:"limit,omitempty"`
+	Sort                  []string `url:"sort,omitempty"`
+	Cursor                string   `url:"cursor,omitempty"`
+}
+
+// CreatePassTypeID registers a new pass type ID for Wallet passes. The identifier conventionally
+// starts with "pass.".
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/register_a_new_pass_type_id
+func (s *ProvisioningService) CreatePassTypeID(ctx context.Context, name string, identifier string) (*PassTypeIDResponse, *Response, error) {
+	req := passTypeIDCreateRequest{
+		Attributes: passTypeIDCreateRequestAttributes{
+			Identifier: identifier,
+			Name:       name,
+		},
+		Type: "passTypeIds",
+	}
+	res := new(PassTypeIDResponse)
+	resp, err := s.client.post(ctx, "v1/passTypeIds", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// UpdatePassTypeID updates a specific pass type ID's name.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/modify_a_pass_type_id
+func (s *ProvisioningService) UpdatePassTypeID(ctx context.Context, id string, name *string) (*PassTypeIDResponse, *Response, error) {
+	req := passTypeIDUpdateRequest{
+		ID:   id,
+		Type: "passTypeIds",
+	}
+
+	if name != nil {
+		req.Attributes = &passTypeIDUpdateRequestAttributes{
+			Name: name,
+		}
+	}
+
+	url := fmt.Sprintf("v1/passTypeIds/%s", id)
+	res := new(PassTypeIDResponse)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// DeletePassTypeID deletes a pass type ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete_a_pass_type_id
+func (s *ProvisioningService) DeletePassTypeID(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/passTypeIds/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// ListPassTypeIDs finds and lists pass type IDs that are registered to your team.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_pass_type_ids
+func (s *ProvisioningService) ListPassTypeIDs(ctx context.Context, params *ListPassTypeIDsQuery) (*PassTypeIDsResponse, *Response, error) {
+	res := new(PassTypeIDsResponse)
+	resp, err := s.client.get(ctx, "v1/passTypeIds", params, res)
+
+	return res, resp, err
+}
+
+// GetPassTypeID gets information about a specific pass type ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/read_pass_type_id_information
+func (s *ProvisioningService) GetPassTypeID(ctx context.Context, id string, params *GetPassTypeIDQuery) (*PassTypeIDResponse, *Response, error) {
+	url := fmt.Sprintf("v1/passTypeIds/%s", id)
+	res := new(PassTypeIDResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// ListCertificatesForPassTypeID lists the certificates used to sign passes of a pass type ID.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/list_all_certificates_for_a_pass_type_id
+func (s *ProvisioningService) ListCertificatesForPassTypeID(ctx context.Context, id string, params *ListCertificatesForPassTypeIDQuery) (*CertificatesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/passTypeIds/%s/certificates", id)
+	res := new(CertificatesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
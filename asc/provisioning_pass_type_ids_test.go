@@ -0,0 +1,74 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreatePassTypeID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &PassTypeIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.CreatePassTypeID(ctx, "", "")
+	})
+}
+
+func TestUpdatePassTypeID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &PassTypeIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.UpdatePassTypeID(ctx, "10", String(""))
+	})
+}
+
+func TestDeletePassTypeID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Provisioning.DeletePassTypeID(ctx, "10")
+	})
+}
+
+func TestListPassTypeIDs(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &PassTypeIDsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListPassTypeIDs(ctx, &ListPassTypeIDsQuery{})
+	})
+}
+
+func TestGetPassTypeID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &PassTypeIDResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.GetPassTypeID(ctx, "10", &GetPassTypeIDQuery{})
+	})
+}
+
+func TestListCertificatesForPassTypeID(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &CertificatesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Provisioning.ListCertificatesForPassTypeID(ctx, "10", &ListCertificatesForPassTypeIDQuery{})
+	})
+}
@@ -207,7 +207,7 @@ func (s *ProvisioningService) DeleteProfile(ctx context.Context, id string) (*Re
 // https://developer.apple.com/documentation/appstoreconnectapi/list_and_download_profiles
 func (s *ProvisioningService) ListProfiles(ctx context.Context, params *ListProfilesQuery) (*ProfilesResponse, *Response, error) {
 	res := new(ProfilesResponse)
-	resp, err := s.client.get(ctx, "profiles", params, res)
+	resp, err := s.client.get(ctx, "v1/profiles", params, res)
 
 	return res, resp, err
 }
@@ -24,4 +24,5 @@ package asc
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/app_store_version_phased_releases
 // https://developer.apple.com/documentation/appstoreconnectapi/app_pre-orders
+// https://developer.apple.com/documentation/appstoreconnectapi/app_store_version_release_requests
 type PublishingService service
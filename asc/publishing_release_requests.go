@@ -0,0 +1,252 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Release types accepted in the ReleaseType attribute of an App Store version.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appstoreversion/attributes
+const (
+	// ReleaseTypeManual releases the version only when a release request is made.
+	ReleaseTypeManual = "MANUAL"
+	// ReleaseTypeAfterApproval releases the version as soon as App Review approves it.
+	ReleaseTypeAfterApproval = "AFTER_APPROVAL"
+	// ReleaseTypeScheduled releases the version after approval, but no earlier than its EarliestReleaseDate.
+	ReleaseTypeScheduled = "SCHEDULED"
+)
+
+// ErrInvalidReleaseDate happens when an earliest release date cannot be used to schedule a release,
+// because it is not on the hour or because it is not in the future.
+type ErrInvalidReleaseDate struct {
+	Date   time.Time
+	Reason string
+}
+
+func (e ErrInvalidReleaseDate) Error() string {
+	return fmt.Sprintf("release date %s %s", e.Date.Format(time.RFC3339), e.Reason)
+}
+
+// ErrReleaseTypeLocked happens when the release type of a version is changed after App Review has
+// started looking at it, at which point App Store Connect no longer accepts the change.
+type ErrReleaseTypeLocked struct {
+	State AppStoreVersionState
+}
+
+func (e ErrReleaseTypeLocked) Error() string {
+	return fmt.Sprintf("release type cannot be changed while the version is in state %s", e.State)
+}
+
+// ErrInvalidReleaseType happens when an unknown release type is used, or when a release date is
+// given for a release type other than SCHEDULED, or omitted for SCHEDULED.
+type ErrInvalidReleaseType struct {
+	ReleaseType string
+	Reason      string
+}
+
+func (e ErrInvalidReleaseType) Error() string {
+	return fmt.Sprintf("release type %s %s", e.ReleaseType, e.Reason)
+}
+
+// AppStoreVersionReleaseRequest defines model for AppStoreVersionReleaseRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appstoreversionreleaserequest
+type AppStoreVersionReleaseRequest struct {
+	ID    string        `json:"id"`
+	Links ResourceLinks `json:"links"`
+	Type  string        `json:"type"`
+}
+
+// AppStoreVersionReleaseRequestResponse defines model for AppStoreVersionReleaseRequestResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appstoreversionreleaserequestresponse
+type AppStoreVersionReleaseRequestResponse struct {
+	Data  AppStoreVersionReleaseRequest `json:"data"`
+	Links DocumentLinks                 `json:"links"`
+}
+
+// appStoreVersionReleaseRequestCreateRequest defines model for AppStoreVersionReleaseRequestCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appstoreversionreleaserequestcreaterequest/data
+type appStoreVersionReleaseRequestCreateRequest struct {
+	Relationships appStoreVersionReleaseRequestCreateRequestRelationships `json:"relationships"`
+	Type          string                                                  `json:"type"`
+}
+
+// appStoreVersionReleaseRequestCreateRequestRelationships are relationships for AppStoreVersionReleaseRequestCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/appstoreversionreleaserequestcreaterequest/data/relationships
+type appStoreVersionReleaseRequestCreateRequestRelationships struct {
+	AppStoreVersion relationshipDeclaration `json:"appStoreVersion"`
+}
+
+// CreateReleaseRequest manually releases an App Store version that is pending developer release.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/manually_release_an_app_store_approved_version_of_your_app
+func (s *PublishingService) CreateReleaseRequest(ctx context.Context, appStoreVersionID string) (*AppStoreVersionReleaseRequestResponse, *Response, error) {
+	req := appStoreVersionReleaseRequestCreateRequest{
+		Relationships: appStoreVersionReleaseRequestCreateRequestRelationships{
+			AppStoreVersion: *newRelationshipDeclaration(&appStoreVersionID, "appStoreVersions"),
+		},
+		Type: "appStoreVersionReleaseRequests",
+	}
+	res := new(AppStoreVersionReleaseRequestResponse)
+	resp, err := s.client.post(ctx, "v1/appStoreVersionReleaseRequests", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// CanRelease reports whether a version is waiting on the developer to release it, which is the only
+// state in which CreateReleaseRequest succeeds. The version is returned alongside for convenience.
+func (s *PublishingService) CanRelease(ctx context.Context, appStoreVersionID string) (bool, *AppStoreVersion, *Response, error) {
+	url := fmt.Sprintf("v1/appStoreVersions/%s", appStoreVersionID)
+	res := new(AppStoreVersionResponse)
+
+	resp, err := s.client.get(ctx, url, nil, res)
+	if err != nil {
+		return false, nil, resp, err
+	}
+
+	return appStoreStateOf(&res.Data) == AppStoreVersionStatePendingDeveloperRelease, &res.Data, resp, nil
+}
+
+// appStoreVersionReleaseTypeUpdateRequest is the AppStoreVersionUpdateRequest sent by SetReleaseType.
+// Unlike AppStoreVersionUpdateRequestAttributes, it always sends earliestReleaseDate, as null when
+// there is none, so that switching away from a scheduled release clears the date.
+type appStoreVersionReleaseTypeUpdateRequest struct {
+	Attributes appStoreVersionReleaseTypeUpdateRequestAttributes `json:"attributes"`
+	ID         string                                            `json:"id"`
+	Type       string                                            `json:"type"`
+}
+
+type appStoreVersionReleaseTypeUpdateRequestAttributes struct {
+	EarliestReleaseDate *DateTime `json:"earliestReleaseDate"`
+	ReleaseType         string    `json:"releaseType"`
+}
+
+// SetReleaseType changes how a version is released once approved. earliestReleaseDate is required for
+// ReleaseTypeScheduled and must be nil otherwise; see NewEarliestReleaseDate. The version's current state
+// is checked first, and ErrReleaseTypeLocked is returned once App Review has started.
+func (s *PublishingService) SetReleaseType(ctx context.Context, appStoreVersionID string, releaseType string, earliestReleaseDate *DateTime) (*AppStoreVersionResponse, *Response, error) {
+	switch releaseType {
+	case ReleaseTypeManual, ReleaseTypeAfterApproval:
+		if earliestReleaseDate != nil {
+			return nil, nil, ErrInvalidReleaseType{ReleaseType: releaseType, Reason: "does not accept an earliest release date"}
+		}
+	case ReleaseTypeScheduled:
+		if earliestReleaseDate == nil {
+			return nil, nil, ErrInvalidReleaseType{ReleaseType: releaseType, Reason: "requires an earliest release date"}
+		}
+
+		if err := validateEarliestReleaseDate(earliestReleaseDate.Time, time.Now()); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, ErrInvalidReleaseType{ReleaseType: releaseType, Reason: "is not recognized"}
+	}
+
+	url := fmt.Sprintf("v1/appStoreVersions/%s", appStoreVersionID)
+	current := new(AppStoreVersionResponse)
+
+	resp, err := s.client.get(ctx, url, nil, current)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if state := appStoreStateOf(&current.Data); !isReleaseTypeChangeable(state) {
+		return nil, resp, ErrReleaseTypeLocked{State: state}
+	}
+
+	req := appStoreVersionReleaseTypeUpdateRequest{
+		Attributes: appStoreVersionReleaseTypeUpdateRequestAttributes{
+			EarliestReleaseDate: earliestReleaseDate,
+			ReleaseType:         releaseType,
+		},
+		ID:   appStoreVersionID,
+		Type: "appStoreVersions",
+	}
+	res := new(AppStoreVersionResponse)
+	resp, err = s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// NewEarliestReleaseDate converts a local time into an EarliestReleaseDate for a scheduled release.
+// App Store Connect only accepts release dates on the hour in UTC and in the future, so t must have
+// zero minutes and seconds once converted to UTC. Wall-clock hours in time zones with a half-hour
+// offset, such as India's, cannot be scheduled. The result is expressed in UTC.
+func NewEarliestReleaseDate(t time.Time) (*DateTime, error) {
+	if err := validateEarliestReleaseDate(t, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &DateTime{Time: t.UTC()}, nil
+}
+
+// EarliestReleaseDateIn builds an EarliestReleaseDate for the given wall-clock hour in an IANA time zone,
+// such as "America/New_York", so that a release can be scheduled for 9 AM local time in a market.
+func EarliestReleaseDateIn(year int, month time.Month, day, hour int, timeZone string) (*DateTime, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEarliestReleaseDate(time.Date(year, month, day, hour, 0, 0, 0, location))
+}
+
+func validateEarliestReleaseDate(t time.Time, now time.Time) error {
+	if utc := t.UTC(); utc.Minute() != 0 || utc.Second() != 0 || utc.Nanosecond() != 0 {
+		return ErrInvalidReleaseDate{Date: t, Reason: "must be on the hour"}
+	}
+
+	if !t.After(now) {
+		return ErrInvalidReleaseDate{Date: t, Reason: "must be in the future"}
+	}
+
+	return nil
+}
+
+func appStoreStateOf(version *AppStoreVersion) AppStoreVersionState {
+	if version.Attributes == nil || version.Attributes.AppStoreState == nil {
+		return ""
+	}
+
+	return *version.Attributes.AppStoreState
+}
+
+func isReleaseTypeChangeable(state AppStoreVersionState) bool {
+	switch state {
+	case "",
+		AppStoreVersionStatePrepareForSubmission,
+		AppStoreVersionStateDeveloperRejected,
+		AppStoreVersionStateRejected,
+		AppStoreVersionStateMetadataRejected,
+		AppStoreVersionStateInvalidBinary,
+		AppStoreVersionStateWaitingForReview:
+		return true
+	}
+
+	return false
+}
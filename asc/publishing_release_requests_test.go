@@ -0,0 +1,169 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReleaseRequest(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &AppStoreVersionReleaseRequestResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Publishing.CreateReleaseRequest(ctx, "10")
+	})
+}
+
+func TestCanRelease(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"data":{"attributes":{"appStoreState":"PENDING_DEVELOPER_RELEASE"}}}`, func(ctx context.Context, client *Client) {
+		ok, version, _, err := client.Publishing.CanRelease(ctx, "10")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NotNil(t, version)
+	})
+
+	testEndpointCustomBehavior(`{"data":{"attributes":{"appStoreState":"IN_REVIEW"}}}`, func(ctx context.Context, client *Client) {
+		ok, _, _, err := client.Publishing.CanRelease(ctx, "10")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestSetReleaseType(t *testing.T) {
+	t.Parallel()
+
+	at := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	testEndpointCustomBehavior(`{"data":{"attributes":{"appStoreState":"PREPARE_FOR_SUBMISSION"}}}`, func(ctx context.Context, client *Client) {
+		_, _, err := client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeManual, nil)
+		assert.NoError(t, err)
+
+		_, _, err = client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeScheduled, &DateTime{Time: at})
+		assert.NoError(t, err)
+	})
+}
+
+func TestSetReleaseTypeClearsEarliestReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	var patched map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patched = body.Data.Attributes
+		}
+
+		fmt.Fprint(w, `{"data":{"attributes":{"appStoreState":"PREPARE_FOR_SUBMISSION"}}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.baseURL, _ = url.Parse(server.URL + "/")
+
+	_, _, err := client.Publishing.SetReleaseType(context.Background(), "10", ReleaseTypeManual, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, patched, "earliestReleaseDate")
+	assert.Nil(t, patched["earliestReleaseDate"])
+	assert.Equal(t, ReleaseTypeManual, patched["releaseType"])
+}
+
+func TestSetReleaseTypeLocked(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"data":{"attributes":{"appStoreState":"IN_REVIEW"}}}`, func(ctx context.Context, client *Client) {
+		_, _, err := client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeAfterApproval, nil)
+		assert.Equal(t, ErrReleaseTypeLocked{State: AppStoreVersionStateInReview}, err)
+	})
+}
+
+func TestSetReleaseTypeInvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	at := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	testEndpointCustomBehavior("{}", func(ctx context.Context, client *Client) {
+		_, _, err := client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeScheduled, nil)
+		assert.IsType(t, ErrInvalidReleaseType{}, err)
+
+		_, _, err = client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeManual, &DateTime{Time: at})
+		assert.IsType(t, ErrInvalidReleaseType{}, err)
+
+		_, _, err = client.Publishing.SetReleaseType(ctx, "10", "WHENEVER", nil)
+		assert.IsType(t, ErrInvalidReleaseType{}, err)
+
+		_, _, err = client.Publishing.SetReleaseType(ctx, "10", ReleaseTypeScheduled, &DateTime{Time: at.Add(-72 * time.Hour)})
+		assert.IsType(t, ErrInvalidReleaseDate{}, err)
+	})
+}
+
+func TestNewEarliestReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	location := time.FixedZone("UTC+9", 9*60*60)
+	next := time.Now().In(location).AddDate(0, 0, 2)
+	at := time.Date(next.Year(), next.Month(), next.Day(), 9, 0, 0, 0, location)
+
+	date, err := NewEarliestReleaseDate(at)
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, date.Location())
+	assert.True(t, at.Equal(date.Time))
+	assert.Equal(t, 0, date.Hour())
+
+	_, err = NewEarliestReleaseDate(at.Add(30 * time.Minute))
+	assert.Equal(t, ErrInvalidReleaseDate{Date: at.Add(30 * time.Minute), Reason: "must be on the hour"}, err)
+
+	_, err = NewEarliestReleaseDate(at.AddDate(0, 0, -4))
+	assert.Equal(t, ErrInvalidReleaseDate{Date: at.AddDate(0, 0, -4), Reason: "must be in the future"}, err)
+}
+
+func TestEarliestReleaseDateIn(t *testing.T) {
+	t.Parallel()
+
+	year := time.Now().Year() + 1
+
+	date, err := EarliestReleaseDateIn(year, time.July, 1, 9, "UTC")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(year, time.July, 1, 9, 0, 0, 0, time.UTC), date.Time)
+
+	_, err = EarliestReleaseDateIn(year, time.July, 1, 9, "Asia/Kolkata")
+	assert.IsType(t, ErrInvalidReleaseDate{}, err)
+
+	_, err = EarliestReleaseDateIn(year, time.July, 1, 9, "Not/AZone")
+	assert.Error(t, err)
+}
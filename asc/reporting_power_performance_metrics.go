@@ -25,6 +25,8 @@ import (
 	"fmt"
 )
 
+const xcodeMetricsMediaType = "application/vnd.apple.xcode-metrics+json"
+
 // DiagnosticLog defines model for DiagnosticLog.
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/diagnosticlog
@@ -93,11 +95,77 @@ type PerfPowerMetricAttributes struct {
 
 // PerfPowerMetricsResponse defines model for PerfPowerMetricsResponse.
 //
+// The metrics endpoints respond with an Xcode metrics document, so the measurements themselves
+// are found in ProductData rather than Data.
+//
 // https://developer.apple.com/documentation/appstoreconnectapi/perfpowermetricsresponse
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics
 type PerfPowerMetricsResponse struct {
-	Data  []PerfPowerMetric  `json:"data"`
-	Links PagedDocumentLinks `json:"links"`
-	Meta  *PagingInformation `json:"meta,omitempty"`
+	Data        []PerfPowerMetric         `json:"data"`
+	Links       PagedDocumentLinks        `json:"links"`
+	Meta        *PagingInformation        `json:"meta,omitempty"`
+	Version     string                    `json:"version,omitempty"`
+	ProductData []XcodeMetricsProductData `json:"productData,omitempty"`
+}
+
+// XcodeMetricsProductData defines model for XcodeMetrics.ProductData
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata
+type XcodeMetricsProductData struct {
+	Platform         string                 `json:"platform,omitempty"`
+	MetricCategories []XcodeMetricsCategory `json:"metricCategories,omitempty"`
+}
+
+// XcodeMetricsCategory defines model for XcodeMetrics.ProductData.MetricCategories
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories
+type XcodeMetricsCategory struct {
+	Identifier string        `json:"identifier,omitempty"`
+	Metrics    []XcodeMetric `json:"metrics,omitempty"`
+}
+
+// XcodeMetric defines model for XcodeMetrics.ProductData.MetricCategories.Metrics
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories/metrics
+type XcodeMetric struct {
+	Identifier string               `json:"identifier,omitempty"`
+	Unit       *XcodeMetricUnit     `json:"unit,omitempty"`
+	Datasets   []XcodeMetricDataset `json:"datasets,omitempty"`
+}
+
+// XcodeMetricUnit defines model for XcodeMetrics.ProductData.MetricCategories.Metrics.Unit
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories/metrics/unit
+type XcodeMetricUnit struct {
+	Identifier  string `json:"identifier,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// XcodeMetricDataset defines model for XcodeMetrics.ProductData.MetricCategories.Metrics.Datasets
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories/metrics/datasets
+type XcodeMetricDataset struct {
+	FilterCriteria *XcodeMetricFilterCriteria `json:"filterCriteria,omitempty"`
+	Points         []XcodeMetricPoint         `json:"points,omitempty"`
+}
+
+// XcodeMetricFilterCriteria defines model for XcodeMetrics.ProductData.MetricCategories.Metrics.Datasets.FilterCriteria
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories/metrics/datasets/filtercriteria
+type XcodeMetricFilterCriteria struct {
+	Device              string `json:"device,omitempty"`
+	DeviceMarketingName string `json:"deviceMarketingName,omitempty"`
+	Percentile          string `json:"percentile,omitempty"`
+}
+
+// XcodeMetricPoint defines model for XcodeMetrics.ProductData.MetricCategories.Metrics.Datasets.Points
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/xcodemetrics/productdata/metriccategories/metrics/datasets/points
+type XcodeMetricPoint struct {
+	Version     string   `json:"version,omitempty"`
+	Value       float64  `json:"value"`
+	ErrorMargin *float64 `json:"errorMargin,omitempty"`
+	Goal        string   `json:"goal,omitempty"`
 }
 
 // GetPerfPowerMetricsQuery are query options for GetPerfPowerMetrics
@@ -134,7 +202,7 @@ type GetLogsForDiagnosticSignatureQuery struct {
 func (s *ReportingService) GetPerfPowerMetricsForApp(ctx context.Context, id string, params *GetPerfPowerMetricsQuery) (*PerfPowerMetricsResponse, *Response, error) {
 	url := fmt.Sprintf("v1/apps/%s/perfPowerMetrics", id)
 	res := new(PerfPowerMetricsResponse)
-	resp, err := s.client.get(ctx, url, params, res)
+	resp, err := s.client.get(ctx, url, params, res, withAccept(xcodeMetricsMediaType))
 
 	return res, resp, err
 }
@@ -145,7 +213,7 @@ func (s *ReportingService) GetPerfPowerMetricsForApp(ctx context.Context, id str
 func (s *ReportingService) GetPerfPowerMetricsForBuild(ctx context.Context, id string, params *GetPerfPowerMetricsQuery) (*PerfPowerMetricsResponse, *Response, error) {
 	url := fmt.Sprintf("v1/builds/%s/perfPowerMetrics", id)
 	res := new(PerfPowerMetricsResponse)
-	resp, err := s.client.get(ctx, url, params, res)
+	resp, err := s.client.get(ctx, url, params, res, withAccept(xcodeMetricsMediaType))
 
 	return res, resp, err
 }
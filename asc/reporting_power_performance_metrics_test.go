@@ -23,6 +23,8 @@ package asc
 import (
 	"context"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetPerfPowerMetricsForApp(t *testing.T) {
@@ -41,6 +43,23 @@ func TestGetPerfPowerMetricsForBuild(t *testing.T) {
 	})
 }
 
+func TestGetPerfPowerMetricsForBuildDecodesXcodeMetrics(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"version":"1.0","productData":[{"platform":"IOS","metricCategories":[{"identifier":"HANG","metrics":[{"identifier":"hangRate","unit":{"identifier":"s/hr","displayName":"seconds per hour"},"datasets":[{"filterCriteria":{"percentile":"percentile.fifty","device":"all_iPhones","deviceMarketingName":"All iPhones"},"points":[{"version":"2.1.0","value":0.42,"errorMargin":0.05}]}]}]}]}]}`
+
+	testEndpointCustomBehavior(raw, func(ctx context.Context, client *Client) {
+		metrics, _, err := client.Reporting.GetPerfPowerMetricsForBuild(ctx, "10", &GetPerfPowerMetricsQuery{})
+		assert.NoError(t, err)
+		assert.Len(t, metrics.ProductData, 1)
+
+		metric := metrics.ProductData[0].MetricCategories[0].Metrics[0]
+		assert.Equal(t, "hangRate", metric.Identifier)
+		assert.Equal(t, "percentile.fifty", metric.Datasets[0].FilterCriteria.Percentile)
+		assert.Equal(t, 0.42, metric.Datasets[0].Points[0].Value)
+	})
+}
+
 func TestListDiagnosticSignaturesForBuild(t *testing.T) {
 	t.Parallel()
 
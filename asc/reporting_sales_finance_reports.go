@@ -63,7 +63,16 @@ func (s *ReportingService) DownloadFinanceReports(ctx context.Context, params *D
 // https://developer.apple.com/documentation/appstoreconnectapi/download_sales_and_trends_reports
 func (s *ReportingService) DownloadSalesAndTrendsReports(ctx context.Context, params *DownloadSalesAndTrendsReportsQuery) (io.Reader, *Response, error) {
 	buffer := new(bytes.Buffer)
-	resp, err := s.client.get(ctx, "salesReports", params, buffer, withAccept("application/a-gzip"))
+	resp, err := s.DownloadSalesAndTrendsReportsTo(ctx, params, buffer)
 
 	return buffer, resp, err
 }
+
+// DownloadSalesAndTrendsReportsTo downloads sales and trends reports like
+// DownloadSalesAndTrendsReports, but writes the gzip-compressed report to w as it is received
+// instead of buffering it in memory.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/download_sales_and_trends_reports
+func (s *ReportingService) DownloadSalesAndTrendsReportsTo(ctx context.Context, params *DownloadSalesAndTrendsReportsQuery, w io.Writer) (*Response, error) {
+	return s.client.get(ctx, "v1/salesReports", params, w, withAccept("application/a-gzip"))
+}
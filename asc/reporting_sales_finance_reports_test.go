@@ -21,8 +21,12 @@ along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
 package asc
 
 import (
+	"bytes"
 	"context"
+	"net/http"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDownloadFinanceReports(t *testing.T) {
@@ -40,3 +44,16 @@ func TestDownloadSalesAndTrendsReports(t *testing.T) {
 		return client.Reporting.DownloadSalesAndTrendsReports(ctx, &DownloadSalesAndTrendsReportsQuery{})
 	})
 }
+
+func TestDownloadSalesAndTrendsReportsTo(t *testing.T) {
+	t.Parallel()
+
+	client, server := newServer("ahhhhhhh", http.StatusOK, false)
+	defer server.Close()
+
+	var buffer bytes.Buffer
+	resp, err := client.Reporting.DownloadSalesAndTrendsReportsTo(context.Background(), &DownloadSalesAndTrendsReportsQuery{}, &buffer)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "ahhhhhhh\n", buffer.String())
+}
@@ -26,4 +26,6 @@ package asc
 // https://developer.apple.com/documentation/appstoreconnectapi/app_store_review_details
 // https://developer.apple.com/documentation/appstoreconnectapi/app_store_review_attachments
 // https://developer.apple.com/documentation/appstoreconnectapi/app_store_version_submissions
+// https://developer.apple.com/documentation/appstoreconnectapi/review_submissions
+// https://developer.apple.com/documentation/appstoreconnectapi/review_submission_items
 type SubmissionService service
@@ -76,6 +76,9 @@ type GetAppStoreVersionSubmissionForAppStoreVersionQuery struct {
 
 // CreateSubmission submits an App Store version to App Review.
 //
+// Deprecated: App Store Connect now expects submissions through review submissions. Use
+// CreateReviewSubmission, CreateReviewSubmissionItem and SubmitReviewSubmission instead.
+//
 // https://developer.apple.com/documentation/appstoreconnectapi/create_an_app_store_version_submission
 func (s *SubmissionService) CreateSubmission(ctx context.Context, appStoreVersionID string) (*AppStoreVersionSubmissionResponse, *Response, error) {
 	req := appStoreVersionSubmissionCreateRequest{
@@ -0,0 +1,495 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReviewSubmissionState defines model for ReviewSubmissionState.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmission/attributes
+type ReviewSubmissionState string
+
+const (
+	// ReviewSubmissionStateReadyForReview is a review submission state for ReadyForReview.
+	ReviewSubmissionStateReadyForReview ReviewSubmissionState = "READY_FOR_REVIEW"
+	// ReviewSubmissionStateWaitingForReview is a review submission state for WaitingForReview.
+	ReviewSubmissionStateWaitingForReview ReviewSubmissionState = "WAITING_FOR_REVIEW"
+	// ReviewSubmissionStateInReview is a review submission state for InReview.
+	ReviewSubmissionStateInReview ReviewSubmissionState = "IN_REVIEW"
+	// ReviewSubmissionStateUnresolvedIssues is a review submission state for UnresolvedIssues.
+	ReviewSubmissionStateUnresolvedIssues ReviewSubmissionState = "UNRESOLVED_ISSUES"
+	// ReviewSubmissionStateCanceling is a review submission state for Canceling.
+	ReviewSubmissionStateCanceling ReviewSubmissionState = "CANCELING"
+	// ReviewSubmissionStateCompleting is a review submission state for Completing.
+	ReviewSubmissionStateCompleting ReviewSubmissionState = "COMPLETING"
+	// ReviewSubmissionStateComplete is a review submission state for Complete.
+	ReviewSubmissionStateComplete ReviewSubmissionState = "COMPLETE"
+)
+
+// ReviewSubmissionItemState defines model for ReviewSubmissionItemState.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitem/attributes
+type ReviewSubmissionItemState string
+
+const (
+	// ReviewSubmissionItemStateReadyForReview is a review submission item state for ReadyForReview.
+	ReviewSubmissionItemStateReadyForReview ReviewSubmissionItemState = "READY_FOR_REVIEW"
+	// ReviewSubmissionItemStateAccepted is a review submission item state for Accepted.
+	ReviewSubmissionItemStateAccepted ReviewSubmissionItemState = "ACCEPTED"
+	// ReviewSubmissionItemStateApproved is a review submission item state for Approved.
+	ReviewSubmissionItemStateApproved ReviewSubmissionItemState = "APPROVED"
+	// ReviewSubmissionItemStateRejected is a review submission item state for Rejected.
+	ReviewSubmissionItemStateRejected ReviewSubmissionItemState = "REJECTED"
+	// ReviewSubmissionItemStateRemoved is a review submission item state for Removed.
+	ReviewSubmissionItemStateRemoved ReviewSubmissionItemState = "REMOVED"
+)
+
+// ReviewSubmissionItemType is the kind of resource a review submission item submits for review.
+type ReviewSubmissionItemType string
+
+const (
+	// ReviewSubmissionItemTypeAppStoreVersion submits an App Store version.
+	ReviewSubmissionItemTypeAppStoreVersion ReviewSubmissionItemType = "appStoreVersions"
+	// ReviewSubmissionItemTypeAppCustomProductPageVersion submits a custom product page version.
+	ReviewSubmissionItemTypeAppCustomProductPageVersion ReviewSubmissionItemType = "appCustomProductPageVersions"
+	// ReviewSubmissionItemTypeAppStoreVersionExperiment submits a product page optimization experiment.
+	ReviewSubmissionItemTypeAppStoreVersionExperiment ReviewSubmissionItemType = "appStoreVersionExperiments"
+	// ReviewSubmissionItemTypeAppEvent submits an in-app event.
+	ReviewSubmissionItemTypeAppEvent ReviewSubmissionItemType = "appEvents"
+)
+
+// ErrInvalidReviewSubmissionItemType happens when CreateReviewSubmissionItem is called with an item type
+// that App Store Connect does not accept in a review submission.
+type ErrInvalidReviewSubmissionItemType struct {
+	Type ReviewSubmissionItemType
+}
+
+func (e ErrInvalidReviewSubmissionItemType) Error() string {
+	return fmt.Sprintf("type %s cannot be added to a review submission", e.Type)
+}
+
+// ReviewSubmission defines model for ReviewSubmission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmission
+type ReviewSubmission struct {
+	Attributes    *ReviewSubmissionAttributes    `json:"attributes,omitempty"`
+	ID            string                         `json:"id"`
+	Links         ResourceLinks                  `json:"links"`
+	Relationships *ReviewSubmissionRelationships `json:"relationships,omitempty"`
+	Type          string                         `json:"type"`
+}
+
+// ReviewSubmissionAttributes defines model for ReviewSubmission.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmission/attributes
+type ReviewSubmissionAttributes struct {
+	Platform      *Platform              `json:"platform,omitempty"`
+	State         *ReviewSubmissionState `json:"state,omitempty"`
+	SubmittedDate *DateTime              `json:"submittedDate,omitempty"`
+}
+
+// ReviewSubmissionRelationships defines model for ReviewSubmission.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmission/relationships
+type ReviewSubmissionRelationships struct {
+	App                      *Relationship      `json:"app,omitempty"`
+	AppStoreVersionForReview *Relationship      `json:"appStoreVersionForReview,omitempty"`
+	Items                    *PagedRelationship `json:"items,omitempty"`
+	LastUpdatedByActor       *Relationship      `json:"lastUpdatedByActor,omitempty"`
+	SubmittedByActor         *Relationship      `json:"submittedByActor,omitempty"`
+}
+
+// ReviewSubmissionResponse defines model for ReviewSubmissionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionresponse
+type ReviewSubmissionResponse struct {
+	Data     ReviewSubmission                   `json:"data"`
+	Included []ReviewSubmissionResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                      `json:"links"`
+}
+
+// ReviewSubmissionsResponse defines model for ReviewSubmissionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionsresponse
+type ReviewSubmissionsResponse struct {
+	Data     []ReviewSubmission                 `json:"data"`
+	Included []ReviewSubmissionResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks                 `json:"links"`
+	Meta     *PagingInformation                 `json:"meta,omitempty"`
+}
+
+// ReviewSubmissionResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a ReviewSubmissionResponse or ReviewSubmissionsResponse.
+type ReviewSubmissionResponseIncluded included
+
+// reviewSubmissionCreateRequest defines model for ReviewSubmissionCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissioncreaterequest/data
+type reviewSubmissionCreateRequest struct {
+	Attributes    reviewSubmissionCreateRequestAttributes    `json:"attributes"`
+	Relationships reviewSubmissionCreateRequestRelationships `json:"relationships"`
+	Type          string                                     `json:"type"`
+}
+
+// reviewSubmissionCreateRequestAttributes are attributes for ReviewSubmissionCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissioncreaterequest/data/attributes
+type reviewSubmissionCreateRequestAttributes struct {
+	Platform Platform `json:"platform"`
+}
+
+// reviewSubmissionCreateRequestRelationships are relationships for ReviewSubmissionCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissioncreaterequest/data/relationships
+type reviewSubmissionCreateRequestRelationships struct {
+	App relationshipDeclaration `json:"app"`
+}
+
+// reviewSubmissionUpdateRequest defines model for ReviewSubmissionUpdateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionupdaterequest/data
+type reviewSubmissionUpdateRequest struct {
+	Attributes *ReviewSubmissionUpdateRequestAttributes `json:"attributes,omitempty"`
+	ID         string                                   `json:"id"`
+	Type       string                                   `json:"type"`
+}
+
+// ReviewSubmissionUpdateRequestAttributes are attributes for ReviewSubmissionUpdateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionupdaterequest/data/attributes
+type ReviewSubmissionUpdateRequestAttributes struct {
+	Canceled  *bool     `json:"canceled,omitempty"`
+	Platform  *Platform `json:"platform,omitempty"`
+	Submitted *bool     `json:"submitted,omitempty"`
+}
+
+// ReviewSubmissionItem defines model for ReviewSubmissionItem.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitem
+type ReviewSubmissionItem struct {
+	Attributes    *ReviewSubmissionItemAttributes    `json:"attributes,omitempty"`
+	ID            string                             `json:"id"`
+	Links         ResourceLinks                      `json:"links"`
+	Relationships *ReviewSubmissionItemRelationships `json:"relationships,omitempty"`
+	Type          string                             `json:"type"`
+}
+
+// ReviewSubmissionItemAttributes defines model for ReviewSubmissionItem.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitem/attributes
+type ReviewSubmissionItemAttributes struct {
+	State *ReviewSubmissionItemState `json:"state,omitempty"`
+}
+
+// ReviewSubmissionItemRelationships defines model for ReviewSubmissionItem.Relationships
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitem/relationships
+type ReviewSubmissionItemRelationships struct {
+	AppCustomProductPageVersion *Relationship `json:"appCustomProductPageVersion,omitempty"`
+	AppEvent                    *Relationship `json:"appEvent,omitempty"`
+	AppStoreVersion             *Relationship `json:"appStoreVersion,omitempty"`
+	AppStoreVersionExperiment   *Relationship `json:"appStoreVersionExperiment,omitempty"`
+	ReviewSubmission            *Relationship `json:"reviewSubmission,omitempty"`
+}
+
+// ReviewSubmissionItemResponse defines model for ReviewSubmissionItemResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemresponse
+type ReviewSubmissionItemResponse struct {
+	Data     ReviewSubmissionItem                   `json:"data"`
+	Included []ReviewSubmissionItemResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                          `json:"links"`
+}
+
+// ReviewSubmissionItemsResponse defines model for ReviewSubmissionItemsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemsresponse
+type ReviewSubmissionItemsResponse struct {
+	Data     []ReviewSubmissionItem                 `json:"data"`
+	Included []ReviewSubmissionItemResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks                     `json:"links"`
+	Meta     *PagingInformation                     `json:"meta,omitempty"`
+}
+
+// ReviewSubmissionItemResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a ReviewSubmissionItemResponse or ReviewSubmissionItemsResponse.
+type ReviewSubmissionItemResponseIncluded included
+
+// reviewSubmissionItemCreateRequest defines model for ReviewSubmissionItemCreateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemcreaterequest/data
+type reviewSubmissionItemCreateRequest struct {
+	Relationships reviewSubmissionItemCreateRequestRelationships `json:"relationships"`
+	Type          string                                         `json:"type"`
+}
+
+// reviewSubmissionItemCreateRequestRelationships are relationships for ReviewSubmissionItemCreateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemcreaterequest/data/relationships
+type reviewSubmissionItemCreateRequestRelationships struct {
+	AppCustomProductPageVersion *relationshipDeclaration `json:"appCustomProductPageVersion,omitempty"`
+	AppEvent                    *relationshipDeclaration `json:"appEvent,omitempty"`
+	AppStoreVersion             *relationshipDeclaration `json:"appStoreVersion,omitempty"`
+	AppStoreVersionExperiment   *relationshipDeclaration `json:"appStoreVersionExperiment,omitempty"`
+	ReviewSubmission            relationshipDeclaration  `json:"reviewSubmission"`
+}
+
+// reviewSubmissionItemUpdateRequest defines model for ReviewSubmissionItemUpdateRequest.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemupdaterequest/data
+type reviewSubmissionItemUpdateRequest struct {
+	Attributes *ReviewSubmissionItemUpdateRequestAttributes `json:"attributes,omitempty"`
+	ID         string                                       `json:"id"`
+	Type       string                                       `json:"type"`
+}
+
+// ReviewSubmissionItemUpdateRequestAttributes are attributes for ReviewSubmissionItemUpdateRequest
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/reviewsubmissionitemupdaterequest/data/attributes
+type ReviewSubmissionItemUpdateRequestAttributes struct {
+	Removed  *bool `json:"removed,omitempty"`
+	Resolved *bool `json:"resolved,omitempty"`
+}
+
+// ListReviewSubmissionsQuery are query options for ListReviewSubmissions
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions
+type ListReviewSubmissionsQuery struct {
+	FieldsReviewSubmissions     []string `url:"fields[reviewSubmissions],omitempty"`
+	FieldsReviewSubmissionItems []string `url:"fields[reviewSubmissionItems],omitempty"`
+	FilterApp                   []string `url:"filter[app],omitempty"`
+	FilterPlatform              []string `url:"filter[platform],omitempty"`
+	FilterState                 []string `url:"filter[state],omitempty"`
+	Include                     []string `url:"include,omitempty"`
+	Limit                       int      `url:"limit,omitempty"`
+	LimitItems                  int      `url:"limit[items],omitempty"`
+	Cursor                      string   `url:"cursor,omitempty"`
+}
+
+// GetReviewSubmissionQuery are query options for GetReviewSubmission
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions_id
+type GetReviewSubmissionQuery struct {
+	FieldsReviewSubmissions     []string `url:"fields[reviewSubmissions],omitempty"`
+	FieldsReviewSubmissionItems []string `url:"fields[reviewSubmissionItems],omitempty"`
+	Include                     []string `url:"include,omitempty"`
+	LimitItems                  int      `url:"limit[items],omitempty"`
+}
+
+// ListItemsForReviewSubmissionQuery are query options for ListItemsForReviewSubmission
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions_id_items
+type ListItemsForReviewSubmissionQuery struct {
+	FieldsAppStoreVersions      []string `url:"fields[appStoreVersions],omitempty"`
+	FieldsReviewSubmissionItems []string `url:"fields[reviewSubmissionItems],omitempty"`
+	Include                     []string `url:"include,omitempty"`
+	Limit                       int      `url:"limit,omitempty"`
+	Cursor                      string   `url:"cursor,omitempty"`
+}
+
+// CreateReviewSubmission creates a review submission for an app on a platform. Items are added to it
+// with CreateReviewSubmissionItem before it is submitted with SubmitReviewSubmission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/post_v1_reviewsubmissions
+func (s *SubmissionService) CreateReviewSubmission(ctx context.Context, platform Platform, appID string) (*ReviewSubmissionResponse, *Response, error) {
+	req := reviewSubmissionCreateRequest{
+		Attributes: reviewSubmissionCreateRequestAttributes{
+			Platform: platform,
+		},
+		Relationships: reviewSubmissionCreateRequestRelationships{
+			App: *newRelationshipDeclaration(&appID, "apps"),
+		},
+		Type: "reviewSubmissions",
+	}
+	res := new(ReviewSubmissionResponse)
+	resp, err := s.client.post(ctx, "v1/reviewSubmissions", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// ListReviewSubmissions finds and lists the review submissions of an app. FilterApp is required.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions
+func (s *SubmissionService) ListReviewSubmissions(ctx context.Context, params *ListReviewSubmissionsQuery) (*ReviewSubmissionsResponse, *Response, error) {
+	res := new(ReviewSubmissionsResponse)
+	resp, err := s.client.get(ctx, "v1/reviewSubmissions", params, res)
+
+	return res, resp, err
+}
+
+// GetReviewSubmission gets information about a specific review submission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions_id
+func (s *SubmissionService) GetReviewSubmission(ctx context.Context, id string, params *GetReviewSubmissionQuery) (*ReviewSubmissionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/reviewSubmissions/%s", id)
+	res := new(ReviewSubmissionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// UpdateReviewSubmission modifies a review submission, which is how it is submitted or canceled.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/patch_v1_reviewsubmissions_id
+func (s *SubmissionService) UpdateReviewSubmission(ctx context.Context, id string, attributes *ReviewSubmissionUpdateRequestAttributes) (*ReviewSubmissionResponse, *Response, error) {
+	req := reviewSubmissionUpdateRequest{
+		Attributes: attributes,
+		ID:         id,
+		Type:       "reviewSubmissions",
+	}
+	url := fmt.Sprintf("v1/reviewSubmissions/%s", id)
+	res := new(ReviewSubmissionResponse)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// SubmitReviewSubmission submits a review submission and all of its items to App Review.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/patch_v1_reviewsubmissions_id
+func (s *SubmissionService) SubmitReviewSubmission(ctx context.Context, id string) (*ReviewSubmissionResponse, *Response, error) {
+	return s.UpdateReviewSubmission(ctx, id, &ReviewSubmissionUpdateRequestAttributes{
+		Submitted: Bool(true),
+	})
+}
+
+// CancelReviewSubmission withdraws a review submission from App Review.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/patch_v1_reviewsubmissions_id
+func (s *SubmissionService) CancelReviewSubmission(ctx context.Context, id string) (*ReviewSubmissionResponse, *Response, error) {
+	return s.UpdateReviewSubmission(ctx, id, &ReviewSubmissionUpdateRequestAttributes{
+		Canceled: Bool(true),
+	})
+}
+
+// ListItemsForReviewSubmission lists the items in a review submission along with their review states.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_v1_reviewsubmissions_id_items
+func (s *SubmissionService) ListItemsForReviewSubmission(ctx context.Context, id string, params *ListItemsForReviewSubmissionQuery) (*ReviewSubmissionItemsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/reviewSubmissions/%s/items", id)
+	res := new(ReviewSubmissionItemsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// CreateReviewSubmissionItem adds a resource, such as an App Store version or an in-app event, to a review submission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/post_v1_reviewsubmissionitems
+func (s *SubmissionService) CreateReviewSubmissionItem(ctx context.Context, reviewSubmissionID string, itemType ReviewSubmissionItemType, itemID string) (*ReviewSubmissionItemResponse, *Response, error) {
+	req := reviewSubmissionItemCreateRequest{
+		Relationships: reviewSubmissionItemCreateRequestRelationships{
+			ReviewSubmission: *newRelationshipDeclaration(&reviewSubmissionID, "reviewSubmissions"),
+		},
+		Type: "reviewSubmissionItems",
+	}
+
+	item := newRelationshipDeclaration(&itemID, string(itemType))
+
+	switch itemType {
+	case ReviewSubmissionItemTypeAppStoreVersion:
+		req.Relationships.AppStoreVersion = item
+	case ReviewSubmissionItemTypeAppCustomProductPageVersion:
+		req.Relationships.AppCustomProductPageVersion = item
+	case ReviewSubmissionItemTypeAppStoreVersionExperiment:
+		req.Relationships.AppStoreVersionExperiment = item
+	case ReviewSubmissionItemTypeAppEvent:
+		req.Relationships.AppEvent = item
+	default:
+		return nil, nil, ErrInvalidReviewSubmissionItemType{Type: itemType}
+	}
+
+	res := new(ReviewSubmissionItemResponse)
+	resp, err := s.client.post(ctx, "v1/reviewSubmissionItems", newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// UpdateReviewSubmissionItem marks an item in a review submission as removed or its issues as resolved.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/patch_v1_reviewsubmissionitems_id
+func (s *SubmissionService) UpdateReviewSubmissionItem(ctx context.Context, id string, attributes *ReviewSubmissionItemUpdateRequestAttributes) (*ReviewSubmissionItemResponse, *Response, error) {
+	req := reviewSubmissionItemUpdateRequest{
+		Attributes: attributes,
+		ID:         id,
+		Type:       "reviewSubmissionItems",
+	}
+	url := fmt.Sprintf("v1/reviewSubmissionItems/%s", id)
+	res := new(ReviewSubmissionItemResponse)
+	resp, err := s.client.patch(ctx, url, newRequestBody(req), res)
+
+	return res, resp, err
+}
+
+// DeleteReviewSubmissionItem removes an item from a review submission that has not been submitted.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete_v1_reviewsubmissionitems_id
+func (s *SubmissionService) DeleteReviewSubmissionItem(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/reviewSubmissionItems/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in ReviewSubmissionResponseIncluded.
+func (i *ReviewSubmissionResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// App returns the App stored within, if one is present.
+func (i *ReviewSubmissionResponseIncluded) App() *App {
+	return extractIncludedApp(i.inner)
+}
+
+// AppStoreVersion returns the AppStoreVersion stored within, if one is present.
+func (i *ReviewSubmissionResponseIncluded) AppStoreVersion() *AppStoreVersion {
+	return extractIncludedAppStoreVersion(i.inner)
+}
+
+// ReviewSubmissionItem returns the ReviewSubmissionItem stored within, if one is present.
+func (i *ReviewSubmissionResponseIncluded) ReviewSubmissionItem() *ReviewSubmissionItem {
+	return extractIncludedReviewSubmissionItem(i.inner)
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in ReviewSubmissionItemResponseIncluded.
+func (i *ReviewSubmissionItemResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// AppStoreVersion returns the AppStoreVersion stored within, if one is present.
+func (i *ReviewSubmissionItemResponseIncluded) AppStoreVersion() *AppStoreVersion {
+	return extractIncludedAppStoreVersion(i.inner)
+}
+
+// ReviewSubmission returns the ReviewSubmission stored within, if one is present.
+func (i *ReviewSubmissionItemResponseIncluded) ReviewSubmission() *ReviewSubmission {
+	return extractIncludedReviewSubmission(i.inner)
+}
@@ -0,0 +1,163 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.CreateReviewSubmission(ctx, PlatformIOS, "10")
+	})
+}
+
+func TestListReviewSubmissions(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.ListReviewSubmissions(ctx, &ListReviewSubmissionsQuery{
+			FilterApp:   []string{"10"},
+			FilterState: []string{string(ReviewSubmissionStateReadyForReview)},
+		})
+	})
+}
+
+func TestGetReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.GetReviewSubmission(ctx, "10", &GetReviewSubmissionQuery{})
+	})
+}
+
+func TestGetReviewSubmissionIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"apps"},{"type":"appStoreVersions"},{"type":"reviewSubmissionItems"}]}`, func(ctx context.Context, client *Client) {
+		submission, _, err := client.Submission.GetReviewSubmission(ctx, "10", &GetReviewSubmissionQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, submission.Included)
+
+		assert.NotNil(t, submission.Included[0].App())
+		assert.NotNil(t, submission.Included[1].AppStoreVersion())
+		assert.NotNil(t, submission.Included[2].ReviewSubmissionItem())
+
+		assert.Nil(t, submission.Included[0].AppStoreVersion())
+		assert.Nil(t, submission.Included[1].ReviewSubmissionItem())
+		assert.Nil(t, submission.Included[2].App())
+	})
+}
+
+func TestUpdateReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.UpdateReviewSubmission(ctx, "10", &ReviewSubmissionUpdateRequestAttributes{})
+	})
+}
+
+func TestSubmitReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.SubmitReviewSubmission(ctx, "10")
+	})
+}
+
+func TestCancelReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.CancelReviewSubmission(ctx, "10")
+	})
+}
+
+func TestListItemsForReviewSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionItemsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.ListItemsForReviewSubmission(ctx, "10", &ListItemsForReviewSubmissionQuery{})
+	})
+}
+
+func TestListItemsForReviewSubmissionIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"appStoreVersions"},{"type":"reviewSubmissions"}]}`, func(ctx context.Context, client *Client) {
+		items, _, err := client.Submission.ListItemsForReviewSubmission(ctx, "10", &ListItemsForReviewSubmissionQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, items.Included)
+
+		assert.NotNil(t, items.Included[0].AppStoreVersion())
+		assert.NotNil(t, items.Included[1].ReviewSubmission())
+
+		assert.Nil(t, items.Included[0].ReviewSubmission())
+		assert.Nil(t, items.Included[1].AppStoreVersion())
+	})
+}
+
+func TestCreateReviewSubmissionItem(t *testing.T) {
+	t.Parallel()
+
+	for _, itemType := range []ReviewSubmissionItemType{
+		ReviewSubmissionItemTypeAppStoreVersion,
+		ReviewSubmissionItemTypeAppCustomProductPageVersion,
+		ReviewSubmissionItemTypeAppStoreVersionExperiment,
+		ReviewSubmissionItemTypeAppEvent,
+	} {
+		itemType := itemType
+
+		testEndpointWithResponse(t, "{}", &ReviewSubmissionItemResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+			return client.Submission.CreateReviewSubmissionItem(ctx, "10", itemType, "20")
+		})
+	}
+}
+
+func TestCreateReviewSubmissionItemInvalidType(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior("{}", func(ctx context.Context, client *Client) {
+		_, _, err := client.Submission.CreateReviewSubmissionItem(ctx, "10", "builds", "20")
+		assert.Equal(t, ErrInvalidReviewSubmissionItemType{Type: "builds"}, err)
+	})
+}
+
+func TestUpdateReviewSubmissionItem(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &ReviewSubmissionItemResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.Submission.UpdateReviewSubmissionItem(ctx, "10", &ReviewSubmissionItemUpdateRequestAttributes{Resolved: Bool(true)})
+	})
+}
+
+func TestDeleteReviewSubmissionItem(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.Submission.DeleteReviewSubmissionItem(ctx, "10")
+	})
+}
@@ -23,4 +23,6 @@ package asc
 // TestflightService handles communication with TestFlight-related methods of the App Store Connect API
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/prerelease_versions_and_beta_testers
+// https://developer.apple.com/documentation/appstoreconnectapi/testflight/beta-feedback
+// https://developer.apple.com/documentation/appstoreconnectapi/testflight/beta-recruitment-criteria
 type TestflightService service
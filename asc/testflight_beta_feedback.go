@@ -0,0 +1,341 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMissingScreenshotURL happens when a feedback screenshot is downloaded without a URL to download it from.
+var ErrMissingScreenshotURL = errors.New("beta feedback screenshot has no url")
+
+// DeviceFamily defines model for DeviceFamily.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/devicefamily
+type DeviceFamily string
+
+const (
+	// DeviceFamilyIPhone is a device family for iPhone.
+	DeviceFamilyIPhone DeviceFamily = "IPHONE"
+	// DeviceFamilyIPad is a device family for iPad.
+	DeviceFamilyIPad DeviceFamily = "IPAD"
+	// DeviceFamilyAppleTV is a device family for Apple TV.
+	DeviceFamilyAppleTV DeviceFamily = "APPLE_TV"
+	// DeviceFamilyAppleWatch is a device family for Apple Watch.
+	DeviceFamilyAppleWatch DeviceFamily = "APPLE_WATCH"
+	// DeviceFamilyMac is a device family for Mac.
+	DeviceFamilyMac DeviceFamily = "MAC"
+	// DeviceFamilyVision is a device family for Apple Vision.
+	DeviceFamilyVision DeviceFamily = "VISION"
+)
+
+// BetaFeedbackScreenshotSubmission defines model for BetaFeedbackScreenshotSubmission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackscreenshotsubmission
+type BetaFeedbackScreenshotSubmission struct {
+	Attributes    *BetaFeedbackScreenshotSubmissionAttributes `json:"attributes,omitempty"`
+	ID            string                                      `json:"id"`
+	Links         ResourceLinks                               `json:"links"`
+	Relationships *BetaFeedbackSubmissionRelationships        `json:"relationships,omitempty"`
+	Type          string                                      `json:"type"`
+}
+
+// BetaFeedbackScreenshotSubmissionAttributes defines model for BetaFeedbackScreenshotSubmission.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackscreenshotsubmission/attributes
+type BetaFeedbackScreenshotSubmissionAttributes struct {
+	BetaFeedbackSubmissionAttributes
+	Screenshots []BetaFeedbackScreenshotImage `json:"screenshots,omitempty"`
+}
+
+// BetaFeedbackScreenshotImage defines model for BetaFeedbackScreenshotImage.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackscreenshotimage
+type BetaFeedbackScreenshotImage struct {
+	ExpirationDate *DateTime `json:"expirationDate,omitempty"`
+	Height         *int      `json:"height,omitempty"`
+	URL            *string   `json:"url,omitempty"`
+	Width          *int      `json:"width,omitempty"`
+}
+
+// BetaFeedbackCrashSubmission defines model for BetaFeedbackCrashSubmission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackcrashsubmission
+type BetaFeedbackCrashSubmission struct {
+	Attributes    *BetaFeedbackSubmissionAttributes    `json:"attributes,omitempty"`
+	ID            string                               `json:"id"`
+	Links         ResourceLinks                        `json:"links"`
+	Relationships *BetaFeedbackSubmissionRelationships `json:"relationships,omitempty"`
+	Type          string                               `json:"type"`
+}
+
+// BetaFeedbackSubmissionAttributes are the attributes shared by BetaFeedbackScreenshotSubmission.Attributes
+// and BetaFeedbackCrashSubmission.Attributes.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackcrashsubmission/attributes
+type BetaFeedbackSubmissionAttributes struct {
+	AppPlatform             *Platform     `json:"appPlatform,omitempty"`
+	AppUptimeInMilliseconds *int64        `json:"appUptimeInMilliseconds,omitempty"`
+	Architecture            *string       `json:"architecture,omitempty"`
+	BatteryPercentage       *int          `json:"batteryPercentage,omitempty"`
+	BuildBundleID           *string       `json:"buildBundleId,omitempty"`
+	Comment                 *string       `json:"comment,omitempty"`
+	ConnectionType          *string       `json:"connectionType,omitempty"`
+	CreatedDate             *DateTime     `json:"createdDate,omitempty"`
+	DeviceFamily            *DeviceFamily `json:"deviceFamily,omitempty"`
+	DeviceModel             *string       `json:"deviceModel,omitempty"`
+	DevicePlatform          *Platform     `json:"devicePlatform,omitempty"`
+	DiskBytesAvailable      *int64        `json:"diskBytesAvailable,omitempty"`
+	DiskBytesTotal          *int64        `json:"diskBytesTotal,omitempty"`
+	Email                   *string       `json:"email,omitempty"`
+	Locale                  *string       `json:"locale,omitempty"`
+	OSVersion               *string       `json:"osVersion,omitempty"`
+	PairedAppleWatch        *string       `json:"pairedAppleWatch,omitempty"`
+	ScreenHeightInPoints    *int          `json:"screenHeightInPoints,omitempty"`
+	ScreenWidthInPoints     *int          `json:"screenWidthInPoints,omitempty"`
+	TimeZone                *string       `json:"timeZone,omitempty"`
+}
+
+// BetaFeedbackSubmissionRelationships are the relationships shared by BetaFeedbackScreenshotSubmission.Relationships
+// and BetaFeedbackCrashSubmission.Relationships.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackcrashsubmission/relationships
+type BetaFeedbackSubmissionRelationships struct {
+	Build    *Relationship `json:"build,omitempty"`
+	CrashLog *Relationship `json:"crashLog,omitempty"`
+	Tester   *Relationship `json:"tester,omitempty"`
+}
+
+// BetaFeedbackScreenshotSubmissionResponse defines model for BetaFeedbackScreenshotSubmissionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackscreenshotsubmissionresponse
+type BetaFeedbackScreenshotSubmissionResponse struct {
+	Data     BetaFeedbackScreenshotSubmission `json:"data"`
+	Included []BetaFeedbackResponseIncluded   `json:"included,omitempty"`
+	Links    DocumentLinks                    `json:"links"`
+}
+
+// BetaFeedbackScreenshotSubmissionsResponse defines model for BetaFeedbackScreenshotSubmissionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackscreenshotsubmissionsresponse
+type BetaFeedbackScreenshotSubmissionsResponse struct {
+	Data     []BetaFeedbackScreenshotSubmission `json:"data"`
+	Included []BetaFeedbackResponseIncluded     `json:"included,omitempty"`
+	Links    PagedDocumentLinks                 `json:"links"`
+	Meta     *PagingInformation                 `json:"meta,omitempty"`
+}
+
+// BetaFeedbackCrashSubmissionResponse defines model for BetaFeedbackCrashSubmissionResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackcrashsubmissionresponse
+type BetaFeedbackCrashSubmissionResponse struct {
+	Data     BetaFeedbackCrashSubmission    `json:"data"`
+	Included []BetaFeedbackResponseIncluded `json:"included,omitempty"`
+	Links    DocumentLinks                  `json:"links"`
+}
+
+// BetaFeedbackCrashSubmissionsResponse defines model for BetaFeedbackCrashSubmissionsResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betafeedbackcrashsubmissionsresponse
+type BetaFeedbackCrashSubmissionsResponse struct {
+	Data     []BetaFeedbackCrashSubmission  `json:"data"`
+	Included []BetaFeedbackResponseIncluded `json:"included,omitempty"`
+	Links    PagedDocumentLinks             `json:"links"`
+	Meta     *PagingInformation             `json:"meta,omitempty"`
+}
+
+// BetaFeedbackResponseIncluded is a heterogenous wrapper for the possible types that can be returned
+// in a BetaFeedbackScreenshotSubmissionResponse or BetaFeedbackCrashSubmissionResponse.
+type BetaFeedbackResponseIncluded included
+
+// BetaCrashLog defines model for BetaCrashLog.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betacrashlog
+type BetaCrashLog struct {
+	Attributes *BetaCrashLogAttributes `json:"attributes,omitempty"`
+	ID         string                  `json:"id"`
+	Links      ResourceLinks           `json:"links"`
+	Type       string                  `json:"type"`
+}
+
+// BetaCrashLogAttributes defines model for BetaCrashLog.Attributes
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betacrashlog/attributes
+type BetaCrashLogAttributes struct {
+	LogText *string `json:"logText,omitempty"`
+}
+
+// BetaCrashLogResponse defines model for BetaCrashLogResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betacrashlogresponse
+type BetaCrashLogResponse struct {
+	Data  BetaCrashLog  `json:"data"`
+	Links DocumentLinks `json:"links"`
+}
+
+// ListBetaFeedbackSubmissionsForAppQuery are query options for ListBetaFeedbackScreenshotSubmissionsForApp
+// and ListBetaFeedbackCrashSubmissionsForApp.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-apps-_id_-betafeedbackscreenshotsubmissions
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-apps-_id_-betafeedbackcrashsubmissions
+type ListBetaFeedbackSubmissionsForAppQuery struct {
+	FieldsBetaFeedbackScreenshotSubmissions []string `url:"fields[betaFeedbackScreenshotSubmissions],omitempty"`
+	FieldsBetaFeedbackCrashSubmissions      []string `url:"fields[betaFeedbackCrashSubmissions],omitempty"`
+	FieldsBuilds                            []string `url:"fields[builds],omitempty"`
+	FieldsBetaTesters                       []string `url:"fields[betaTesters],omitempty"`
+	FilterAppPlatform                       []string `url:"filter[appPlatform],omitempty"`
+	FilterBuild                             []string `url:"filter[build],omitempty"`
+	FilterBuildPreReleaseVersion            []string `url:"filter[build.preReleaseVersion],omitempty"`
+	FilterDeviceModel                       []string `url:"filter[deviceModel],omitempty"`
+	FilterDevicePlatform                    []string `url:"filter[devicePlatform],omitempty"`
+	FilterOSVersion                         []string `url:"filter[osVersion],omitempty"`
+	FilterTester                            []string `url:"filter[tester],omitempty"`
+	Include                                 []string `url:"include,omitempty"`
+	Sort                                    []string `url:"sort,omitempty"`
+	Limit                                   int      `url:"limit,omitempty"`
+	Cursor                                  string   `url:"cursor,omitempty"`
+}
+
+// GetBetaFeedbackSubmissionQuery are query options for GetBetaFeedbackScreenshotSubmission and
+// GetBetaFeedbackCrashSubmission.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackscreenshotsubmissions-_id_
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackcrashsubmissions-_id_
+type GetBetaFeedbackSubmissionQuery struct {
+	FieldsBetaFeedbackScreenshotSubmissions []string `url:"fields[betaFeedbackScreenshotSubmissions],omitempty"`
+	FieldsBetaFeedbackCrashSubmissions      []string `url:"fields[betaFeedbackCrashSubmissions],omitempty"`
+	FieldsBuilds                            []string `url:"fields[builds],omitempty"`
+	FieldsBetaTesters                       []string `url:"fields[betaTesters],omitempty"`
+	Include                                 []string `url:"include,omitempty"`
+}
+
+// GetCrashLogForBetaFeedbackCrashSubmissionQuery are query options for GetCrashLogForBetaFeedbackCrashSubmission
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackcrashsubmissions-_id_-crashlog
+type GetCrashLogForBetaFeedbackCrashSubmissionQuery struct {
+	FieldsBetaCrashLogs []string `url:"fields[betaCrashLogs],omitempty"`
+}
+
+// ListBetaFeedbackScreenshotSubmissionsForApp lists the screenshot feedback sent by testers of an app.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-apps-_id_-betafeedbackscreenshotsubmissions
+func (s *TestflightService) ListBetaFeedbackScreenshotSubmissionsForApp(ctx context.Context, id string, params *ListBetaFeedbackSubmissionsForAppQuery) (*BetaFeedbackScreenshotSubmissionsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/apps/%s/betaFeedbackScreenshotSubmissions", id)
+	res := new(BetaFeedbackScreenshotSubmissionsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetBetaFeedbackScreenshotSubmission gets a single piece of screenshot feedback.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackscreenshotsubmissions-_id_
+func (s *TestflightService) GetBetaFeedbackScreenshotSubmission(ctx context.Context, id string, params *GetBetaFeedbackSubmissionQuery) (*BetaFeedbackScreenshotSubmissionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaFeedbackScreenshotSubmissions/%s", id)
+	res := new(BetaFeedbackScreenshotSubmissionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DeleteBetaFeedbackScreenshotSubmission deletes a piece of screenshot feedback.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete-v1-betafeedbackscreenshotsubmissions-_id_
+func (s *TestflightService) DeleteBetaFeedbackScreenshotSubmission(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/betaFeedbackScreenshotSubmissions/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// ListBetaFeedbackCrashSubmissionsForApp lists the crash feedback sent by testers of an app.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-apps-_id_-betafeedbackcrashsubmissions
+func (s *TestflightService) ListBetaFeedbackCrashSubmissionsForApp(ctx context.Context, id string, params *ListBetaFeedbackSubmissionsForAppQuery) (*BetaFeedbackCrashSubmissionsResponse, *Response, error) {
+	url := fmt.Sprintf("v1/apps/%s/betaFeedbackCrashSubmissions", id)
+	res := new(BetaFeedbackCrashSubmissionsResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetBetaFeedbackCrashSubmission gets a single piece of crash feedback.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackcrashsubmissions-_id_
+func (s *TestflightService) GetBetaFeedbackCrashSubmission(ctx context.Context, id string, params *GetBetaFeedbackSubmissionQuery) (*BetaFeedbackCrashSubmissionResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaFeedbackCrashSubmissions/%s", id)
+	res := new(BetaFeedbackCrashSubmissionResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DeleteBetaFeedbackCrashSubmission deletes a piece of crash feedback.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/delete-v1-betafeedbackcrashsubmissions-_id_
+func (s *TestflightService) DeleteBetaFeedbackCrashSubmission(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("v1/betaFeedbackCrashSubmissions/%s", id)
+
+	return s.client.delete(ctx, url, nil)
+}
+
+// GetCrashLogForBetaFeedbackCrashSubmission gets the crash log attached to a piece of crash feedback.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get-v1-betafeedbackcrashsubmissions-_id_-crashlog
+func (s *TestflightService) GetCrashLogForBetaFeedbackCrashSubmission(ctx context.Context, id string, params *GetCrashLogForBetaFeedbackCrashSubmissionQuery) (*BetaCrashLogResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaFeedbackCrashSubmissions/%s/crashLog", id)
+	res := new(BetaCrashLogResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// DownloadBetaFeedbackScreenshot writes the image of a feedback screenshot to w. Screenshot URLs
+// expire, so the submission should be fetched shortly before downloading. The image is requested
+// without the API token.
+func (s *TestflightService) DownloadBetaFeedbackScreenshot(ctx context.Context, screenshot BetaFeedbackScreenshotImage, w io.Writer) (*Response, error) {
+	if screenshot.URL == nil {
+		return nil, ErrMissingScreenshotURL
+	}
+
+	return s.client.download(ctx, *screenshot.URL, w)
+}
+
+// UnmarshalJSON is a custom unmarshaller for the heterogenous data stored in BetaFeedbackResponseIncluded.
+func (i *BetaFeedbackResponseIncluded) UnmarshalJSON(b []byte) error {
+	typeName, inner, err := unmarshalInclude(b)
+	i.Type = typeName
+	i.inner = inner
+
+	return err
+}
+
+// BetaTester returns the BetaTester stored within, if one is present.
+func (i *BetaFeedbackResponseIncluded) BetaTester() *BetaTester {
+	return extractIncludedBetaTester(i.inner)
+}
+
+// Build returns the Build stored within, if one is present.
+func (i *BetaFeedbackResponseIncluded) Build() *Build {
+	return extractIncludedBuild(i.inner)
+}
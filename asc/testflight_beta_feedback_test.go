@@ -0,0 +1,169 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBetaFeedbackScreenshotSubmissionsForApp(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaFeedbackScreenshotSubmissionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.ListBetaFeedbackScreenshotSubmissionsForApp(ctx, "10", &ListBetaFeedbackSubmissionsForAppQuery{
+			FilterBuild:       []string{"20"},
+			FilterDeviceModel: []string{"iPhone15,2"},
+		})
+	})
+}
+
+func TestGetBetaFeedbackScreenshotSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaFeedbackScreenshotSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaFeedbackScreenshotSubmission(ctx, "10", &GetBetaFeedbackSubmissionQuery{})
+	})
+}
+
+func TestGetBetaFeedbackScreenshotSubmissionDecodesAttributes(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"data":{"id":"10","attributes":{"comment":"Button overlaps","deviceModel":"iPhone15,2","devicePlatform":"IOS","screenshots":[{"url":"https://example.com/1.png","width":1170,"height":2532}]}}}`
+
+	testEndpointCustomBehavior(raw, func(ctx context.Context, client *Client) {
+		submission, _, err := client.TestFlight.GetBetaFeedbackScreenshotSubmission(ctx, "10", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Button overlaps", *submission.Data.Attributes.Comment)
+		assert.Equal(t, PlatformIOS, *submission.Data.Attributes.DevicePlatform)
+		assert.Equal(t, 1170, *submission.Data.Attributes.Screenshots[0].Width)
+	})
+}
+
+func TestDeleteBetaFeedbackScreenshotSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.TestFlight.DeleteBetaFeedbackScreenshotSubmission(ctx, "10")
+	})
+}
+
+func TestListBetaFeedbackCrashSubmissionsForApp(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaFeedbackCrashSubmissionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.ListBetaFeedbackCrashSubmissionsForApp(ctx, "10", &ListBetaFeedbackSubmissionsForAppQuery{
+			FilterTester:         []string{"20"},
+			FilterDevicePlatform: []string{string(PlatformIOS)},
+		})
+	})
+}
+
+func TestGetBetaFeedbackCrashSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaFeedbackCrashSubmissionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaFeedbackCrashSubmission(ctx, "10", &GetBetaFeedbackSubmissionQuery{})
+	})
+}
+
+func TestGetBetaFeedbackCrashSubmissionIncludeds(t *testing.T) {
+	t.Parallel()
+
+	testEndpointCustomBehavior(`{"included":[{"type":"builds"},{"type":"betaTesters"}]}`, func(ctx context.Context, client *Client) {
+		submission, _, err := client.TestFlight.GetBetaFeedbackCrashSubmission(ctx, "10", &GetBetaFeedbackSubmissionQuery{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, submission.Included)
+
+		assert.NotNil(t, submission.Included[0].Build())
+		assert.NotNil(t, submission.Included[1].BetaTester())
+
+		assert.Nil(t, submission.Included[0].BetaTester())
+		assert.Nil(t, submission.Included[1].Build())
+	})
+}
+
+func TestDeleteBetaFeedbackCrashSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.TestFlight.DeleteBetaFeedbackCrashSubmission(ctx, "10")
+	})
+}
+
+func TestGetCrashLogForBetaFeedbackCrashSubmission(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaCrashLogResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetCrashLogForBetaFeedbackCrashSubmission(ctx, "10", &GetCrashLogForBetaFeedbackCrashSubmissionQuery{})
+	})
+}
+
+func TestDownloadBetaFeedbackScreenshot(t *testing.T) {
+	t.Parallel()
+
+	client, server := newServer("image-bytes", 200, false)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	_, err := client.TestFlight.DownloadBetaFeedbackScreenshot(context.Background(), BetaFeedbackScreenshotImage{URL: String(server.URL + "/1.png")}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "image-bytes\n", buf.String())
+
+	_, err = client.TestFlight.DownloadBetaFeedbackScreenshot(context.Background(), BetaFeedbackScreenshotImage{}, &buf)
+	assert.Equal(t, ErrMissingScreenshotURL, err)
+}
+
+func TestDownloadBetaFeedbackScreenshotWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	var authorization []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+
+		if r.URL.Path == "/expired.png" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("image-bytes"))
+	}))
+	defer server.Close()
+
+	transport := &AuthTransport{jwtGenerator: &mockJWTGenerator{token: "TEST.TEST.TEST"}}
+	client := NewClient(transport.Client())
+
+	var buf bytes.Buffer
+	_, err := client.TestFlight.DownloadBetaFeedbackScreenshot(context.Background(), BetaFeedbackScreenshotImage{URL: String(server.URL + "/1.png")}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "image-bytes", buf.String())
+
+	_, err = client.TestFlight.DownloadBetaFeedbackScreenshot(context.Background(), BetaFeedbackScreenshotImage{URL: String(server.URL + "/expired.png")}, &buf)
+	assert.IsType(t, &ErrorResponse{}, err)
+	assert.Equal(t, []string{"", ""}, authorization)
+}
@@ -0,0 +1,178 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxPublicLinkLimit is the largest number of testers App Store Connect allows to join through a public link.
+const maxPublicLinkLimit = 10000
+
+// ErrInvalidPublicLinkLimit happens when a public link limit is outside the range App Store Connect accepts.
+type ErrInvalidPublicLinkLimit struct {
+	Limit int
+}
+
+func (e ErrInvalidPublicLinkLimit) Error() string {
+	return fmt.Sprintf("public link limit %d must be between 1 and %d", e.Limit, maxPublicLinkLimit)
+}
+
+// ErrPublicLinkNotRotated happens when a beta group still has the same public link after it was
+// disabled and re-enabled.
+type ErrPublicLinkNotRotated struct {
+	PublicLink string
+}
+
+func (e ErrPublicLinkNotRotated) Error() string {
+	return fmt.Sprintf("public link %s was not replaced", e.PublicLink)
+}
+
+// BetaTesterInviteCounts tallies the testers of a beta group by how they were invited.
+type BetaTesterInviteCounts struct {
+	Email      int
+	PublicLink int
+	// Unknown counts testers whose invite type was not reported.
+	Unknown int
+}
+
+// Total returns the number of testers counted.
+func (c BetaTesterInviteCounts) Total() int {
+	return c.Email + c.PublicLink + c.Unknown
+}
+
+// EnableBetaGroupPublicLink turns on the public link of a beta group. If limit is non-nil, at most that
+// many testers can join through the link; otherwise the limit is turned off.
+func (s *TestflightService) EnableBetaGroupPublicLink(ctx context.Context, id string, limit *int) (*BetaGroupResponse, *Response, error) {
+	attributes := &BetaGroupUpdateRequestAttributes{
+		PublicLinkEnabled:      Bool(true),
+		PublicLinkLimitEnabled: Bool(limit != nil),
+	}
+
+	if limit != nil {
+		if *limit < 1 || *limit > maxPublicLinkLimit {
+			return nil, nil, ErrInvalidPublicLinkLimit{Limit: *limit}
+		}
+
+		attributes.PublicLinkLimit = limit
+	}
+
+	return s.UpdateBetaGroup(ctx, id, attributes)
+}
+
+// DisableBetaGroupPublicLink turns off the public link of a beta group. Testers who already joined keep their access.
+func (s *TestflightService) DisableBetaGroupPublicLink(ctx context.Context, id string) (*BetaGroupResponse, *Response, error) {
+	return s.UpdateBetaGroup(ctx, id, &BetaGroupUpdateRequestAttributes{
+		PublicLinkEnabled: Bool(false),
+	})
+}
+
+// RotateBetaGroupPublicLink replaces the public link of a beta group so that a leaked link stops working.
+// App Store Connect issues a new link when the public link is turned off and on again, so that is what
+// this does, keeping the group's current limit. A link that was turned off is turned off again once
+// replaced, so rotating never reopens a closed group. ErrPublicLinkNotRotated is returned if the link
+// is unchanged.
+func (s *TestflightService) RotateBetaGroupPublicLink(ctx context.Context, id string) (*BetaGroupResponse, *Response, error) {
+	current, resp, err := s.GetBetaGroup(ctx, id, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var previous string
+
+	var limit *int
+
+	enabled := false
+
+	if attributes := current.Data.Attributes; attributes != nil {
+		if attributes.PublicLink != nil {
+			previous = *attributes.PublicLink
+		}
+
+		if attributes.PublicLinkLimitEnabled != nil && *attributes.PublicLinkLimitEnabled {
+			limit = attributes.PublicLinkLimit
+		}
+
+		enabled = attributes.PublicLinkEnabled != nil && *attributes.PublicLinkEnabled
+	}
+
+	if enabled {
+		if _, resp, err = s.DisableBetaGroupPublicLink(ctx, id); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	res, resp, err := s.EnableBetaGroupPublicLink(ctx, id, limit)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	rotated := res.Data.Attributes == nil || res.Data.Attributes.PublicLink == nil || previous == "" || *res.Data.Attributes.PublicLink != previous
+
+	if !enabled {
+		if res, resp, err = s.DisableBetaGroupPublicLink(ctx, id); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if !rotated {
+		return res, resp, ErrPublicLinkNotRotated{PublicLink: previous}
+	}
+
+	return res, resp, nil
+}
+
+// CountBetaTestersByInviteType pages through the testers of a beta group and counts how many joined through
+// the group's public link and how many were invited by email.
+func (s *TestflightService) CountBetaTestersByInviteType(ctx context.Context, id string) (*BetaTesterInviteCounts, *Response, error) {
+	counts := new(BetaTesterInviteCounts)
+	query := &ListBetaTestersForBetaGroupQuery{
+		FieldsBetaTesters: []string{"inviteType"},
+		Limit:             200,
+	}
+
+	for {
+		res, resp, err := s.ListBetaTestersForBetaGroup(ctx, id, query)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		for _, tester := range res.Data {
+			switch {
+			case tester.Attributes == nil || tester.Attributes.InviteType == nil:
+				counts.Unknown++
+			case *tester.Attributes.InviteType == BetaInviteTypePublicLink:
+				counts.PublicLink++
+			case *tester.Attributes.InviteType == BetaInviteTypeEmail:
+				counts.Email++
+			default:
+				counts.Unknown++
+			}
+		}
+
+		if res.Links.Next == nil {
+			return counts, resp, nil
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+}
@@ -0,0 +1,118 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableBetaGroupPublicLink(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaGroupResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.EnableBetaGroupPublicLink(ctx, "10", Int(500))
+	})
+}
+
+func TestEnableBetaGroupPublicLinkInvalidLimit(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(nil)
+	_, _, err := client.TestFlight.EnableBetaGroupPublicLink(context.Background(), "10", Int(0))
+	assert.Equal(t, ErrInvalidPublicLinkLimit{Limit: 0}, err)
+
+	_, _, err = client.TestFlight.EnableBetaGroupPublicLink(context.Background(), "10", Int(maxPublicLinkLimit+1))
+	assert.Error(t, err)
+}
+
+func TestDisableBetaGroupPublicLink(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaGroupResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.DisableBetaGroupPublicLink(ctx, "10")
+	})
+}
+
+func TestRotateBetaGroupPublicLink(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/betaGroups/10": {`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/old","publicLinkEnabled":true,"publicLinkLimit":250,"publicLinkLimitEnabled":true}}}`},
+		"PATCH /v1/betaGroups/10": {
+			`{"data":{"id":"10","attributes":{"publicLinkEnabled":false}}}`,
+			`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/new","publicLinkEnabled":true,"publicLinkLimit":250}}}`,
+		},
+	})
+
+	res, _, err := client.TestFlight.RotateBetaGroupPublicLink(context.Background(), "10")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://testflight.apple.com/join/new", *res.Data.Attributes.PublicLink)
+	assert.Equal(t, 2, server.requests["PATCH /v1/betaGroups/10"])
+}
+
+func TestRotateBetaGroupPublicLinkKeepsItDisabled(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/betaGroups/10": {`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/old","publicLinkEnabled":false}}}`},
+		"PATCH /v1/betaGroups/10": {
+			`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/new","publicLinkEnabled":true}}}`,
+			`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/new","publicLinkEnabled":false}}}`,
+		},
+	})
+
+	res, _, err := client.TestFlight.RotateBetaGroupPublicLink(context.Background(), "10")
+	assert.NoError(t, err)
+	assert.False(t, *res.Data.Attributes.PublicLinkEnabled)
+	assert.Equal(t, 2, server.requests["PATCH /v1/betaGroups/10"])
+}
+
+func TestRotateBetaGroupPublicLinkUnchanged(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/betaGroups/10":   {`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/old","publicLinkEnabled":true}}}`},
+		"PATCH /v1/betaGroups/10": {`{"data":{"id":"10","attributes":{"publicLink":"https://testflight.apple.com/join/old","publicLinkEnabled":true}}}`},
+	})
+
+	_, _, err := client.TestFlight.RotateBetaGroupPublicLink(context.Background(), "10")
+	assert.Equal(t, ErrPublicLinkNotRotated{PublicLink: "https://testflight.apple.com/join/old"}, err)
+}
+
+func TestCountBetaTestersByInviteType(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/betaGroups/10/betaTesters": {
+			`{"data":[{"id":"1","attributes":{"inviteType":"EMAIL"}},{"id":"2","attributes":{"inviteType":"PUBLIC_LINK"}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups/10/betaTesters?cursor=abc"}}`,
+			`{"data":[{"id":"3","attributes":{"inviteType":"PUBLIC_LINK"}},{"id":"4"}],"links":{"self":""}}`,
+		},
+	})
+
+	counts, _, err := client.TestFlight.CountBetaTestersByInviteType(context.Background(), "10")
+	assert.NoError(t, err)
+	assert.Equal(t, &BetaTesterInviteCounts{Email: 1, PublicLink: 2, Unknown: 1}, counts)
+	assert.Equal(t, 4, counts.Total())
+	assert.Equal(t, 2, server.requests["GET /v1/betaGroups/10/betaTesters"])
+}
@@ -55,9 +55,10 @@ type BetaGroupAttributes struct {
 //
 // https://developer.apple.com/documentation/appstoreconnectapi/betagroup/relationships
 type BetaGroupRelationships struct {
-	App         *Relationship      `json:"app,omitempty"`
-	BetaTesters *PagedRelationship `json:"betaTesters,omitempty"`
-	Builds      *PagedRelationship `json:"builds,omitempty"`
+	App                     *Relationship      `json:"app,omitempty"`
+	BetaRecruitmentCriteria *Relationship      `json:"betaRecruitmentCriteria,omitempty"`
+	BetaTesters             *PagedRelationship `json:"betaTesters,omitempty"`
+	Builds                  *PagedRelationship `json:"builds,omitempty"`
 }
 
 // BetaGroupResponse defines model for BetaGroupResponse.
@@ -295,7 +296,7 @@ func (s *TestflightService) DeleteBetaGroup(ctx context.Context, id string) (*Re
 // https://developer.apple.com/documentation/appstoreconnectapi/list_beta_groups
 func (s *TestflightService) ListBetaGroups(ctx context.Context, params *ListBetaGroupsQuery) (*BetaGroupsResponse, *Response, error) {
 	res := new(BetaGroupsResponse)
-	resp, err := s.client.get(ctx, "betaGroups", params, res)
+	resp, err := s.client.get(ctx, "v1/betaGroups", params, res)
 
 	return res, resp, err
 }
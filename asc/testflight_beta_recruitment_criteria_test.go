@@ -0,0 +1,74 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreateBetaRecruitmentCriterion(t *testing.T) {
+	t.Parallel()
+
+	filters := []DeviceFamilyOsVersionFilter{
+		{DeviceFamily: deviceFamilyPtr(DeviceFamilyIPhone), MinimumOsInclusive: String("16.0")},
+	}
+
+	testEndpointWithResponse(t, "{}", &BetaRecruitmentCriterionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.CreateBetaRecruitmentCriterion(ctx, filters, "10")
+	})
+}
+
+func TestUpdateBetaRecruitmentCriterion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaRecruitmentCriterionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.UpdateBetaRecruitmentCriterion(ctx, "10", []DeviceFamilyOsVersionFilter{})
+	})
+}
+
+func TestDeleteBetaRecruitmentCriterion(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithNoContent(t, func(ctx context.Context, client *Client) (*Response, error) {
+		return client.TestFlight.DeleteBetaRecruitmentCriterion(ctx, "10")
+	})
+}
+
+func TestGetBetaRecruitmentCriterionForBetaGroup(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaRecruitmentCriterionResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaRecruitmentCriterionForBetaGroup(ctx, "10", &GetBetaRecruitmentCriterionForBetaGroupQuery{})
+	})
+}
+
+func TestListBetaRecruitmentCriterionOptions(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaRecruitmentCriterionOptionsResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.ListBetaRecruitmentCriterionOptions(ctx, &ListBetaRecruitmentCriterionOptionsQuery{})
+	})
+}
+
+func deviceFamilyPtr(family DeviceFamily) *DeviceFamily {
+	return &family
+}
@@ -0,0 +1,374 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const defaultBetaReviewLocale = "en-US"
+
+// BetaReviewProblem is something that stops a build from being submitted for beta app review, or that
+// is likely to slow the review down.
+type BetaReviewProblem struct {
+	// Field names the missing attribute, such as "betaAppReviewDetail.contactEmail" or
+	// "betaAppLocalizations[en-US].feedbackEmail".
+	Field   string
+	Message string
+	// Blocking is true when App Store Connect rejects the submission until the problem is fixed.
+	// Other problems are advisory.
+	Blocking bool
+	// Fixed is true when the problem was corrected from BetaReviewDefaults.
+	Fixed bool
+}
+
+// BetaReviewReadiness is the outcome of CheckBetaAppReviewReadiness.
+type BetaReviewReadiness struct {
+	BetaAppReviewDetail  *BetaAppReviewDetail
+	BetaAppLocalizations []BetaAppLocalization
+	Build                *Build
+	Problems             []BetaReviewProblem
+}
+
+// Ready reports whether every blocking problem is fixed.
+func (r *BetaReviewReadiness) Ready() bool {
+	return len(r.Blocking()) == 0
+}
+
+// Blocking returns the blocking problems that are not fixed.
+func (r *BetaReviewReadiness) Blocking() []BetaReviewProblem {
+	return r.unfixed(true)
+}
+
+// Advisory returns the advisory problems that are not fixed.
+func (r *BetaReviewReadiness) Advisory() []BetaReviewProblem {
+	return r.unfixed(false)
+}
+
+func (r *BetaReviewReadiness) unfixed(blocking bool) []BetaReviewProblem {
+	var problems []BetaReviewProblem
+
+	for _, problem := range r.Problems {
+		if !problem.Fixed && problem.Blocking == blocking {
+			problems = append(problems, problem)
+		}
+	}
+
+	return problems
+}
+
+// report records a problem and returns its index in Problems.
+func (r *BetaReviewReadiness) report(field string, blocking bool, format string, args ...interface{}) int {
+	r.Problems = append(r.Problems, BetaReviewProblem{Field: field, Message: fmt.Sprintf(format, args...), Blocking: blocking})
+
+	return len(r.Problems) - 1
+}
+
+func (r *BetaReviewReadiness) markFixed(problems ...int) {
+	for _, i := range problems {
+		r.Problems[i].Fixed = true
+	}
+}
+
+// BetaReviewDefaults are values CheckBetaAppReviewReadiness uses to fill in missing fields. Empty
+// values are never written.
+type BetaReviewDefaults struct {
+	ContactFirstName    string
+	ContactLastName     string
+	ContactEmail        string
+	ContactPhone        string
+	DemoAccountName     string
+	DemoAccountPassword string
+	Notes               string
+
+	// Locale is the locale of the beta app localization that is created when the app has none.
+	// Defaults to "en-US".
+	Locale string
+	// FeedbackEmail fills in the feedback email of every beta app localization that lacks one.
+	FeedbackEmail string
+	// Descriptions maps locales, such as "en-US", to the beta app description of that locale.
+	Descriptions     map[string]string
+	PrivacyPolicyURL string
+
+	// UsesNonExemptEncryption answers export compliance for the build when it has not been answered yet.
+	UsesNonExemptEncryption *bool
+}
+
+// CheckBetaAppReviewReadiness checks that an app and one of its builds have everything beta app review
+// requires before the build can be tested externally: review contact information, a demo account when
+// one is required, a feedback email and description in every beta app localization, and an export
+// compliance answer. When defaults is not nil, missing fields that it provides are written to App Store
+// Connect and the corresponding problems are marked as fixed. The checks are read-only otherwise.
+func (s *TestflightService) CheckBetaAppReviewReadiness(ctx context.Context, appID string, buildID string, defaults *BetaReviewDefaults) (*BetaReviewReadiness, error) {
+	if defaults == nil {
+		defaults = &BetaReviewDefaults{}
+	}
+
+	readiness := new(BetaReviewReadiness)
+
+	if err := s.checkBetaAppReviewDetail(ctx, appID, defaults, readiness); err != nil {
+		return readiness, err
+	}
+
+	if err := s.checkBetaAppLocalizations(ctx, appID, defaults, readiness); err != nil {
+		return readiness, err
+	}
+
+	if err := s.checkBuildForBetaReview(ctx, buildID, defaults, readiness); err != nil {
+		return readiness, err
+	}
+
+	return readiness, nil
+}
+
+// betaReviewField is an attribute that is checked by CheckBetaAppReviewReadiness.
+type betaReviewField struct {
+	name     string
+	value    *string
+	fallback string
+	blocking bool
+	message  string
+	set      func(value *string)
+}
+
+// checkBetaReviewFields reports every missing field, and sets the fallback of the ones that have
+// one. It returns the indices of the problems that are fixed once the caller writes the new values.
+func checkBetaReviewFields(readiness *BetaReviewReadiness, fields []betaReviewField) []int {
+	var fixes []int
+
+	for _, field := range fields {
+		if !isBlank(field.value) {
+			continue
+		}
+
+		problem := readiness.report(field.name, field.blocking, field.message)
+
+		if field.fallback != "" {
+			field.set(String(field.fallback))
+
+			fixes = append(fixes, problem)
+		}
+	}
+
+	return fixes
+}
+
+func (s *TestflightService) checkBetaAppReviewDetail(ctx context.Context, appID string, defaults *BetaReviewDefaults, readiness *BetaReviewReadiness) error {
+	res, _, err := s.GetBetaAppReviewDetailsForApp(ctx, appID, nil)
+	if err != nil {
+		return err
+	}
+
+	detail := res.Data
+	readiness.BetaAppReviewDetail = &detail
+
+	attributes := detail.Attributes
+	if attributes == nil {
+		attributes = &BetaAppReviewDetailAttributes{}
+	}
+
+	update := &BetaAppReviewDetailUpdateRequestAttributes{}
+	fields := []betaReviewField{
+		{"betaAppReviewDetail.contactFirstName", attributes.ContactFirstName, defaults.ContactFirstName, true, "the review contact has no first name", func(v *string) { update.ContactFirstName = v }},
+		{"betaAppReviewDetail.contactLastName", attributes.ContactLastName, defaults.ContactLastName, true, "the review contact has no last name", func(v *string) { update.ContactLastName = v }},
+		{"betaAppReviewDetail.contactEmail", attributes.ContactEmail, defaults.ContactEmail, true, "the review contact has no email", func(v *string) { update.ContactEmail = v }},
+		{"betaAppReviewDetail.contactPhone", attributes.ContactPhone, defaults.ContactPhone, true, "the review contact has no phone number", func(v *string) { update.ContactPhone = v }},
+	}
+
+	if attributes.DemoAccountRequired != nil && *attributes.DemoAccountRequired {
+		fields = append(fields,
+			betaReviewField{"betaAppReviewDetail.demoAccountName", attributes.DemoAccountName, defaults.DemoAccountName, true, "a demo account is required but has no user name", func(v *string) { update.DemoAccountName = v }},
+			betaReviewField{"betaAppReviewDetail.demoAccountPassword", attributes.DemoAccountPassword, defaults.DemoAccountPassword, true, "a demo account is required but has no password", func(v *string) { update.DemoAccountPassword = v }},
+		)
+	}
+
+	fields = append(fields, betaReviewField{"betaAppReviewDetail.notes", attributes.Notes, defaults.Notes, false, "there are no notes for the reviewer", func(v *string) { update.Notes = v }})
+
+	fixes := checkBetaReviewFields(readiness, fields)
+	if len(fixes) == 0 {
+		return nil
+	}
+
+	updated, _, err := s.UpdateBetaAppReviewDetail(ctx, detail.ID, update)
+	if err != nil {
+		return err
+	}
+
+	readiness.BetaAppReviewDetail = &updated.Data
+
+	readiness.markFixed(fixes...)
+
+	return nil
+}
+
+func (s *TestflightService) checkBetaAppLocalizations(ctx context.Context, appID string, defaults *BetaReviewDefaults, readiness *BetaReviewReadiness) error {
+	res, _, err := s.ListBetaAppLocalizationsForApp(ctx, appID, &ListBetaAppLocalizationsForAppQuery{Limit: 200})
+	if err != nil {
+		return err
+	}
+
+	readiness.BetaAppLocalizations = res.Data
+
+	if len(res.Data) == 0 {
+		problem := readiness.report("betaAppLocalizations", true, "the app has no beta app localizations")
+
+		locale := defaults.Locale
+		if locale == "" {
+			locale = defaultBetaReviewLocale
+		}
+
+		if defaults.FeedbackEmail == "" && defaults.Descriptions[locale] == "" {
+			return nil
+		}
+
+		created, _, err := s.CreateBetaAppLocalization(ctx, BetaAppLocalizationCreateRequestAttributes{
+			Locale:           locale,
+			Description:      optionalString(defaults.Descriptions[locale]),
+			FeedbackEmail:    optionalString(defaults.FeedbackEmail),
+			PrivacyPolicyURL: optionalString(defaults.PrivacyPolicyURL),
+		}, appID)
+		if err != nil {
+			return err
+		}
+
+		readiness.markFixed(problem)
+
+		// The created localization is checked like any other, so that a field the defaults did not
+		// supply is still reported.
+		localization := created.Data
+		if localization.Attributes == nil {
+			localization.Attributes = &BetaAppLocalizationAttributes{
+				Locale:           &locale,
+				Description:      optionalString(defaults.Descriptions[locale]),
+				FeedbackEmail:    optionalString(defaults.FeedbackEmail),
+				PrivacyPolicyURL: optionalString(defaults.PrivacyPolicyURL),
+			}
+		}
+
+		readiness.BetaAppLocalizations = []BetaAppLocalization{localization}
+	}
+
+	for i, localization := range readiness.BetaAppLocalizations {
+		attributes := localization.Attributes
+		if attributes == nil {
+			attributes = &BetaAppLocalizationAttributes{}
+		}
+
+		locale := ""
+		if attributes.Locale != nil {
+			locale = *attributes.Locale
+		}
+
+		prefix := fmt.Sprintf("betaAppLocalizations[%s].", locale)
+		update := &BetaAppLocalizationUpdateRequestAttributes{}
+
+		fixes := checkBetaReviewFields(readiness, []betaReviewField{
+			{prefix + "feedbackEmail", attributes.FeedbackEmail, defaults.FeedbackEmail, true, fmt.Sprintf("the %s localization has no feedback email", locale), func(v *string) { update.FeedbackEmail = v }},
+			{prefix + "description", attributes.Description, defaults.Descriptions[locale], true, fmt.Sprintf("the %s localization has no beta app description", locale), func(v *string) { update.Description = v }},
+			{prefix + "privacyPolicyUrl", attributes.PrivacyPolicyURL, defaults.PrivacyPolicyURL, false, fmt.Sprintf("the %s localization has no privacy policy URL", locale), func(v *string) { update.PrivacyPolicyURL = v }},
+		})
+		if len(fixes) == 0 {
+			continue
+		}
+
+		updated, _, err := s.UpdateBetaAppLocalization(ctx, localization.ID, update)
+		if err != nil {
+			return err
+		}
+
+		readiness.BetaAppLocalizations[i] = updated.Data
+
+		readiness.markFixed(fixes...)
+	}
+
+	return nil
+}
+
+func (s *TestflightService) checkBuildForBetaReview(ctx context.Context, buildID string, defaults *BetaReviewDefaults, readiness *BetaReviewReadiness) error {
+	res, _, err := s.client.Builds.GetBuild(ctx, buildID, nil)
+	if err != nil {
+		return err
+	}
+
+	build := res.Data
+	readiness.Build = &build
+
+	attributes := build.Attributes
+	if attributes == nil {
+		attributes = &BuildAttributes{}
+	}
+
+	if attributes.ProcessingState != nil && *attributes.ProcessingState != BuildProcessingStateValid {
+		readiness.report("build.processingState", true, "the build is in processing state %s", *attributes.ProcessingState)
+	}
+
+	if attributes.Expired != nil && *attributes.Expired {
+		readiness.report("build.expired", true, "the build has expired")
+	}
+
+	usesNonExemptEncryption := attributes.UsesNonExemptEncryption
+
+	if usesNonExemptEncryption == nil {
+		problem := readiness.report("build.usesNonExemptEncryption", true, "export compliance has not been answered for the build")
+
+		if defaults.UsesNonExemptEncryption == nil {
+			return nil
+		}
+
+		updated, _, err := s.client.Builds.UpdateBuild(ctx, build.ID, nil, defaults.UsesNonExemptEncryption, nil)
+		if err != nil {
+			return err
+		}
+
+		readiness.Build = &updated.Data
+		readiness.markFixed(problem)
+		usesNonExemptEncryption = defaults.UsesNonExemptEncryption
+	}
+
+	if !*usesNonExemptEncryption {
+		return nil
+	}
+
+	declaration, _, err := s.client.Builds.GetAppEncryptionDeclarationForBuild(ctx, build.ID, nil)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+
+	if err != nil || declaration.Data.ID == "" {
+		readiness.report("build.appEncryptionDeclaration", true, "the build uses non-exempt encryption but has no app encryption declaration")
+	}
+
+	return nil
+}
+
+func isBlank(value *string) bool {
+	return value == nil || strings.TrimSpace(*value) == ""
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
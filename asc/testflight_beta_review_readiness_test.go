@@ -0,0 +1,139 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReadyReviewDetail = `{"data":{"id":"detail","attributes":{"contactFirstName":"Ada","contactLastName":"Lovelace","contactEmail":"ada@example.com","contactPhone":"+1 555 0100","notes":"Sign in with Apple"}}}`
+
+func TestCheckBetaAppReviewReadiness(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/apps/app/betaAppReviewDetail":          {`{"data":{"id":"detail","attributes":{"contactFirstName":"Ada","demoAccountRequired":true,"demoAccountName":"demo"}}}`},
+		"GET /v1/apps/app/betaAppLocalizations":         {`{"data":[{"id":"loc-en","attributes":{"locale":"en-US","feedbackEmail":"beta@example.com"}}]}`},
+		"GET /v1/builds/build":                          {`{"data":{"id":"build","attributes":{"processingState":"VALID","usesNonExemptEncryption":true}}}`},
+		"GET /v1/builds/build/appEncryptionDeclaration": {`{"data":{"id":"declaration"}}`},
+	})
+
+	readiness, err := client.TestFlight.CheckBetaAppReviewReadiness(context.Background(), "app", "build", nil)
+	assert.NoError(t, err)
+	assert.False(t, readiness.Ready())
+
+	var blocking []string
+	for _, problem := range readiness.Blocking() {
+		blocking = append(blocking, problem.Field)
+	}
+
+	assert.Equal(t, []string{
+		"betaAppReviewDetail.contactLastName",
+		"betaAppReviewDetail.contactEmail",
+		"betaAppReviewDetail.contactPhone",
+		"betaAppReviewDetail.demoAccountPassword",
+		"betaAppLocalizations[en-US].description",
+	}, blocking)
+	assert.Len(t, readiness.Advisory(), 2)
+
+	for key := range server.requests {
+		assert.Equal(t, "GET", key[:3], "unexpected write %s", key)
+	}
+}
+
+func TestCheckBetaAppReviewReadinessAutoFills(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/apps/app/betaAppReviewDetail":  {`{"data":{"id":"detail","attributes":{"contactFirstName":"Ada","contactLastName":"Lovelace"}}}`},
+		"PATCH /v1/betaAppReviewDetails/detail": {testReadyReviewDetail},
+		"GET /v1/apps/app/betaAppLocalizations": {`{"data":[]}`},
+		"POST /v1/betaAppLocalizations":         {`{"data":{"id":"loc-en","attributes":{"locale":"en-US","feedbackEmail":"beta@example.com","description":"A beta","privacyPolicyUrl":"https://example.com/privacy"}}}`},
+		"GET /v1/builds/build":                  {`{"data":{"id":"build","attributes":{"processingState":"VALID"}}}`},
+		"PATCH /v1/builds/build":                {`{"data":{"id":"build","attributes":{"processingState":"VALID","usesNonExemptEncryption":false}}}`},
+	})
+
+	readiness, err := client.TestFlight.CheckBetaAppReviewReadiness(context.Background(), "app", "build", &BetaReviewDefaults{
+		ContactEmail:            "ada@example.com",
+		ContactPhone:            "+1 555 0100",
+		Notes:                   "Sign in with Apple",
+		FeedbackEmail:           "beta@example.com",
+		Descriptions:            map[string]string{"en-US": "A beta"},
+		PrivacyPolicyURL:        "https://example.com/privacy",
+		UsesNonExemptEncryption: Bool(false),
+	})
+	assert.NoError(t, err)
+	assert.True(t, readiness.Ready())
+	assert.Empty(t, readiness.Advisory())
+	assert.Len(t, readiness.Problems, 5)
+	assert.Equal(t, "loc-en", readiness.BetaAppLocalizations[0].ID)
+	assert.False(t, *readiness.Build.Attributes.UsesNonExemptEncryption)
+	assert.Equal(t, 1, server.requests["PATCH /v1/betaAppReviewDetails/detail"])
+	assert.Equal(t, 1, server.requests["POST /v1/betaAppLocalizations"])
+	assert.Equal(t, 1, server.requests["PATCH /v1/builds/build"])
+}
+
+func TestCheckBetaAppReviewReadinessReportsFieldsMissingFromCreatedLocalization(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/apps/app/betaAppReviewDetail":  {testReadyReviewDetail},
+		"GET /v1/apps/app/betaAppLocalizations": {`{"data":[]}`},
+		"POST /v1/betaAppLocalizations":         {`{"data":{"id":"loc-en","attributes":{"locale":"en-US","feedbackEmail":"beta@example.com"}}}`},
+		"GET /v1/builds/build":                  {`{"data":{"id":"build","attributes":{"processingState":"VALID","usesNonExemptEncryption":false}}}`},
+	})
+
+	readiness, err := client.TestFlight.CheckBetaAppReviewReadiness(context.Background(), "app", "build", &BetaReviewDefaults{
+		FeedbackEmail: "beta@example.com",
+	})
+	assert.NoError(t, err)
+	assert.False(t, readiness.Ready())
+
+	var blocking []string
+	for _, problem := range readiness.Blocking() {
+		blocking = append(blocking, problem.Field)
+	}
+
+	assert.Equal(t, []string{"betaAppLocalizations[en-US].description"}, blocking)
+	assert.Equal(t, 1, server.requests["POST /v1/betaAppLocalizations"])
+	assert.Empty(t, server.requests["PATCH /v1/betaAppLocalizations/loc-en"])
+}
+
+func TestCheckBetaAppReviewReadinessBuildProblems(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/apps/app/betaAppReviewDetail":  {testReadyReviewDetail},
+		"GET /v1/apps/app/betaAppLocalizations": {`{"data":[{"id":"loc-en","attributes":{"locale":"en-US","feedbackEmail":"beta@example.com","description":"A beta","privacyPolicyUrl":"https://example.com/privacy"}}]}`},
+		"GET /v1/builds/build":                  {`{"data":{"id":"build","attributes":{"processingState":"PROCESSING","expired":true,"usesNonExemptEncryption":true}}}`},
+	})
+
+	readiness, err := client.TestFlight.CheckBetaAppReviewReadiness(context.Background(), "app", "build", &BetaReviewDefaults{})
+	assert.NoError(t, err)
+	assert.Equal(t, []BetaReviewProblem{
+		{Field: "build.processingState", Message: "the build is in processing state PROCESSING", Blocking: true},
+		{Field: "build.expired", Message: "the build has expired", Blocking: true},
+		{Field: "build.appEncryptionDeclaration", Message: "the build uses non-exempt encryption but has no app encryption declaration", Blocking: true},
+	}, readiness.Problems)
+}
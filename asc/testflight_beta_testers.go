@@ -287,7 +287,7 @@ func (s *TestflightService) DeleteBetaTester(ctx context.Context, id string) (*R
 // https://developer.apple.com/documentation/appstoreconnectapi/list_beta_testers
 func (s *TestflightService) ListBetaTesters(ctx context.Context, params *ListBetaTestersQuery) (*BetaTestersResponse, *Response, error) {
 	res := new(BetaTestersResponse)
-	resp, err := s.client.get(ctx, "betaTesters", params, res)
+	resp, err := s.client.get(ctx, "v1/betaTesters", params, res)
 
 	return res, resp, err
 }
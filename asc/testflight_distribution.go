@@ -0,0 +1,406 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	defaultDistributionPollInterval = 30 * time.Second
+	defaultDistributionTimeout      = time.Hour
+)
+
+// ErrBuildNotDistributable happens when DistributeBuild cannot find a valid build, either because
+// processing failed or because the build did not appear before the timeout elapsed.
+type ErrBuildNotDistributable struct {
+	Version         string
+	BuildNumber     string
+	ProcessingState string
+}
+
+func (e ErrBuildNotDistributable) Error() string {
+	if e.ProcessingState == "" {
+		return fmt.Sprintf("build %s (%s) was not found before the timeout elapsed", e.Version, e.BuildNumber)
+	}
+
+	return fmt.Sprintf("build %s (%s) is in processing state %s", e.Version, e.BuildNumber, e.ProcessingState)
+}
+
+// DistributeBuildOptions describes a build and how it should be distributed through TestFlight.
+type DistributeBuildOptions struct {
+	// AppID is the App Store Connect ID of the app.
+	AppID string
+	// Version is the prerelease version, or CFBundleShortVersionString, such as "2.1.0".
+	Version string
+	// BuildNumber is the build's CFBundleVersion.
+	BuildNumber string
+	// Platform narrows the search for the build when an app ships on several platforms.
+	Platform Platform
+
+	// WhatsNew maps locales, such as "en-US", to the "What to Test" notes for the build.
+	WhatsNew map[string]string
+	// UsesNonExemptEncryption answers export compliance for the build when it has not been answered yet.
+	UsesNonExemptEncryption *bool
+	// BetaGroupIDs are the groups the build is added to. External groups cause the build to be
+	// submitted for beta app review.
+	BetaGroupIDs []string
+	// Notify sends a build available notification to testers when the build was added to a group.
+	Notify bool
+
+	// PollInterval is how often to check the build's processing state. Defaults to 30 seconds.
+	PollInterval time.Duration
+	// Timeout is how long to wait for the build to become valid. Defaults to one hour.
+	Timeout time.Duration
+}
+
+// BetaGroupDistribution is the outcome of distributing a build to a single beta group.
+type BetaGroupDistribution struct {
+	BetaGroupID string
+	Name        string
+	Internal    bool
+	// Added is true when the build was added to the group by this call, and false when the
+	// group already had the build.
+	Added bool
+	// BetaReviewState is the state of the build's beta app review, for external groups.
+	BetaReviewState *BetaReviewState
+	// Err is set when distributing to this group failed. Other groups are still attempted.
+	Err error
+}
+
+// DistributeBuildResult describes what DistributeBuild found and changed.
+type DistributeBuildResult struct {
+	Build                     Build
+	LocalizationsCreated      []string
+	LocalizationsUpdated      []string
+	ExportComplianceAnswered  bool
+	BetaAppReviewSubmissionID string
+	NotificationSent          bool
+	Groups                    []BetaGroupDistribution
+}
+
+// DistributeBuild finds a build by app, version and build number, waits for it to finish processing,
+// and distributes it to beta groups: it sets the "What to Test" notes, answers export compliance,
+// adds the build to each group, submits it for beta app review when any group is external, and
+// notifies testers. Every step checks the current state first, so calling it again for the same
+// build only performs what is still missing. Failures that concern a single group are reported in
+// that group's result rather than returned.
+func (s *TestflightService) DistributeBuild(ctx context.Context, options DistributeBuildOptions) (*DistributeBuildResult, error) {
+	if options.PollInterval == 0 {
+		options.PollInterval = defaultDistributionPollInterval
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = defaultDistributionTimeout
+	}
+
+	build, err := s.waitForDistributableBuild(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DistributeBuildResult{Build: *build}
+
+	if err := s.applyWhatsNew(ctx, build.ID, options.WhatsNew, result); err != nil {
+		return result, err
+	}
+
+	if options.UsesNonExemptEncryption != nil && (build.Attributes == nil || build.Attributes.UsesNonExemptEncryption == nil) {
+		if _, _, err := s.client.Builds.UpdateBuild(ctx, build.ID, nil, options.UsesNonExemptEncryption, nil); err != nil {
+			return result, err
+		}
+
+		result.ExportComplianceAnswered = true
+	}
+
+	if len(options.BetaGroupIDs) == 0 {
+		return result, nil
+	}
+
+	groups, err := s.listBetaGroupsByID(ctx, options.BetaGroupIDs)
+	if err != nil {
+		return result, err
+	}
+
+	var external []int
+
+	for _, id := range options.BetaGroupIDs {
+		distribution := s.addBuildToBetaGroup(ctx, build.ID, id, groups)
+		result.Groups = append(result.Groups, distribution)
+
+		if distribution.Err == nil && !distribution.Internal {
+			external = append(external, len(result.Groups)-1)
+		}
+	}
+
+	if len(external) > 0 {
+		submission, err := s.ensureBetaAppReviewSubmission(ctx, build.ID)
+
+		for _, i := range external {
+			if err != nil {
+				result.Groups[i].Err = err
+
+				continue
+			}
+
+			result.Groups[i].BetaReviewState = submission.Attributes.BetaReviewState
+		}
+
+		if submission != nil {
+			result.BetaAppReviewSubmissionID = submission.ID
+		}
+	}
+
+	if options.Notify && anyGroupAdded(result.Groups) {
+		if _, _, err := s.CreateAvailableBuildNotification(ctx, build.ID); err != nil {
+			return result, err
+		}
+
+		result.NotificationSent = true
+	}
+
+	return result, nil
+}
+
+// listBetaGroupsByID returns the beta groups with the given IDs, reading every page.
+func (s *TestflightService) listBetaGroupsByID(ctx context.Context, ids []string) ([]BetaGroup, error) {
+	var groups []BetaGroup
+
+	query := &ListBetaGroupsQuery{FilterID: ids, Limit: 200}
+
+	for {
+		res, _, err := s.ListBetaGroups(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, res.Data...)
+
+		if res.Links.Next == nil {
+			return groups, nil
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func (s *TestflightService) waitForDistributableBuild(ctx context.Context, options DistributeBuildOptions) (*Build, error) {
+	query := &ListBuildsQuery{
+		FilterApp:                      []string{options.AppID},
+		FilterVersion:                  []string{options.BuildNumber},
+		FilterPreReleaseVersionVersion: []string{options.Version},
+	}
+
+	if options.Platform != "" {
+		query.FilterPreReleaseVersionPlatform = []string{string(options.Platform)}
+	}
+
+	deadline := time.Now().Add(options.Timeout)
+	state := ""
+
+	for {
+		builds, _, err := s.client.Builds.ListBuilds(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(builds.Data) > 0 {
+			build := builds.Data[0]
+			if build.Attributes != nil && build.Attributes.ProcessingState != nil {
+				state = *build.Attributes.ProcessingState
+			}
+
+			switch state {
+			case BuildProcessingStateValid:
+				return &build, nil
+			case BuildProcessingStateFailed, BuildProcessingStateInvalid:
+				return nil, ErrBuildNotDistributable{Version: options.Version, BuildNumber: options.BuildNumber, ProcessingState: state}
+			}
+		}
+
+		if time.Now().Add(options.PollInterval).After(deadline) {
+			return nil, ErrBuildNotDistributable{Version: options.Version, BuildNumber: options.BuildNumber, ProcessingState: state}
+		}
+
+		timer := time.NewTimer(options.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *TestflightService) applyWhatsNew(ctx context.Context, buildID string, whatsNew map[string]string, result *DistributeBuildResult) error {
+	if len(whatsNew) == 0 {
+		return nil
+	}
+
+	existing, _, err := s.ListBetaBuildLocalizationsForBuild(ctx, buildID, &ListBetaBuildLocalizationsForBuildQuery{Limit: 200})
+	if err != nil {
+		return err
+	}
+
+	byLocale := make(map[string]BetaBuildLocalization, len(existing.Data))
+
+	for _, localization := range existing.Data {
+		if localization.Attributes != nil && localization.Attributes.Locale != nil {
+			byLocale[*localization.Attributes.Locale] = localization
+		}
+	}
+
+	for _, locale := range sortedKeys(whatsNew) {
+		text := whatsNew[locale]
+
+		localization, ok := byLocale[locale]
+		if !ok {
+			if _, _, err := s.CreateBetaBuildLocalization(ctx, locale, &text, buildID); err != nil {
+				return err
+			}
+
+			result.LocalizationsCreated = append(result.LocalizationsCreated, locale)
+
+			continue
+		}
+
+		if localization.Attributes.WhatsNew != nil && *localization.Attributes.WhatsNew == text {
+			continue
+		}
+
+		if _, _, err := s.UpdateBetaBuildLocalization(ctx, localization.ID, &text); err != nil {
+			return err
+		}
+
+		result.LocalizationsUpdated = append(result.LocalizationsUpdated, locale)
+	}
+
+	return nil
+}
+
+func (s *TestflightService) addBuildToBetaGroup(ctx context.Context, buildID string, betaGroupID string, groups []BetaGroup) BetaGroupDistribution {
+	distribution := BetaGroupDistribution{BetaGroupID: betaGroupID}
+
+	var group *BetaGroup
+
+	for i := range groups {
+		if groups[i].ID == betaGroupID {
+			group = &groups[i]
+
+			break
+		}
+	}
+
+	if group == nil {
+		distribution.Err = fmt.Errorf("beta group %s was not found", betaGroupID)
+
+		return distribution
+	}
+
+	if group.Attributes != nil {
+		if group.Attributes.Name != nil {
+			distribution.Name = *group.Attributes.Name
+		}
+
+		distribution.Internal = group.Attributes.IsInternalGroup != nil && *group.Attributes.IsInternalGroup
+	}
+
+	builds, _, err := s.client.Builds.ListBuilds(ctx, &ListBuildsQuery{
+		FilterID:         []string{buildID},
+		FilterBetaGroups: []string{betaGroupID},
+	})
+	if err != nil {
+		distribution.Err = err
+
+		return distribution
+	}
+
+	if len(builds.Data) > 0 {
+		return distribution
+	}
+
+	if _, err := s.AddBuildsToBetaGroup(ctx, betaGroupID, []string{buildID}); err != nil {
+		distribution.Err = err
+
+		return distribution
+	}
+
+	distribution.Added = true
+
+	return distribution
+}
+
+func (s *TestflightService) ensureBetaAppReviewSubmission(ctx context.Context, buildID string) (*BetaAppReviewSubmission, error) {
+	existing, _, err := s.GetBetaAppReviewSubmissionForBuild(ctx, buildID, nil)
+	if err == nil && existing.Data.ID != "" {
+		return ensureBetaAppReviewSubmissionAttributes(&existing.Data), nil
+	} else if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	created, _, err := s.CreateBetaAppReviewSubmission(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ensureBetaAppReviewSubmissionAttributes(&created.Data), nil
+}
+
+func ensureBetaAppReviewSubmissionAttributes(submission *BetaAppReviewSubmission) *BetaAppReviewSubmission {
+	if submission.Attributes == nil {
+		submission.Attributes = &BetaAppReviewSubmissionAttributes{}
+	}
+
+	return submission
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func anyGroupAdded(groups []BetaGroupDistribution) bool {
+	for _, group := range groups {
+		if group.Added {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNotFoundError(err error) bool {
+	var errResponse *ErrorResponse
+
+	return errors.As(err, &errResponse) && errResponse.Response != nil && errResponse.Response.StatusCode == http.StatusNotFound
+}
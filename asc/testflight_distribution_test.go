@@ -0,0 +1,239 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// distributionServer serves canned bodies keyed by method and path. Build membership lookups for a
+// beta group are keyed with the group ID appended, so tests can decide which groups have the build.
+type distributionServer struct {
+	mu       sync.Mutex
+	bodies   map[string][]string
+	requests map[string]int
+}
+
+func (d *distributionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	if group := r.URL.Query().Get("filter[betaGroups]"); group != "" {
+		key += "?betaGroups=" + group
+	}
+
+	d.requests[key]++
+
+	bodies, ok := d.bodies[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":"404"}]}`)
+
+		return
+	}
+
+	if len(bodies) > 1 {
+		d.bodies[key] = bodies[1:]
+	}
+
+	if bodies[0] == "" {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	fmt.Fprint(w, bodies[0])
+}
+
+func newDistributionServer(t *testing.T, bodies map[string][]string) (*Client, *distributionServer) {
+	t.Helper()
+
+	d := &distributionServer{bodies: bodies, requests: make(map[string]int)}
+	server := httptest.NewServer(d)
+	t.Cleanup(server.Close)
+
+	base, _ := url.Parse(server.URL + "/")
+	client := NewClient(server.Client())
+	client.baseURL = base
+
+	return client, d
+}
+
+func testDistributeBuildOptions() DistributeBuildOptions {
+	return DistributeBuildOptions{
+		AppID:                   "app",
+		Version:                 "2.1.0",
+		BuildNumber:             "417",
+		WhatsNew:                map[string]string{"en-US": "New onboarding", "fr-FR": "Nouvel accueil"},
+		UsesNonExemptEncryption: Bool(false),
+		BetaGroupIDs:            []string{"internal", "external"},
+		Notify:                  true,
+		PollInterval:            time.Millisecond,
+		Timeout:                 time.Second,
+	}
+}
+
+const testDistributionGroups = `{"data":[{"id":"internal","attributes":{"name":"Team","isInternalGroup":true}},{"id":"external","attributes":{"name":"Public","isInternalGroup":false}}]}`
+
+func TestDistributeBuild(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds": {
+			`{"data":[{"id":"build","attributes":{"processingState":"PROCESSING"}}]}`,
+			`{"data":[{"id":"build","attributes":{"processingState":"VALID"}}]}`,
+		},
+		"GET /v1/builds/build/betaBuildLocalizations":       {`{"data":[{"id":"loc-en","attributes":{"locale":"en-US","whatsNew":"Old notes"}}]}`},
+		"PATCH /v1/betaBuildLocalizations/loc-en":           {`{"data":{"id":"loc-en"}}`},
+		"POST /v1/betaBuildLocalizations":                   {`{"data":{"id":"loc-fr"}}`},
+		"PATCH /v1/builds/build":                            {`{"data":{"id":"build"}}`},
+		"GET /v1/betaGroups":                                {testDistributionGroups},
+		"GET /v1/builds?betaGroups=internal":                {`{"data":[{"id":"build"}]}`},
+		"GET /v1/builds?betaGroups=external":                {`{"data":[]}`},
+		"POST /v1/betaGroups/external/relationships/builds": {""},
+		"POST /v1/betaAppReviewSubmissions":                 {`{"data":{"id":"review","attributes":{"betaReviewState":"WAITING_FOR_REVIEW"}}}`},
+		"POST /v1/buildBetaNotifications":                   {`{"data":{"id":"notification"}}`},
+	})
+
+	result, err := client.TestFlight.DistributeBuild(context.Background(), testDistributeBuildOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, "build", result.Build.ID)
+	assert.Equal(t, []string{"fr-FR"}, result.LocalizationsCreated)
+	assert.Equal(t, []string{"en-US"}, result.LocalizationsUpdated)
+	assert.True(t, result.ExportComplianceAnswered)
+	assert.Equal(t, "review", result.BetaAppReviewSubmissionID)
+	assert.True(t, result.NotificationSent)
+
+	waiting := BetaReviewStateWaitingForReview
+	assert.Equal(t, []BetaGroupDistribution{
+		{BetaGroupID: "internal", Name: "Team", Internal: true},
+		{BetaGroupID: "external", Name: "Public", Added: true, BetaReviewState: &waiting},
+	}, result.Groups)
+	assert.Equal(t, 0, server.requests["POST /v1/betaGroups/internal/relationships/builds"])
+}
+
+func TestDistributeBuildIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds": {`{"data":[{"id":"build","attributes":{"processingState":"VALID","usesNonExemptEncryption":false}}]}`},
+		"GET /v1/builds/build/betaBuildLocalizations":  {`{"data":[{"id":"loc-en","attributes":{"locale":"en-US","whatsNew":"New onboarding"}},{"id":"loc-fr","attributes":{"locale":"fr-FR","whatsNew":"Nouvel accueil"}}]}`},
+		"GET /v1/betaGroups":                           {testDistributionGroups},
+		"GET /v1/builds?betaGroups=internal":           {`{"data":[{"id":"build"}]}`},
+		"GET /v1/builds?betaGroups=external":           {`{"data":[{"id":"build"}]}`},
+		"GET /v1/builds/build/betaAppReviewSubmission": {`{"data":{"id":"review","attributes":{"betaReviewState":"APPROVED"}}}`},
+	})
+
+	result, err := client.TestFlight.DistributeBuild(context.Background(), testDistributeBuildOptions())
+	assert.NoError(t, err)
+	assert.Empty(t, result.LocalizationsCreated)
+	assert.Empty(t, result.LocalizationsUpdated)
+	assert.False(t, result.ExportComplianceAnswered)
+	assert.False(t, result.NotificationSent)
+	assert.Equal(t, BetaReviewStateApproved, *result.Groups[1].BetaReviewState)
+
+	for key, count := range server.requests {
+		assert.Equal(t, "GET", key[:3], "unexpected write %s", key)
+		assert.Equal(t, 1, count)
+	}
+}
+
+func TestDistributeBuildReportsMissingGroup(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds":     {`{"data":[{"id":"build","attributes":{"processingState":"VALID"}}]}`},
+		"GET /v1/betaGroups": {`{"data":[]}`},
+	})
+
+	options := testDistributeBuildOptions()
+	options.WhatsNew = nil
+	options.UsesNonExemptEncryption = nil
+	options.BetaGroupIDs = []string{"missing"}
+
+	result, err := client.TestFlight.DistributeBuild(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Len(t, result.Groups, 1)
+	assert.Error(t, result.Groups[0].Err)
+	assert.False(t, result.NotificationSent)
+}
+
+func TestDistributeBuildPagesThroughGroups(t *testing.T) {
+	t.Parallel()
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds": {`{"data":[{"id":"build","attributes":{"processingState":"VALID"}}]}`},
+		"GET /v1/betaGroups": {
+			`{"data":[{"id":"internal","attributes":{"name":"Team","isInternalGroup":true}}],"links":{"next":"https://api.appstoreconnect.apple.com/v1/betaGroups?cursor=2"}}`,
+			`{"data":[{"id":"external","attributes":{"name":"Public","isInternalGroup":false}}]}`,
+		},
+		"GET /v1/builds?betaGroups=internal":           {`{"data":[{"id":"build"}]}`},
+		"GET /v1/builds?betaGroups=external":           {`{"data":[{"id":"build"}]}`},
+		"GET /v1/builds/build/betaAppReviewSubmission": {`{"data":{"id":"review","attributes":{"betaReviewState":"APPROVED"}}}`},
+	})
+
+	options := testDistributeBuildOptions()
+	options.WhatsNew = nil
+	options.UsesNonExemptEncryption = nil
+
+	result, err := client.TestFlight.DistributeBuild(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.requests["GET /v1/betaGroups"])
+
+	for _, group := range result.Groups {
+		assert.NoError(t, group.Err, group.BetaGroupID)
+	}
+}
+
+func TestDistributeBuildFailsOnInvalidBuild(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds": {`{"data":[{"id":"build","attributes":{"processingState":"INVALID"}}]}`},
+	})
+
+	_, err := client.TestFlight.DistributeBuild(context.Background(), testDistributeBuildOptions())
+	assert.Equal(t, ErrBuildNotDistributable{Version: "2.1.0", BuildNumber: "417", ProcessingState: "INVALID"}, err)
+}
+
+func TestDistributeBuildTimesOut(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newDistributionServer(t, map[string][]string{
+		"GET /v1/builds": {`{"data":[]}`},
+	})
+
+	options := testDistributeBuildOptions()
+	options.Timeout = 5 * time.Millisecond
+
+	_, err := client.TestFlight.DistributeBuild(context.Background(), options)
+	assert.Equal(t, ErrBuildNotDistributable{Version: "2.1.0", BuildNumber: "417"}, err)
+}
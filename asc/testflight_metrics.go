@@ -0,0 +1,401 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MetricPeriod defines model for the period of a TestFlight metrics query, as an ISO 8601 duration.
+type MetricPeriod string
+
+const (
+	// MetricPeriodSevenDays covers the last 7 days.
+	MetricPeriodSevenDays MetricPeriod = "P7D"
+	// MetricPeriodThirtyDays covers the last 30 days.
+	MetricPeriodThirtyDays MetricPeriod = "P30D"
+	// MetricPeriodNinetyDays covers the last 90 days.
+	MetricPeriodNinetyDays MetricPeriod = "P90D"
+	// MetricPeriodOneYear covers the last 365 days.
+	MetricPeriodOneYear MetricPeriod = "P365D"
+)
+
+// ErrInvalidMetricPeriod happens when no metrics period covers the requested number of days.
+type ErrInvalidMetricPeriod struct {
+	Days int
+}
+
+func (e ErrInvalidMetricPeriod) Error() string {
+	return fmt.Sprintf("no metrics period covers %d days", e.Days)
+}
+
+// MetricPeriodCovering returns the shortest metrics period that covers the given number of days.
+func MetricPeriodCovering(days int) (MetricPeriod, error) {
+	switch {
+	case days < 1:
+		return "", ErrInvalidMetricPeriod{Days: days}
+	case days <= 7:
+		return MetricPeriodSevenDays, nil
+	case days <= 30:
+		return MetricPeriodThirtyDays, nil
+	case days <= 90:
+		return MetricPeriodNinetyDays, nil
+	case days <= 365:
+		return MetricPeriodOneYear, nil
+	}
+
+	return "", ErrInvalidMetricPeriod{Days: days}
+}
+
+// MetricDimension defines model for a dimension that a metrics series is grouped by.
+type MetricDimension struct {
+	// Data is the ID of the resource the series belongs to, such as a beta tester ID.
+	Data  string               `json:"data,omitempty"`
+	Links MetricDimensionLinks `json:"links"`
+}
+
+// MetricDimensionLinks defines model for the links of a MetricDimension.
+type MetricDimensionLinks struct {
+	GroupBy *Reference `json:"groupBy,omitempty"`
+	Related *Reference `json:"related,omitempty"`
+}
+
+// BetaTesterUsageDimensions defines model for the dimensions of a beta tester usage series.
+type BetaTesterUsageDimensions struct {
+	BetaTesters *MetricDimension `json:"betaTesters,omitempty"`
+}
+
+// BetaTesterUsageValues defines model for the values of a beta tester usage data point.
+type BetaTesterUsageValues struct {
+	CrashCount    int `json:"crashCount"`
+	FeedbackCount int `json:"feedbackCount"`
+	SessionCount  int `json:"sessionCount"`
+}
+
+// BetaTesterUsageDataPoint defines model for a single time window of a beta tester usage series.
+type BetaTesterUsageDataPoint struct {
+	End    *DateTime             `json:"end,omitempty"`
+	Start  *DateTime             `json:"start,omitempty"`
+	Values BetaTesterUsageValues `json:"values"`
+}
+
+// BetaTesterUsageSeries defines model for a beta tester usage time series.
+type BetaTesterUsageSeries struct {
+	DataPoints []BetaTesterUsageDataPoint `json:"dataPoints,omitempty"`
+	Dimensions BetaTesterUsageDimensions  `json:"dimensions"`
+}
+
+// Totals sums the values of every data point in the series.
+func (s BetaTesterUsageSeries) Totals() BetaTesterUsageValues {
+	var totals BetaTesterUsageValues
+
+	for _, point := range s.DataPoints {
+		totals.CrashCount += point.Values.CrashCount
+		totals.FeedbackCount += point.Values.FeedbackCount
+		totals.SessionCount += point.Values.SessionCount
+	}
+
+	return totals
+}
+
+// BetaTesterUsagesResponse defines model for BetaTesterUsagesV1MetricResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betatesterusagesv1metricresponse
+type BetaTesterUsagesResponse struct {
+	Data  []BetaTesterUsageSeries `json:"data"`
+	Links PagedDocumentLinks      `json:"links"`
+	Meta  *PagingInformation      `json:"meta,omitempty"`
+}
+
+// BetaBuildUsageValues defines model for the values of a beta build usage data point.
+type BetaBuildUsageValues struct {
+	CrashCount    int `json:"crashCount"`
+	FeedbackCount int `json:"feedbackCount"`
+	InstallCount  int `json:"installCount"`
+	InviteCount   int `json:"inviteCount"`
+	SessionCount  int `json:"sessionCount"`
+}
+
+// BetaBuildUsageDataPoint defines model for a single time window of a beta build usage series.
+type BetaBuildUsageDataPoint struct {
+	End    *DateTime            `json:"end,omitempty"`
+	Start  *DateTime            `json:"start,omitempty"`
+	Values BetaBuildUsageValues `json:"values"`
+}
+
+// BetaBuildUsageSeries defines model for a beta build usage time series.
+type BetaBuildUsageSeries struct {
+	DataPoints []BetaBuildUsageDataPoint `json:"dataPoints,omitempty"`
+}
+
+// Totals sums the values of every data point in the series.
+func (s BetaBuildUsageSeries) Totals() BetaBuildUsageValues {
+	var totals BetaBuildUsageValues
+
+	for _, point := range s.DataPoints {
+		totals.CrashCount += point.Values.CrashCount
+		totals.FeedbackCount += point.Values.FeedbackCount
+		totals.InstallCount += point.Values.InstallCount
+		totals.InviteCount += point.Values.InviteCount
+		totals.SessionCount += point.Values.SessionCount
+	}
+
+	return totals
+}
+
+// BetaBuildUsagesResponse defines model for BetaBuildUsagesV1MetricResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betabuildusagesv1metricresponse
+type BetaBuildUsagesResponse struct {
+	Data  []BetaBuildUsageSeries `json:"data"`
+	Links PagedDocumentLinks     `json:"links"`
+	Meta  *PagingInformation     `json:"meta,omitempty"`
+}
+
+// BetaPublicLinkUsageValues defines model for the values of a public link usage data point.
+type BetaPublicLinkUsageValues struct {
+	AcceptedCount           int     `json:"acceptedCount"`
+	DidNotAcceptCount       int     `json:"didNotAcceptCount"`
+	DidNotMeetCriteriaCount int     `json:"didNotMeetCriteriaCount"`
+	NotClearRatio           float64 `json:"notClearRatio"`
+	NotInterestingRatio     float64 `json:"notInterestingRatio"`
+	NotRelevantRatio        float64 `json:"notRelevantRatio"`
+	ViewCount               int     `json:"viewCount"`
+}
+
+// BetaPublicLinkUsageDataPoint defines model for a single time window of a public link usage series.
+type BetaPublicLinkUsageDataPoint struct {
+	End    *DateTime                 `json:"end,omitempty"`
+	Start  *DateTime                 `json:"start,omitempty"`
+	Values BetaPublicLinkUsageValues `json:"values"`
+}
+
+// BetaPublicLinkUsageSeries defines model for a public link usage time series.
+type BetaPublicLinkUsageSeries struct {
+	DataPoints []BetaPublicLinkUsageDataPoint `json:"dataPoints,omitempty"`
+}
+
+// BetaPublicLinkUsagesResponse defines model for BetaPublicLinkUsagesV1MetricResponse.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/betapubliclinkusagesv1metricresponse
+type BetaPublicLinkUsagesResponse struct {
+	Data  []BetaPublicLinkUsageSeries `json:"data"`
+	Links PagedDocumentLinks          `json:"links"`
+	Meta  *PagingInformation          `json:"meta,omitempty"`
+}
+
+// GetBetaTesterUsagesForAppQuery are query options for GetBetaTesterUsagesForApp
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_beta_tester_usage_metrics_for_an_app
+type GetBetaTesterUsagesForAppQuery struct {
+	FilterBetaTesters []string     `url:"filter[betaTesters],omitempty"`
+	GroupBy           []string     `url:"groupBy,omitempty"`
+	Period            MetricPeriod `url:"period,omitempty"`
+	Limit             int          `url:"limit,omitempty"`
+	Cursor            string       `url:"cursor,omitempty"`
+}
+
+// GetBetaTesterUsagesForBetaGroupQuery are query options for GetBetaTesterUsagesForBetaGroup
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_beta_tester_usage_metrics_for_a_beta_group
+type GetBetaTesterUsagesForBetaGroupQuery struct {
+	FilterBetaTesters []string     `url:"filter[betaTesters],omitempty"`
+	GroupBy           []string     `url:"groupBy,omitempty"`
+	Period            MetricPeriod `url:"period,omitempty"`
+	Limit             int          `url:"limit,omitempty"`
+	Cursor            string       `url:"cursor,omitempty"`
+}
+
+// GetBetaTesterUsagesForBetaTesterQuery are query options for GetBetaTesterUsagesForBetaTester.
+// FilterApps is required by App Store Connect.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_usage_metrics_for_a_beta_tester
+type GetBetaTesterUsagesForBetaTesterQuery struct {
+	FilterApps []string     `url:"filter[apps],omitempty"`
+	Period     MetricPeriod `url:"period,omitempty"`
+	Limit      int          `url:"limit,omitempty"`
+	Cursor     string       `url:"cursor,omitempty"`
+}
+
+// GetPublicLinkUsagesForBetaGroupQuery are query options for GetPublicLinkUsagesForBetaGroup
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_public_link_usage_metrics_for_a_beta_group
+type GetPublicLinkUsagesForBetaGroupQuery struct {
+	Limit  int    `url:"limit,omitempty"`
+	Cursor string `url:"cursor,omitempty"`
+}
+
+// GetBetaBuildUsagesForBuildQuery are query options for GetBetaBuildUsagesForBuild
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_usage_metrics_for_a_build
+type GetBetaBuildUsagesForBuildQuery struct {
+	Limit  int    `url:"limit,omitempty"`
+	Cursor string `url:"cursor,omitempty"`
+}
+
+// GetBetaTesterUsagesForApp gets the sessions, crashes and feedback of an app's beta testers over time.
+// Set GroupBy to "betaTesters" to get one series per tester.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_beta_tester_usage_metrics_for_an_app
+func (s *TestflightService) GetBetaTesterUsagesForApp(ctx context.Context, id string, params *GetBetaTesterUsagesForAppQuery) (*BetaTesterUsagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/apps/%s/metrics/betaTesterUsages", id)
+	res := new(BetaTesterUsagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetBetaTesterUsagesForBetaGroup gets the sessions, crashes and feedback of a beta group's testers over time.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_beta_tester_usage_metrics_for_a_beta_group
+func (s *TestflightService) GetBetaTesterUsagesForBetaGroup(ctx context.Context, id string, params *GetBetaTesterUsagesForBetaGroupQuery) (*BetaTesterUsagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaGroups/%s/metrics/betaTesterUsages", id)
+	res := new(BetaTesterUsagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetBetaTesterUsagesForBetaTester gets the sessions, crashes and feedback of a single beta tester for an app over time.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_usage_metrics_for_a_beta_tester
+func (s *TestflightService) GetBetaTesterUsagesForBetaTester(ctx context.Context, id string, params *GetBetaTesterUsagesForBetaTesterQuery) (*BetaTesterUsagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaTesters/%s/metrics/betaTesterUsages", id)
+	res := new(BetaTesterUsagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetPublicLinkUsagesForBetaGroup gets how many people viewed and accepted a beta group's public link over time.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_public_link_usage_metrics_for_a_beta_group
+func (s *TestflightService) GetPublicLinkUsagesForBetaGroup(ctx context.Context, id string, params *GetPublicLinkUsagesForBetaGroupQuery) (*BetaPublicLinkUsagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/betaGroups/%s/metrics/publicLinkUsages", id)
+	res := new(BetaPublicLinkUsagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// GetBetaBuildUsagesForBuild gets the installs, sessions, crashes and feedback of a build over time.
+//
+// https://developer.apple.com/documentation/appstoreconnectapi/get_usage_metrics_for_a_build
+func (s *TestflightService) GetBetaBuildUsagesForBuild(ctx context.Context, id string, params *GetBetaBuildUsagesForBuildQuery) (*BetaBuildUsagesResponse, *Response, error) {
+	url := fmt.Sprintf("v1/builds/%s/metrics/betaBuildUsages", id)
+	res := new(BetaBuildUsagesResponse)
+	resp, err := s.client.get(ctx, url, params, res)
+
+	return res, resp, err
+}
+
+// InactiveBetaTester is a beta tester with no sessions in the window given to ListInactiveBetaTesters.
+type InactiveBetaTester struct {
+	BetaTester BetaTester
+	// LastSession is the end of the most recent data point with a session, if one falls within the
+	// metrics period but before the window. It is nil when the tester has no sessions in the period at all.
+	LastSession *time.Time
+}
+
+// ListInactiveBetaTesters lists the testers of an app with no sessions in the last days days, so that
+// they can be removed before the app reaches the TestFlight tester limit. A data point that straddles
+// the start of the window counts as activity, so testers are never reported while they may still be active.
+func (s *TestflightService) ListInactiveBetaTesters(ctx context.Context, appID string, days int) ([]InactiveBetaTester, *Response, error) {
+	period, err := MetricPeriodCovering(days)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	lastSessions := make(map[string]time.Time)
+	usageQuery := &GetBetaTesterUsagesForAppQuery{
+		GroupBy: []string{"betaTesters"},
+		Period:  period,
+		Limit:   200,
+	}
+
+	for {
+		res, resp, err := s.GetBetaTesterUsagesForApp(ctx, appID, usageQuery)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		for _, series := range res.Data {
+			if series.Dimensions.BetaTesters == nil {
+				continue
+			}
+
+			id := series.Dimensions.BetaTesters.Data
+
+			for _, point := range series.DataPoints {
+				if point.Values.SessionCount == 0 || point.End == nil {
+					continue
+				}
+
+				if point.End.After(lastSessions[id]) {
+					lastSessions[id] = point.End.Time
+				}
+			}
+		}
+
+		if res.Links.Next == nil {
+			break
+		}
+
+		usageQuery.Cursor = res.Links.Next.Cursor()
+	}
+
+	var inactive []InactiveBetaTester
+
+	testerQuery := &ListBetaTestersQuery{
+		FilterApps: []string{appID},
+		Limit:      200,
+	}
+
+	for {
+		res, resp, err := s.ListBetaTesters(ctx, testerQuery)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		for _, tester := range res.Data {
+			last, ok := lastSessions[tester.ID]
+			if ok && last.After(cutoff) {
+				continue
+			}
+
+			entry := InactiveBetaTester{BetaTester: tester}
+			if ok {
+				entry.LastSession = &last
+			}
+
+			inactive = append(inactive, entry)
+		}
+
+		if res.Links.Next == nil {
+			return inactive, resp, nil
+		}
+
+		testerQuery.Cursor = res.Links.Next.Cursor()
+	}
+}
@@ -0,0 +1,140 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package asc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBetaTesterUsagesForApp(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaTesterUsagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaTesterUsagesForApp(ctx, "10", &GetBetaTesterUsagesForAppQuery{Period: MetricPeriodThirtyDays})
+	})
+}
+
+func TestGetBetaTesterUsagesForBetaGroup(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaTesterUsagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaTesterUsagesForBetaGroup(ctx, "10", &GetBetaTesterUsagesForBetaGroupQuery{})
+	})
+}
+
+func TestGetBetaTesterUsagesForBetaTester(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaTesterUsagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaTesterUsagesForBetaTester(ctx, "10", &GetBetaTesterUsagesForBetaTesterQuery{FilterApps: []string{"20"}})
+	})
+}
+
+func TestGetPublicLinkUsagesForBetaGroup(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaPublicLinkUsagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetPublicLinkUsagesForBetaGroup(ctx, "10", &GetPublicLinkUsagesForBetaGroupQuery{})
+	})
+}
+
+func TestGetBetaBuildUsagesForBuild(t *testing.T) {
+	t.Parallel()
+
+	testEndpointWithResponse(t, "{}", &BetaBuildUsagesResponse{}, func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		return client.TestFlight.GetBetaBuildUsagesForBuild(ctx, "10", &GetBetaBuildUsagesForBuildQuery{})
+	})
+}
+
+func TestBetaBuildUsagesDecoding(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"data":[{"dataPoints":[
+		{"start":"2026-10-01T00:00:00Z","end":"2026-10-02T00:00:00Z","values":{"installCount":4,"sessionCount":10,"crashCount":1}},
+		{"start":"2026-10-02T00:00:00Z","end":"2026-10-03T00:00:00Z","values":{"installCount":2,"sessionCount":5,"feedbackCount":3}}
+	]}],"links":{"self":""}}`
+
+	var res BetaBuildUsagesResponse
+	assert.NoError(t, json.Unmarshal([]byte(raw), &res))
+	assert.Len(t, res.Data, 1)
+	assert.Equal(t, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), res.Data[0].DataPoints[1].Start.UTC())
+	assert.Equal(t, BetaBuildUsageValues{CrashCount: 1, FeedbackCount: 3, InstallCount: 6, SessionCount: 15}, res.Data[0].Totals())
+}
+
+func TestMetricPeriodCovering(t *testing.T) {
+	t.Parallel()
+
+	for days, expected := range map[int]MetricPeriod{1: MetricPeriodSevenDays, 7: MetricPeriodSevenDays, 8: MetricPeriodThirtyDays, 90: MetricPeriodNinetyDays, 365: MetricPeriodOneYear} {
+		period, err := MetricPeriodCovering(days)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, period, "days: %d", days)
+	}
+
+	_, err := MetricPeriodCovering(0)
+	assert.Equal(t, ErrInvalidMetricPeriod{Days: 0}, err)
+
+	_, err = MetricPeriodCovering(400)
+	assert.Error(t, err)
+}
+
+func TestListInactiveBetaTesters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	recent := now.AddDate(0, 0, -2).Format(time.RFC3339)
+	stale := now.AddDate(0, 0, -20).Format(time.RFC3339)
+
+	client, server := newDistributionServer(t, map[string][]string{
+		"GET /v1/apps/app/metrics/betaTesterUsages": {
+			fmt.Sprintf(`{"data":[
+				{"dimensions":{"betaTesters":{"data":"active"}},"dataPoints":[{"end":%q,"values":{"sessionCount":3}}]},
+				{"dimensions":{"betaTesters":{"data":"stale"}},"dataPoints":[{"end":%q,"values":{"sessionCount":1}},{"end":%q,"values":{"sessionCount":0}}]}
+			],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/apps/app/metrics/betaTesterUsages?cursor=abc"}}`, recent, stale, recent),
+			`{"data":[],"links":{"self":""}}`,
+		},
+		"GET /v1/betaTesters": {
+			`{"data":[{"id":"active"},{"id":"stale"},{"id":"never"}],"links":{"self":""}}`,
+		},
+	})
+
+	inactive, _, err := client.TestFlight.ListInactiveBetaTesters(context.Background(), "app", 14)
+	assert.NoError(t, err)
+	assert.Len(t, inactive, 2)
+	assert.Equal(t, "stale", inactive[0].BetaTester.ID)
+	assert.NotNil(t, inactive[0].LastSession)
+	assert.Equal(t, stale, inactive[0].LastSession.UTC().Format(time.RFC3339))
+	assert.Equal(t, "never", inactive[1].BetaTester.ID)
+	assert.Nil(t, inactive[1].LastSession)
+	assert.Equal(t, 2, server.requests["GET /v1/apps/app/metrics/betaTesterUsages"])
+}
+
+func TestListInactiveBetaTestersInvalidDays(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := NewClient(nil).TestFlight.ListInactiveBetaTesters(context.Background(), "app", 0)
+	assert.Equal(t, ErrInvalidMetricPeriod{Days: 0}, err)
+}
@@ -0,0 +1,575 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package betametadata manages an app's TestFlight metadata as files that can be kept under version
+control: the beta app localizations, the "What to Test" notes of builds, the beta license agreement
+and the beta app review details.
+
+Metadata is fetched from App Store Connect and written to a directory, edited, then read back and
+compared with what App Store Connect has now. The resulting Plan creates, updates and deletes
+resources through asc.TestflightService, and can be reviewed before it is applied:
+
+	current, err := betametadata.Fetch(ctx, client, "1234567890", buildIDs)
+	err = betametadata.Write("testflight", current)
+
+	desired, err := betametadata.Read("testflight")
+	remote, err := betametadata.Fetch(ctx, client, desired.AppID, desired.BuildIDs())
+	plan := betametadata.NewPlan(remote, desired)
+	result, err := plan.Apply(ctx, client)
+
+See Read for the layout of the directory.
+*/
+package betametadata
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const pageLimit = 200
+
+// AppLocalization is the TestFlight information shown to testers in one locale.
+type AppLocalization struct {
+	Description       string `json:"description,omitempty"`
+	FeedbackEmail     string `json:"feedbackEmail,omitempty"`
+	MarketingURL      string `json:"marketingUrl,omitempty"`
+	PrivacyPolicyURL  string `json:"privacyPolicyUrl,omitempty"`
+	TVOSPrivacyPolicy string `json:"tvOsPrivacyPolicy,omitempty"`
+}
+
+// ReviewDetail is the contact and demo account information given to beta app review.
+type ReviewDetail struct {
+	ContactFirstName    string `json:"contactFirstName,omitempty"`
+	ContactLastName     string `json:"contactLastName,omitempty"`
+	ContactEmail        string `json:"contactEmail,omitempty"`
+	ContactPhone        string `json:"contactPhone,omitempty"`
+	DemoAccountRequired *bool  `json:"demoAccountRequired,omitempty"`
+	DemoAccountName     string `json:"demoAccountName,omitempty"`
+	// DemoAccountPassword is never filled in by Fetch, so that it is not written to disk. Set it
+	// before applying a plan to change the password.
+	DemoAccountPassword string `json:"demoAccountPassword,omitempty"`
+	Notes               string `json:"notes,omitempty"`
+}
+
+// Metadata is the TestFlight metadata of an app. A nil field is not managed: NewPlan leaves the
+// corresponding resources alone. Within AppLocalization and ReviewDetail, empty strings are not
+// managed either, since App Store Connect does not allow clearing most of these attributes.
+type Metadata struct {
+	AppID string
+	// LicenseAgreement is the text of the beta license agreement.
+	LicenseAgreement *string
+	ReviewDetail     *ReviewDetail
+	// Localizations maps locales, such as "en-US", to the beta app localization of that locale.
+	Localizations map[string]AppLocalization
+	// WhatsNew maps build IDs to the "What to Test" notes of the build, by locale.
+	WhatsNew map[string]map[string]string
+
+	// ids maps resources fetched from App Store Connect to their IDs. Keys are built by the
+	// *Key functions below.
+	ids map[string]string
+}
+
+// BuildIDs returns the IDs of the builds that have "What to Test" notes, in order.
+func (m *Metadata) BuildIDs() []string {
+	ids := make([]string, 0, len(m.WhatsNew))
+	for id := range m.WhatsNew {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+const (
+	licenseAgreementKey = "betaLicenseAgreement"
+	reviewDetailKey     = "betaAppReviewDetail"
+)
+
+func appLocalizationKey(locale string) string {
+	return "betaAppLocalization/" + locale
+}
+
+func buildLocalizationKey(buildID, locale string) string {
+	return "betaBuildLocalization/" + buildID + "/" + locale
+}
+
+// Fetch gets the TestFlight metadata of an app, and the "What to Test" notes of the given builds.
+func Fetch(ctx context.Context, client *asc.Client, appID string, buildIDs []string) (*Metadata, error) {
+	m := &Metadata{
+		AppID:         appID,
+		Localizations: make(map[string]AppLocalization),
+		WhatsNew:      make(map[string]map[string]string, len(buildIDs)),
+		ids:           make(map[string]string),
+	}
+
+	agreement, _, err := client.TestFlight.GetBetaLicenseAgreementForApp(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m.LicenseAgreement = asc.String("")
+	m.ids[licenseAgreementKey] = agreement.Data.ID
+
+	if agreement.Data.Attributes != nil && agreement.Data.Attributes.AgreementText != nil {
+		m.LicenseAgreement = agreement.Data.Attributes.AgreementText
+	}
+
+	detail, _, err := client.TestFlight.GetBetaAppReviewDetailsForApp(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m.ReviewDetail = newReviewDetail(detail.Data.Attributes)
+	m.ids[reviewDetailKey] = detail.Data.ID
+
+	query := &asc.ListBetaAppLocalizationsForAppQuery{Limit: pageLimit}
+
+	for {
+		localizations, _, err := client.TestFlight.ListBetaAppLocalizationsForApp(ctx, appID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, localization := range localizations.Data {
+			if localization.Attributes == nil || localization.Attributes.Locale == nil {
+				continue
+			}
+
+			locale := *localization.Attributes.Locale
+			m.Localizations[locale] = newAppLocalization(localization.Attributes)
+			m.ids[appLocalizationKey(locale)] = localization.ID
+		}
+
+		if localizations.Links.Next == nil {
+			break
+		}
+
+		query.Cursor = localizations.Links.Next.Cursor()
+	}
+
+	for _, buildID := range buildIDs {
+		localizations, _, err := client.TestFlight.ListBetaBuildLocalizationsForBuild(ctx, buildID, &asc.ListBetaBuildLocalizationsForBuildQuery{Limit: pageLimit})
+		if err != nil {
+			return nil, err
+		}
+
+		notes := make(map[string]string, len(localizations.Data))
+
+		for _, localization := range localizations.Data {
+			if localization.Attributes == nil || localization.Attributes.Locale == nil {
+				continue
+			}
+
+			locale := *localization.Attributes.Locale
+			notes[locale] = ascutil.StringValue(localization.Attributes.WhatsNew)
+			m.ids[buildLocalizationKey(buildID, locale)] = localization.ID
+		}
+
+		m.WhatsNew[buildID] = notes
+	}
+
+	return m, nil
+}
+
+func newReviewDetail(attributes *asc.BetaAppReviewDetailAttributes) *ReviewDetail {
+	if attributes == nil {
+		return &ReviewDetail{}
+	}
+
+	return &ReviewDetail{
+		ContactFirstName:    ascutil.StringValue(attributes.ContactFirstName),
+		ContactLastName:     ascutil.StringValue(attributes.ContactLastName),
+		ContactEmail:        ascutil.StringValue(attributes.ContactEmail),
+		ContactPhone:        ascutil.StringValue(attributes.ContactPhone),
+		DemoAccountRequired: attributes.DemoAccountRequired,
+		DemoAccountName:     ascutil.StringValue(attributes.DemoAccountName),
+		Notes:               ascutil.StringValue(attributes.Notes),
+	}
+}
+
+func newAppLocalization(attributes *asc.BetaAppLocalizationAttributes) AppLocalization {
+	return AppLocalization{
+		Description:       ascutil.StringValue(attributes.Description),
+		FeedbackEmail:     ascutil.StringValue(attributes.FeedbackEmail),
+		MarketingURL:      ascutil.StringValue(attributes.MarketingURL),
+		PrivacyPolicyURL:  ascutil.StringValue(attributes.PrivacyPolicyURL),
+		TVOSPrivacyPolicy: ascutil.StringValue(attributes.TVOSPrivacyPolicy),
+	}
+}
+
+// Action is what a Change does to a resource.
+type Action string
+
+const (
+	// ActionCreate creates a resource.
+	ActionCreate Action = "create"
+	// ActionUpdate updates an existing resource.
+	ActionUpdate Action = "update"
+	// ActionDelete deletes an existing resource.
+	ActionDelete Action = "delete"
+)
+
+// Resource is the kind of resource a Change applies to.
+type Resource string
+
+const (
+	// ResourceLicenseAgreement is the app's beta license agreement.
+	ResourceLicenseAgreement Resource = "betaLicenseAgreement"
+	// ResourceReviewDetail is the app's beta app review detail.
+	ResourceReviewDetail Resource = "betaAppReviewDetail"
+	// ResourceAppLocalization is a beta app localization.
+	ResourceAppLocalization Resource = "betaAppLocalization"
+	// ResourceBuildLocalization is a beta build localization.
+	ResourceBuildLocalization Resource = "betaBuildLocalization"
+)
+
+// Change is a single create, update or delete of a resource.
+type Change struct {
+	Action   Action
+	Resource Resource
+	// ID is the ID of the resource that is updated or deleted. It is empty for creates.
+	ID string
+	// Locale is set for localizations.
+	Locale string
+	// BuildID is set for build localizations.
+	BuildID string
+
+	licenseAgreement string
+	reviewDetail     ReviewDetail
+	appLocalization  AppLocalization
+	whatsNew         string
+}
+
+func (c Change) String() string {
+	switch c.Resource {
+	case ResourceAppLocalization:
+		return fmt.Sprintf("%s %s %s", c.Action, c.Resource, c.Locale)
+	case ResourceBuildLocalization:
+		return fmt.Sprintf("%s %s %s of build %s", c.Action, c.Resource, c.Locale, c.BuildID)
+	default:
+		return fmt.Sprintf("%s %s", c.Action, c.Resource)
+	}
+}
+
+// Plan is the list of changes that makes App Store Connect match the desired metadata.
+type Plan struct {
+	AppID   string
+	Changes []Change
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// NewPlan compares the current metadata, as returned by Fetch, with the desired metadata. Locales
+// that are missing from desired are deleted, but only for the sections desired manages: every
+// beta app localization when desired.Localizations is not nil, and the localizations of each build
+// that has an entry in desired.WhatsNew.
+func NewPlan(current *Metadata, desired *Metadata) *Plan {
+	plan := &Plan{AppID: current.AppID}
+
+	if desired.LicenseAgreement != nil && current.LicenseAgreement != nil && *desired.LicenseAgreement != *current.LicenseAgreement {
+		plan.Changes = append(plan.Changes, Change{
+			Action:           ActionUpdate,
+			Resource:         ResourceLicenseAgreement,
+			ID:               current.ids[licenseAgreementKey],
+			licenseAgreement: *desired.LicenseAgreement,
+		})
+	}
+
+	if desired.ReviewDetail != nil && current.ReviewDetail != nil {
+		if update, changed := diffReviewDetail(*current.ReviewDetail, *desired.ReviewDetail); changed {
+			plan.Changes = append(plan.Changes, Change{
+				Action:       ActionUpdate,
+				Resource:     ResourceReviewDetail,
+				ID:           current.ids[reviewDetailKey],
+				reviewDetail: update,
+			})
+		}
+	}
+
+	if desired.Localizations != nil {
+		plan.Changes = append(plan.Changes, diffAppLocalizations(current, desired.Localizations)...)
+	}
+
+	for _, buildID := range desired.BuildIDs() {
+		plan.Changes = append(plan.Changes, diffBuildLocalizations(current, buildID, desired.WhatsNew[buildID])...)
+	}
+
+	return plan
+}
+
+// diffReviewDetail returns the fields of desired that differ from current. Empty fields are left out.
+func diffReviewDetail(current, desired ReviewDetail) (ReviewDetail, bool) {
+	var update ReviewDetail
+
+	changed := false
+	diff := func(current string, desired string, field *string) {
+		if desired != "" && desired != current {
+			*field = desired
+			changed = true
+		}
+	}
+
+	diff(current.ContactFirstName, desired.ContactFirstName, &update.ContactFirstName)
+	diff(current.ContactLastName, desired.ContactLastName, &update.ContactLastName)
+	diff(current.ContactEmail, desired.ContactEmail, &update.ContactEmail)
+	diff(current.ContactPhone, desired.ContactPhone, &update.ContactPhone)
+	diff(current.DemoAccountName, desired.DemoAccountName, &update.DemoAccountName)
+	diff(current.DemoAccountPassword, desired.DemoAccountPassword, &update.DemoAccountPassword)
+	diff(current.Notes, desired.Notes, &update.Notes)
+
+	if desired.DemoAccountRequired != nil && (current.DemoAccountRequired == nil || *current.DemoAccountRequired != *desired.DemoAccountRequired) {
+		update.DemoAccountRequired = desired.DemoAccountRequired
+		changed = true
+	}
+
+	return update, changed
+}
+
+// diffAppLocalization returns the fields of desired that differ from current. Empty fields are left out.
+func diffAppLocalization(current, desired AppLocalization) (AppLocalization, bool) {
+	var update AppLocalization
+
+	changed := false
+	diff := func(current string, desired string, field *string) {
+		if desired != "" && desired != current {
+			*field = desired
+			changed = true
+		}
+	}
+
+	diff(current.Description, desired.Description, &update.Description)
+	diff(current.FeedbackEmail, desired.FeedbackEmail, &update.FeedbackEmail)
+	diff(current.MarketingURL, desired.MarketingURL, &update.MarketingURL)
+	diff(current.PrivacyPolicyURL, desired.PrivacyPolicyURL, &update.PrivacyPolicyURL)
+	diff(current.TVOSPrivacyPolicy, desired.TVOSPrivacyPolicy, &update.TVOSPrivacyPolicy)
+
+	return update, changed
+}
+
+func diffAppLocalizations(current *Metadata, desired map[string]AppLocalization) []Change {
+	var changes []Change
+
+	for _, locale := range sortedLocales(desired) {
+		existing, ok := current.Localizations[locale]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Resource: ResourceAppLocalization, Locale: locale, appLocalization: desired[locale]})
+
+			continue
+		}
+
+		if update, changed := diffAppLocalization(existing, desired[locale]); changed {
+			changes = append(changes, Change{
+				Action:          ActionUpdate,
+				Resource:        ResourceAppLocalization,
+				ID:              current.ids[appLocalizationKey(locale)],
+				Locale:          locale,
+				appLocalization: update,
+			})
+		}
+	}
+
+	for _, locale := range sortedLocales(current.Localizations) {
+		if _, ok := desired[locale]; !ok {
+			changes = append(changes, Change{Action: ActionDelete, Resource: ResourceAppLocalization, ID: current.ids[appLocalizationKey(locale)], Locale: locale})
+		}
+	}
+
+	return changes
+}
+
+func diffBuildLocalizations(current *Metadata, buildID string, desired map[string]string) []Change {
+	var changes []Change
+
+	existing := current.WhatsNew[buildID]
+
+	for _, locale := range ascutil.SortedKeys(desired) {
+		notes, ok := existing[locale]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Resource: ResourceBuildLocalization, Locale: locale, BuildID: buildID, whatsNew: desired[locale]})
+
+			continue
+		}
+
+		if notes != desired[locale] {
+			changes = append(changes, Change{
+				Action:   ActionUpdate,
+				Resource: ResourceBuildLocalization,
+				ID:       current.ids[buildLocalizationKey(buildID, locale)],
+				Locale:   locale,
+				BuildID:  buildID,
+				whatsNew: desired[locale],
+			})
+		}
+	}
+
+	for _, locale := range ascutil.SortedKeys(existing) {
+		if _, ok := desired[locale]; !ok {
+			changes = append(changes, Change{
+				Action:   ActionDelete,
+				Resource: ResourceBuildLocalization,
+				ID:       current.ids[buildLocalizationKey(buildID, locale)],
+				Locale:   locale,
+				BuildID:  buildID,
+			})
+		}
+	}
+
+	return changes
+}
+
+// Failure records a change that could not be applied.
+type Failure struct {
+	Change Change
+	Err    error
+}
+
+// Result lists the changes made by Apply.
+type Result struct {
+	Applied  []Change
+	Failures []Failure
+}
+
+// ErrApply collects the metadata changes that App Store Connect rejected, as also listed in
+// Result.Failures.
+type ErrApply struct {
+	Failures []Failure
+}
+
+func (e ErrApply) Error() string {
+	return fmt.Sprintf("%d metadata changes failed, first: %s: %s", len(e.Failures), e.Failures[0].Change, e.Failures[0].Err)
+}
+
+// Apply makes the changes in the plan, in order, carrying on past a change that fails. When any
+// fail, the error is an ErrApply naming them.
+func (p *Plan) Apply(ctx context.Context, client *asc.Client) (*Result, error) {
+	result := &Result{}
+
+	for _, change := range p.Changes {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if err := p.apply(ctx, client, change); err != nil {
+			result.Failures = append(result.Failures, Failure{Change: change, Err: err})
+
+			continue
+		}
+
+		result.Applied = append(result.Applied, change)
+	}
+
+	if len(result.Failures) > 0 {
+		return result, ErrApply{Failures: result.Failures}
+	}
+
+	return result, nil
+}
+
+func (p *Plan) apply(ctx context.Context, client *asc.Client, change Change) error {
+	var err error
+
+	switch change.Resource {
+	case ResourceLicenseAgreement:
+		_, _, err = client.TestFlight.UpdateBetaLicenseAgreement(ctx, change.ID, asc.String(change.licenseAgreement))
+	case ResourceReviewDetail:
+		detail := change.reviewDetail
+		_, _, err = client.TestFlight.UpdateBetaAppReviewDetail(ctx, change.ID, &asc.BetaAppReviewDetailUpdateRequestAttributes{
+			ContactEmail:        ascutil.OptionalString(detail.ContactEmail),
+			ContactFirstName:    ascutil.OptionalString(detail.ContactFirstName),
+			ContactLastName:     ascutil.OptionalString(detail.ContactLastName),
+			ContactPhone:        ascutil.OptionalString(detail.ContactPhone),
+			DemoAccountName:     ascutil.OptionalString(detail.DemoAccountName),
+			DemoAccountPassword: ascutil.OptionalString(detail.DemoAccountPassword),
+			DemoAccountRequired: detail.DemoAccountRequired,
+			Notes:               ascutil.OptionalString(detail.Notes),
+		})
+	case ResourceAppLocalization:
+		err = applyAppLocalization(ctx, client, p.AppID, change)
+	case ResourceBuildLocalization:
+		err = applyBuildLocalization(ctx, client, change)
+	}
+
+	return err
+}
+
+func applyAppLocalization(ctx context.Context, client *asc.Client, appID string, change Change) error {
+	localization := change.appLocalization
+
+	var err error
+
+	switch change.Action {
+	case ActionCreate:
+		_, _, err = client.TestFlight.CreateBetaAppLocalization(ctx, asc.BetaAppLocalizationCreateRequestAttributes{
+			Description:       ascutil.OptionalString(localization.Description),
+			FeedbackEmail:     ascutil.OptionalString(localization.FeedbackEmail),
+			Locale:            change.Locale,
+			MarketingURL:      ascutil.OptionalString(localization.MarketingURL),
+			PrivacyPolicyURL:  ascutil.OptionalString(localization.PrivacyPolicyURL),
+			TVOSPrivacyPolicy: ascutil.OptionalString(localization.TVOSPrivacyPolicy),
+		}, appID)
+	case ActionUpdate:
+		_, _, err = client.TestFlight.UpdateBetaAppLocalization(ctx, change.ID, &asc.BetaAppLocalizationUpdateRequestAttributes{
+			Description:       ascutil.OptionalString(localization.Description),
+			FeedbackEmail:     ascutil.OptionalString(localization.FeedbackEmail),
+			MarketingURL:      ascutil.OptionalString(localization.MarketingURL),
+			PrivacyPolicyURL:  ascutil.OptionalString(localization.PrivacyPolicyURL),
+			TVOSPrivacyPolicy: ascutil.OptionalString(localization.TVOSPrivacyPolicy),
+		})
+	case ActionDelete:
+		_, err = client.TestFlight.DeleteBetaAppLocalization(ctx, change.ID)
+	}
+
+	return err
+}
+
+func applyBuildLocalization(ctx context.Context, client *asc.Client, change Change) error {
+	var err error
+
+	switch change.Action {
+	case ActionCreate:
+		_, _, err = client.TestFlight.CreateBetaBuildLocalization(ctx, change.Locale, asc.String(change.whatsNew), change.BuildID)
+	case ActionUpdate:
+		_, _, err = client.TestFlight.UpdateBetaBuildLocalization(ctx, change.ID, asc.String(change.whatsNew))
+	case ActionDelete:
+		_, err = client.TestFlight.DeleteBetaBuildLocalization(ctx, change.ID)
+	}
+
+	return err
+}
+
+func sortedLocales(m map[string]AppLocalization) []string {
+	locales := make([]string, 0, len(m))
+	for locale := range m {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
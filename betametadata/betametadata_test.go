@@ -0,0 +1,144 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package betametadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func testRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/apps/app/betaLicenseAgreement":       `{"data":{"id":"license","attributes":{"agreementText":"Be nice."}}}`,
+		"GET /v1/apps/app/betaAppReviewDetail":        `{"data":{"id":"detail","attributes":{"contactEmail":"ada@example.com","demoAccountName":"demo","demoAccountPassword":"secret"}}}`,
+		"GET /v1/apps/app/betaAppLocalizations":       `{"data":[{"id":"loc-en","attributes":{"locale":"en-US","description":"A beta","feedbackEmail":"beta@example.com"}},{"id":"loc-de","attributes":{"locale":"de-DE","description":"Eine Beta"}}]}`,
+		"GET /v1/builds/build/betaBuildLocalizations": `{"data":[{"id":"notes-en","attributes":{"locale":"en-US","whatsNew":"Try the new onboarding"}},{"id":"notes-de","attributes":{"locale":"de-DE","whatsNew":"Neues Onboarding"}}]}`,
+		"PATCH /v1/betaLicenseAgreements/license":     `{"data":{"id":"license"}}`,
+		"PATCH /v1/betaAppReviewDetails/detail":       `{"data":{"id":"detail"}}`,
+		"PATCH /v1/betaAppLocalizations/loc-en":       `{"data":{"id":"loc-en"}}`,
+		"POST /v1/betaAppLocalizations":               `{"data":{"id":"loc-fr"}}`,
+		"DELETE /v1/betaAppLocalizations/loc-de":      "",
+		"PATCH /v1/betaBuildLocalizations/notes-en":   `{"data":{"id":"notes-en"}}`,
+		"DELETE /v1/betaBuildLocalizations/notes-de":  "",
+		"GET /v1/builds/other/betaBuildLocalizations": `{"data":[]}`,
+		"POST /v1/betaBuildLocalizations":             `{"data":{"id":"notes-other"}}`,
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	m, err := Fetch(context.Background(), client, "app", []string{"build"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Be nice.", *m.LicenseAgreement)
+	assert.Equal(t, &ReviewDetail{ContactEmail: "ada@example.com", DemoAccountName: "demo"}, m.ReviewDetail)
+	assert.Equal(t, map[string]AppLocalization{
+		"en-US": {Description: "A beta", FeedbackEmail: "beta@example.com"},
+		"de-DE": {Description: "Eine Beta"},
+	}, m.Localizations)
+	assert.Equal(t, map[string]map[string]string{
+		"build": {"en-US": "Try the new onboarding", "de-DE": "Neues Onboarding"},
+	}, m.WhatsNew)
+}
+
+func TestNewPlanIsEmptyWhenInSync(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	current, err := Fetch(context.Background(), client, "app", []string{"build"})
+	assert.NoError(t, err)
+
+	desired := *current
+	desired.ids = nil
+
+	assert.True(t, NewPlan(current, &desired).Empty())
+	assert.True(t, NewPlan(current, &Metadata{AppID: "app"}).Empty())
+}
+
+func TestNewPlanAndApply(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+
+	current, err := Fetch(context.Background(), client, "app", []string{"build", "other"})
+	assert.NoError(t, err)
+
+	desired := &Metadata{
+		AppID:            "app",
+		LicenseAgreement: asc.String("Be very nice."),
+		ReviewDetail:     &ReviewDetail{ContactEmail: "ada@example.com", DemoAccountPassword: "rotated"},
+		Localizations: map[string]AppLocalization{
+			"en-US": {Description: "A better beta", FeedbackEmail: "beta@example.com"},
+			"fr-FR": {Description: "Une bêta"},
+		},
+		WhatsNew: map[string]map[string]string{
+			"build": {"en-US": "Try the new settings"},
+			"other": {"en-US": "First build"},
+		},
+	}
+
+	plan := NewPlan(current, desired)
+
+	var changes []string
+	for _, change := range plan.Changes {
+		changes = append(changes, change.String())
+	}
+
+	assert.Equal(t, []string{
+		"update betaLicenseAgreement",
+		"update betaAppReviewDetail",
+		"update betaAppLocalization en-US",
+		"create betaAppLocalization fr-FR",
+		"delete betaAppLocalization de-DE",
+		"update betaBuildLocalization en-US of build build",
+		"delete betaBuildLocalization de-DE of build build",
+		"create betaBuildLocalization en-US of build other",
+	}, changes)
+
+	result, err := plan.Apply(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Len(t, result.Applied, 8)
+	assert.JSONEq(t, `{"data":{"attributes":{"demoAccountPassword":"rotated"},"id":"detail","type":"betaAppReviewDetails"}}`, api.Requests("PATCH /v1/betaAppReviewDetails/detail")[0])
+	assert.JSONEq(t, `{"data":{"attributes":{"description":"A better beta"},"id":"loc-en","type":"betaAppLocalizations"}}`, api.Requests("PATCH /v1/betaAppLocalizations/loc-en")[0])
+}
+
+func TestApplyReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	plan := &Plan{AppID: "app", Changes: []Change{
+		{Action: ActionDelete, Resource: ResourceAppLocalization, ID: "missing", Locale: "it-IT"},
+		{Action: ActionDelete, Resource: ResourceAppLocalization, ID: "loc-de", Locale: "de-DE"},
+	}}
+
+	result, err := plan.Apply(context.Background(), client)
+	assert.IsType(t, ErrApply{}, err)
+	assert.Len(t, result.Applied, 1)
+	assert.Equal(t, "it-IT", result.Failures[0].Change.Locale)
+}
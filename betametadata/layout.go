@@ -0,0 +1,301 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package betametadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// LayoutVersion is the version of the directory layout written by Write.
+	LayoutVersion = 1
+
+	// ManifestFileName is the name of the file that identifies a layout and its app.
+	ManifestFileName = "testflight.json"
+	// LicenseAgreementFileName is the name of the file holding the beta license agreement.
+	LicenseAgreementFileName = "license.txt"
+	// ReviewDetailFileName is the name of the file holding the beta app review details.
+	ReviewDetailFileName = "review.json"
+	// LocalizationsDir is the directory holding a JSON file per beta app localization.
+	LocalizationsDir = "localizations"
+	// BuildsDir is the directory holding a directory per build, with a text file per locale.
+	BuildsDir = "builds"
+)
+
+// ErrUnsupportedVersion is returned by Read for a layout written by a newer version of this package.
+type ErrUnsupportedVersion struct {
+	Version int
+}
+
+func (e ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("metadata layout version %d is not supported, the latest is %d", e.Version, LayoutVersion)
+}
+
+// ErrInvalidManifest is returned by Read when the manifest does not name an app.
+type ErrInvalidManifest struct {
+	Path string
+}
+
+func (e ErrInvalidManifest) Error() string {
+	return fmt.Sprintf("%s has no appId", e.Path)
+}
+
+// manifest is the content of ManifestFileName.
+type manifest struct {
+	Version int    `json:"version"`
+	AppID   string `json:"appId"`
+}
+
+// Read loads metadata from a directory with the following layout:
+//
+//	testflight.json                  {"version": 1, "appId": "1234567890"}
+//	license.txt                      the beta license agreement
+//	review.json                      the beta app review details, as a ReviewDetail
+//	localizations/<locale>.json      a beta app localization, as an AppLocalization
+//	builds/<build ID>/<locale>.txt   the "What to Test" notes of a build
+//
+// Only the manifest is required. A missing file or directory leaves the matching Metadata field
+// nil, so that the resources it describes are not managed. Text files end with a newline that is
+// not part of the text.
+func Read(dir string) (*Metadata, error) {
+	var mf manifest
+
+	path := filepath.Join(dir, ManifestFileName)
+	if err := readJSON(path, &mf); err != nil {
+		return nil, err
+	}
+
+	if mf.Version > LayoutVersion {
+		return nil, ErrUnsupportedVersion{Version: mf.Version}
+	}
+
+	if mf.AppID == "" {
+		return nil, ErrInvalidManifest{Path: path}
+	}
+
+	m := &Metadata{AppID: mf.AppID}
+
+	license, err := readText(filepath.Join(dir, LicenseAgreementFileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	} else if err == nil {
+		m.LicenseAgreement = &license
+	}
+
+	var detail ReviewDetail
+
+	err = readJSON(filepath.Join(dir, ReviewDetailFileName), &detail)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	} else if err == nil {
+		m.ReviewDetail = &detail
+	}
+
+	if m.Localizations, err = readLocalizations(filepath.Join(dir, LocalizationsDir)); err != nil {
+		return nil, err
+	}
+
+	if m.WhatsNew, err = readBuilds(filepath.Join(dir, BuildsDir)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func readLocalizations(dir string) (map[string]AppLocalization, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	localizations := make(map[string]AppLocalization, len(entries))
+
+	for _, entry := range entries {
+		locale, ok := localeOf(entry, ".json")
+		if !ok {
+			continue
+		}
+
+		var localization AppLocalization
+		if err := readJSON(filepath.Join(dir, entry.Name()), &localization); err != nil {
+			return nil, err
+		}
+
+		localizations[locale] = localization
+	}
+
+	return localizations, nil
+}
+
+func readBuilds(dir string) (map[string]map[string]string, error) {
+	builds, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	whatsNew := make(map[string]map[string]string, len(builds))
+
+	for _, build := range builds {
+		if !build.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(dir, build.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		notes := make(map[string]string, len(entries))
+
+		for _, entry := range entries {
+			locale, ok := localeOf(entry, ".txt")
+			if !ok {
+				continue
+			}
+
+			text, err := readText(filepath.Join(dir, build.Name(), entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			notes[locale] = text
+		}
+
+		whatsNew[build.Name()] = notes
+	}
+
+	return whatsNew, nil
+}
+
+// localeOf returns the locale named by a file with the given extension.
+func localeOf(entry fs.DirEntry, ext string) (string, bool) {
+	if entry.IsDir() || filepath.Ext(entry.Name()) != ext {
+		return "", false
+	}
+
+	return strings.TrimSuffix(entry.Name(), ext), true
+}
+
+// Write saves metadata to dir in the layout described by Read. The localizations directory and the
+// directory of each build are replaced, so that locales that no longer exist are removed. Nil fields
+// are not written, and files already in dir for them are left alone.
+func Write(dir string, m *Metadata) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := writeJSON(filepath.Join(dir, ManifestFileName), manifest{Version: LayoutVersion, AppID: m.AppID}); err != nil {
+		return err
+	}
+
+	if m.LicenseAgreement != nil {
+		if err := writeText(filepath.Join(dir, LicenseAgreementFileName), *m.LicenseAgreement); err != nil {
+			return err
+		}
+	}
+
+	if m.ReviewDetail != nil {
+		if err := writeJSON(filepath.Join(dir, ReviewDetailFileName), m.ReviewDetail); err != nil {
+			return err
+		}
+	}
+
+	if m.Localizations != nil {
+		localizations := filepath.Join(dir, LocalizationsDir)
+		if err := replaceDir(localizations); err != nil {
+			return err
+		}
+
+		for locale, localization := range m.Localizations {
+			if err := writeJSON(filepath.Join(localizations, locale+".json"), localization); err != nil {
+				return err
+			}
+		}
+	}
+
+	for buildID, notes := range m.WhatsNew {
+		build := filepath.Join(dir, BuildsDir, buildID)
+		if err := replaceDir(build); err != nil {
+			return err
+		}
+
+		for locale, text := range notes {
+			if err := writeText(filepath.Join(build, locale+".txt"), text); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func replaceDir(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	return os.MkdirAll(dir, 0o755)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func readText(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func writeText(path string, text string) error {
+	return os.WriteFile(path, []byte(text+"\n"), 0o644)
+}
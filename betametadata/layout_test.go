@@ -0,0 +1,82 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package betametadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := &Metadata{
+		AppID:            "app",
+		LicenseAgreement: asc.String("Be nice.\n\nReally."),
+		ReviewDetail:     &ReviewDetail{ContactEmail: "ada@example.com", DemoAccountRequired: asc.Bool(false)},
+		Localizations:    map[string]AppLocalization{"en-US": {Description: "A beta"}},
+		WhatsNew:         map[string]map[string]string{"build": {"en-US": "Try it"}},
+	}
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, LocalizationsDir), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, LocalizationsDir, "de-DE.json"), []byte(`{}`), 0o644))
+
+	assert.NoError(t, Write(dir, m))
+
+	text, err := os.ReadFile(filepath.Join(dir, BuildsDir, "build", "en-US.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Try it\n", string(text))
+
+	read, err := Read(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, m, read)
+}
+
+func TestReadLeavesMissingSectionsUnmanaged(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(`{"version":1,"appId":"app"}`), 0o644))
+
+	m, err := Read(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{AppID: "app"}, m)
+}
+
+func TestReadRejectsInvalidManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ManifestFileName)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version":2,"appId":"app"}`), 0o644))
+	_, err := Read(dir)
+	assert.Equal(t, ErrUnsupportedVersion{Version: 2}, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version":1}`), 0o644))
+	_, err = Read(dir)
+	assert.Equal(t, ErrInvalidManifest{Path: path}, err)
+}
@@ -0,0 +1,297 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package capabilities configures the capabilities of a bundle ID from an app's entitlements.
+
+FromEntitlements maps each entitlement key, such as aps-environment or
+com.apple.developer.associated-domains, to the capability that grants it along with its settings.
+The entitlements can be read from an .entitlements property list with ReadEntitlements, or from a
+YAML file with ReadYAML. NewPlan compares the result with the bundle ID's current capabilities and
+Apply makes the fewest calls needed to match it:
+
+	entitlements, err := capabilities.ReadEntitlements(data)
+	desired, unknown, err := capabilities.FromEntitlements(entitlements)
+	current, err := capabilities.Fetch(ctx, client, bundleID)
+	plan := capabilities.NewPlan(current, desired, capabilities.Options{})
+	result, err := plan.Apply(ctx, client, bundleID)
+
+Apple Pay and Wallet also need the merchant IDs and pass type IDs the entitlements list to be
+registered and linked to the bundle ID's capabilities. Once Apply has enabled the capabilities,
+CreateMissingIdentifiers registers the identifiers the team does not have yet and LinkIdentifiers
+links them:
+
+	identifiers := capabilities.IdentifiersFromEntitlements(entitlements)
+	created, err := capabilities.CreateMissingIdentifiers(ctx, client, identifiers)
+	linked, err := capabilities.LinkIdentifiers(ctx, client, bundleID, identifiers)
+
+App groups and iCloud containers cannot be registered or linked through the App Store Connect API,
+and still have to be assigned on the developer website.
+*/
+package capabilities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const pageLimit = 200
+
+// DefaultKeep are the capabilities App Store Connect enables on every explicit bundle ID. They are
+// never disabled unless Options.Keep says otherwise.
+var DefaultKeep = []asc.CapabilityType{asc.CapabilityTypeGameCenter, asc.CapabilityTypeInAppPurchase}
+
+// Fetch reads every capability enabled on the bundle ID.
+func Fetch(ctx context.Context, client *asc.Client, bundleID string) ([]asc.BundleIDCapability, error) {
+	var capabilities []asc.BundleIDCapability
+
+	params := &asc.ListCapabilitiesForBundleIDQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListCapabilitiesForBundleID(ctx, bundleID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		capabilities = append(capabilities, res.Data...)
+
+		if res.Links.Next == nil {
+			return capabilities, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// Options changes how a Plan is computed.
+type Options struct {
+	// Keep are capabilities that are not disabled when they are missing from the desired list.
+	// Defaults to DefaultKeep; set it to an empty, non-nil slice to disable everything else.
+	Keep []asc.CapabilityType
+}
+
+// Update is an enabled capability whose settings change.
+type Update struct {
+	ID         string
+	Capability Capability
+}
+
+// Disable is an enabled capability that is not wanted.
+type Disable struct {
+	ID   string
+	Type asc.CapabilityType
+}
+
+// Plan lists the calls needed to give a bundle ID the desired capabilities.
+type Plan struct {
+	Enable  []Capability
+	Update  []Update
+	Disable []Disable
+}
+
+// Empty reports whether the plan has nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Enable) == 0 && len(p.Update) == 0 && len(p.Disable) == 0
+}
+
+// NewPlan compares the current capabilities of a bundle ID with the desired ones. A capability is
+// only updated when one of the desired settings has different options enabled; settings the desired
+// capability does not mention are left alone.
+func NewPlan(current []asc.BundleIDCapability, desired []Capability, options Options) *Plan {
+	keep := options.Keep
+	if keep == nil {
+		keep = DefaultKeep
+	}
+
+	plan := &Plan{}
+	enabled := make(map[asc.CapabilityType]asc.BundleIDCapability, len(current))
+
+	for _, capability := range current {
+		if capability.Attributes != nil && capability.Attributes.CapabilityType != nil {
+			enabled[*capability.Attributes.CapabilityType] = capability
+		}
+	}
+
+	wanted := make(map[asc.CapabilityType]bool, len(desired))
+
+	for _, capability := range desired {
+		wanted[capability.Type] = true
+
+		existing, ok := enabled[capability.Type]
+		if !ok {
+			plan.Enable = append(plan.Enable, capability)
+
+			continue
+		}
+
+		if !settingsMatch(existing.Attributes.Settings, capability.Settings) {
+			plan.Update = append(plan.Update, Update{ID: existing.ID, Capability: capability})
+		}
+	}
+
+	for _, capability := range current {
+		if capability.Attributes == nil || capability.Attributes.CapabilityType == nil {
+			continue
+		}
+
+		capabilityType := *capability.Attributes.CapabilityType
+		if !wanted[capabilityType] && !contains(keep, capabilityType) {
+			plan.Disable = append(plan.Disable, Disable{ID: capability.ID, Type: capabilityType})
+		}
+	}
+
+	return plan
+}
+
+// settingsMatch reports whether every desired setting has the same options enabled in current.
+func settingsMatch(current, desired []asc.CapabilitySetting) bool {
+	for _, setting := range desired {
+		if setting.Key == nil {
+			continue
+		}
+
+		want := enabledOptions(setting)
+		have := map[string]bool{}
+
+		for _, other := range current {
+			if other.Key != nil && *other.Key == *setting.Key {
+				have = enabledOptions(other)
+
+				break
+			}
+		}
+
+		if len(want) != len(have) {
+			return false
+		}
+
+		for key := range want {
+			if !have[key] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func enabledOptions(setting asc.CapabilitySetting) map[string]bool {
+	options := make(map[string]bool)
+
+	for _, option := range setting.Options {
+		if option.Key != nil && option.Enabled != nil && *option.Enabled {
+			options[*option.Key] = true
+		}
+	}
+
+	return options
+}
+
+func contains(types []asc.CapabilityType, t asc.CapabilityType) bool {
+	for _, other := range types {
+		if other == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Failure records a capability that could not be changed.
+type Failure struct {
+	Type asc.CapabilityType
+	Err  error
+}
+
+// Result counts the changes made by Apply.
+type Result struct {
+	Enabled  int
+	Updated  int
+	Disabled int
+	Failures []Failure
+}
+
+// ErrApply summarises the capability changes that Apply could not make, which Result.Failures
+// details.
+type ErrApply struct {
+	Failures []Failure
+}
+
+func (e ErrApply) Error() string {
+	return fmt.Sprintf("%d capability changes failed, first: %s", len(e.Failures), e.Failures[0].Err)
+}
+
+// Apply enables, updates and disables capabilities of the bundle ID as planned. Every change is
+// tried; those App Store Connect refuses end up in Result.Failures and in the returned ErrApply.
+func (p *Plan) Apply(ctx context.Context, client *asc.Client, bundleID string) (*Result, error) {
+	result := &Result{}
+
+	for _, capability := range p.Enable {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if _, _, err := client.Provisioning.EnableCapability(ctx, capability.Type, capability.Settings, bundleID); err != nil {
+			result.Failures = append(result.Failures, Failure{Type: capability.Type, Err: err})
+
+			continue
+		}
+
+		result.Enabled++
+	}
+
+	for _, update := range p.Update {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		capabilityType := update.Capability.Type
+
+		if _, _, err := client.Provisioning.UpdateCapability(ctx, update.ID, &capabilityType, update.Capability.Settings); err != nil {
+			result.Failures = append(result.Failures, Failure{Type: capabilityType, Err: err})
+
+			continue
+		}
+
+		result.Updated++
+	}
+
+	for _, disable := range p.Disable {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if _, err := client.Provisioning.DisableCapability(ctx, disable.ID); err != nil {
+			result.Failures = append(result.Failures, Failure{Type: disable.Type, Err: err})
+
+			continue
+		}
+
+		result.Disabled++
+	}
+
+	if len(result.Failures) > 0 {
+		return result, ErrApply{Failures: result.Failures}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,117 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func testRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/bundleIds/b1/bundleIdCapabilities": `{"data":[
+			{"id":"cap1","attributes":{"capabilityType":"IN_APP_PURCHASE"}},
+			{"id":"cap2","attributes":{"capabilityType":"PUSH_NOTIFICATIONS"}},
+			{"id":"cap3","attributes":{"capabilityType":"ICLOUD","settings":[{"key":"ICLOUD_VERSION","options":[{"key":"XCODE_5","enabled":true},{"key":"XCODE_6","enabled":false}]}]}},
+			{"id":"cap4","attributes":{"capabilityType":"HEALTHKIT"}}
+		]}`,
+		"POST /v1/bundleIdCapabilities":        `{"data":{"id":"cap5"}}`,
+		"PATCH /v1/bundleIdCapabilities/cap3":  `{"data":{"id":"cap3"}}`,
+		"DELETE /v1/bundleIdCapabilities/cap4": "",
+	}
+}
+
+func testDesired() []Capability {
+	return []Capability{
+		{Type: asc.CapabilityTypeAppGroups},
+		{Type: asc.CapabilityTypeiCloud, Settings: []asc.CapabilitySetting{setting("ICLOUD_VERSION", "XCODE_6")}},
+		{Type: asc.CapabilityTypePushNotifications},
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	current, err := Fetch(context.Background(), client, "b1")
+	assert.NoError(t, err)
+	assert.Len(t, current, 4)
+
+	plan := NewPlan(current, testDesired(), Options{})
+	assert.False(t, plan.Empty())
+	assert.Equal(t, []Capability{{Type: asc.CapabilityTypeAppGroups}}, plan.Enable)
+	assert.Equal(t, []Update{{ID: "cap3", Capability: testDesired()[1]}}, plan.Update)
+	assert.Equal(t, []Disable{{ID: "cap4", Type: asc.CapabilityTypeHealthKit}}, plan.Disable)
+
+	plan = NewPlan(current, testDesired(), Options{Keep: []asc.CapabilityType{}})
+	assert.Equal(t, []Disable{
+		{ID: "cap1", Type: asc.CapabilityTypeInAppPurchase},
+		{ID: "cap4", Type: asc.CapabilityTypeHealthKit},
+	}, plan.Disable)
+}
+
+func TestNewPlanIgnoresMatchingSettings(t *testing.T) {
+	t.Parallel()
+
+	iCloud := asc.CapabilityTypeiCloud
+	current := []asc.BundleIDCapability{{ID: "cap3", Attributes: &asc.BundleIDCapabilityAttributes{
+		CapabilityType: &iCloud,
+		Settings: []asc.CapabilitySetting{
+			setting("ICLOUD_VERSION", "XCODE_6"),
+			setting("OTHER", "VALUE"),
+		},
+	}}}
+
+	plan := NewPlan(current, testDesired()[1:2], Options{})
+	assert.True(t, plan.Empty())
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+
+	current, err := Fetch(context.Background(), client, "b1")
+	assert.NoError(t, err)
+
+	result, err := NewPlan(current, testDesired(), Options{}).Apply(context.Background(), client, "b1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{Enabled: 1, Updated: 1, Disabled: 1}, result)
+	assert.JSONEq(t, `{"data":{"type":"bundleIdCapabilities","attributes":{"capabilityType":"APP_GROUPS"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}}}}}`, api.Requests("POST /v1/bundleIdCapabilities")[0])
+	assert.JSONEq(t, `{"data":{"type":"bundleIdCapabilities","id":"cap3","attributes":{"capabilityType":"ICLOUD","settings":[{"key":"ICLOUD_VERSION","options":[{"key":"XCODE_6","enabled":true}]}]}}}`, api.Requests("PATCH /v1/bundleIdCapabilities/cap3")[0])
+}
+
+func TestApplyReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, map[string]string{})
+
+	plan := &Plan{Disable: []Disable{{ID: "cap4", Type: asc.CapabilityTypeHealthKit}}}
+
+	result, err := plan.Apply(context.Background(), client, "b1")
+	assert.IsType(t, ErrApply{}, err)
+	assert.Equal(t, asc.CapabilityTypeHealthKit, result.Failures[0].Type)
+}
@@ -0,0 +1,195 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+// Setting keys and option keys used in capability settings.
+const (
+	settingICloudVersion            = "ICLOUD_VERSION"
+	settingDataProtectionLevel      = "DATA_PROTECTION_PERMISSION_LEVEL"
+	settingAppleIDAuthAppConsent    = "APPLE_ID_AUTH_APP_CONSENT"
+	optionICloudXcode6              = "XCODE_6"
+	optionAppleIDAuthPrimaryConsent = "PRIMARY_APP_CONSENT"
+)
+
+// ErrInvalidEntitlement is returned when an entitlement has a value that cannot be mapped to a
+// capability.
+type ErrInvalidEntitlement struct {
+	Key    string
+	Reason string
+}
+
+func (e ErrInvalidEntitlement) Error() string {
+	return fmt.Sprintf("entitlement %s: %s", e.Key, e.Reason)
+}
+
+// Capability is a capability a bundle ID should have, with the settings it should be configured with.
+type Capability struct {
+	Type     asc.CapabilityType
+	Settings []asc.CapabilitySetting
+}
+
+// entitlementCapabilities maps entitlement keys to the capability that grants them.
+var entitlementCapabilities = map[string]asc.CapabilityType{
+	"aps-environment":                                                          asc.CapabilityTypePushNotifications,
+	"com.apple.developer.aps-environment":                                      asc.CapabilityTypePushNotifications,
+	"com.apple.security.application-groups":                                    asc.CapabilityTypeAppGroups,
+	"com.apple.developer.associated-domains":                                   asc.CapabilityTypeAssociatedDomains,
+	"com.apple.developer.icloud-container-identifiers":                         asc.CapabilityTypeiCloud,
+	"com.apple.developer.icloud-services":                                      asc.CapabilityTypeiCloud,
+	"com.apple.developer.ubiquity-container-identifiers":                       asc.CapabilityTypeiCloud,
+	"com.apple.developer.ubiquity-kvstore-identifier":                          asc.CapabilityTypeiCloud,
+	"com.apple.developer.in-app-payments":                                      asc.CapabilityTypeApplePay,
+	"com.apple.developer.pass-type-identifiers":                                asc.CapabilityTypeWallet,
+	"com.apple.developer.healthkit":                                            asc.CapabilityTypeHealthKit,
+	"com.apple.developer.homekit":                                              asc.CapabilityTypeHomeKit,
+	"com.apple.developer.siri":                                                 asc.CapabilityTypeSiriKit,
+	"com.apple.developer.game-center":                                          asc.CapabilityTypeGameCenter,
+	"com.apple.developer.applesignin":                                          asc.CapabilityTypeAppleIDAuth,
+	"com.apple.developer.networking.wifi-info":                                 asc.CapabilityTypeAccessWifiInformation,
+	"com.apple.developer.authentication-services.autofill-credential-provider": asc.CapabilityTypeAutoFillCredentialProvider,
+	"com.apple.developer.ClassKit-environment":                                 asc.CapabilityTypeClassKit,
+	"com.apple.developer.coremedia.hls.low-latency":                            asc.CapabilityTypeCoreMediaHLSLowLatency,
+	"com.apple.developer.default-data-protection":                              asc.CapabilityTypeDataProtection,
+	"com.apple.developer.networking.HotspotConfiguration":                      asc.CapabilityTypeHotSpot,
+	"inter-app-audio":                                                          asc.CapabilityTypeInterAppAudio,
+	"com.apple.developer.networking.multipath":                                 asc.CapabilityTypeMultipath,
+	"com.apple.developer.networking.custom-protocol":                           asc.CapabilityTypeNetworkCustomProtocol,
+	"com.apple.developer.networking.networkextension":                          asc.CapabilityTypeNetworkExtensions,
+	"com.apple.developer.nfc.readersession.formats":                            asc.CapabilityTypeNFCTagReading,
+	"com.apple.developer.networking.vpn.api":                                   asc.CapabilityTypePersonalVPN,
+	"com.apple.developer.system-extension.install":                             asc.CapabilityTypeSystemExtensionInstall,
+	"com.apple.developer.user-management":                                      asc.CapabilityTypeUserManagement,
+	"com.apple.external-accessory.wireless-configuration":                      asc.CapabilityTypeWirelessAccessoryConfiguration,
+}
+
+// implicitEntitlements are granted to every app by its provisioning profile and need no capability.
+var implicitEntitlements = map[string]bool{
+	"application-identifier":                           true,
+	"com.apple.application-identifier":                 true,
+	"com.apple.developer.team-identifier":              true,
+	"get-task-allow":                                   true,
+	"keychain-access-groups":                           true,
+	"com.apple.security.app-sandbox":                   true,
+	"com.apple.security.get-task-allow":                true,
+	"beta-reports-active":                              true,
+	"com.apple.developer.icloud-container-environment": true,
+}
+
+// dataProtectionLevels maps the values of com.apple.developer.default-data-protection to the options
+// of the DATA_PROTECTION_PERMISSION_LEVEL setting.
+var dataProtectionLevels = map[string]string{
+	"NSFileProtectionComplete":                             "COMPLETE_PROTECTION",
+	"NSFileProtectionCompleteUnlessOpen":                   "PROTECTED_UNLESS_OPEN",
+	"NSFileProtectionCompleteUntilFirstUserAuthentication": "PROTECTED_UNTIL_FIRST_USER_AUTH",
+}
+
+// FromEntitlements returns the capabilities needed to sign an app with the entitlements, sorted by
+// type. Entitlements that every app has, such as application-identifier, are ignored. Entitlements
+// that no capability grants are returned in unknown, sorted, so that they can be reported.
+//
+// App Groups, iCloud containers, merchant IDs and pass type IDs listed in the entitlements are not
+// capability settings; they are identifiers assigned to the bundle ID separately.
+func FromEntitlements(entitlements map[string]interface{}) (capabilities []Capability, unknown []string, err error) {
+	byType := make(map[asc.CapabilityType]*Capability)
+
+	keys := make([]string, 0, len(entitlements))
+	for key := range entitlements {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		capabilityType, ok := entitlementCapabilities[key]
+		if !ok {
+			if !implicitEntitlements[key] {
+				unknown = append(unknown, key)
+			}
+
+			continue
+		}
+
+		if disabled(entitlements[key]) {
+			continue
+		}
+
+		capability, ok := byType[capabilityType]
+		if !ok {
+			capability = &Capability{Type: capabilityType}
+			byType[capabilityType] = capability
+		}
+
+		switch capabilityType {
+		case asc.CapabilityTypeiCloud:
+			capability.Settings = []asc.CapabilitySetting{setting(settingICloudVersion, optionICloudXcode6)}
+		case asc.CapabilityTypeAppleIDAuth:
+			capability.Settings = []asc.CapabilitySetting{setting(settingAppleIDAuthAppConsent, optionAppleIDAuthPrimaryConsent)}
+		case asc.CapabilityTypeDataProtection:
+			value, _ := entitlements[key].(string)
+
+			level, ok := dataProtectionLevels[value]
+			if !ok {
+				return nil, nil, ErrInvalidEntitlement{Key: key, Reason: fmt.Sprintf("unknown data protection level %q", value)}
+			}
+
+			capability.Settings = []asc.CapabilitySetting{setting(settingDataProtectionLevel, level)}
+		}
+	}
+
+	for _, capability := range byType {
+		capabilities = append(capabilities, *capability)
+	}
+
+	sort.Slice(capabilities, func(i, j int) bool { return capabilities[i].Type < capabilities[j].Type })
+
+	return capabilities, unknown, nil
+}
+
+// disabled reports whether an entitlement's value turns it off: false, or an empty string or array.
+func disabled(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case nil:
+		return true
+	}
+
+	return false
+}
+
+// setting returns a capability setting with a single enabled option.
+func setting(key, option string) asc.CapabilitySetting {
+	return asc.CapabilitySetting{
+		Key:     asc.String(key),
+		Options: []asc.CapabilityOption{{Key: asc.String(option), Enabled: asc.Bool(true)}},
+	}
+}
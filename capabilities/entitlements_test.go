@@ -0,0 +1,64 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+func TestFromEntitlements(t *testing.T) {
+	t.Parallel()
+
+	capabilities, unknown, err := FromEntitlements(map[string]interface{}{
+		"application-identifier":                           "TEAMID.com.example.app",
+		"aps-environment":                                  "production",
+		"com.apple.security.application-groups":            []interface{}{"group.com.example"},
+		"com.apple.developer.icloud-container-identifiers": []interface{}{"iCloud.com.example"},
+		"com.apple.developer.icloud-services":              []interface{}{"CloudKit"},
+		"com.apple.developer.default-data-protection":      "NSFileProtectionComplete",
+		"com.apple.developer.associated-domains":           []interface{}{},
+		"com.apple.developer.healthkit":                    false,
+		"com.example.custom":                               true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"com.example.custom"}, unknown)
+	assert.Equal(t, []Capability{
+		{Type: asc.CapabilityTypeAppGroups},
+		{Type: asc.CapabilityTypeDataProtection, Settings: []asc.CapabilitySetting{setting("DATA_PROTECTION_PERMISSION_LEVEL", "COMPLETE_PROTECTION")}},
+		{Type: asc.CapabilityTypeiCloud, Settings: []asc.CapabilitySetting{setting("ICLOUD_VERSION", "XCODE_6")}},
+		{Type: asc.CapabilityTypePushNotifications},
+	}, capabilities)
+}
+
+func TestFromEntitlementsRejectsInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := FromEntitlements(map[string]interface{}{
+		"com.apple.developer.default-data-protection": "NSFileProtectionNone",
+	})
+	assert.Equal(t, ErrInvalidEntitlement{
+		Key:    "com.apple.developer.default-data-protection",
+		Reason: `unknown data protection level "NSFileProtectionNone"`,
+	}, err)
+}
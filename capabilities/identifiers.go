@@ -0,0 +1,366 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const teamIdentifierPrefix = "$(TeamIdentifierPrefix)"
+
+var teamPrefix = regexp.MustCompile(`^[A-Z0-9]{10}\.`)
+
+// ErrCapabilityNotEnabled is returned by LinkIdentifiers when the bundle ID lacks the capability
+// that identifiers are linked to: Apple Pay for merchant IDs, Wallet for pass type IDs.
+type ErrCapabilityNotEnabled struct {
+	Type asc.CapabilityType
+}
+
+func (e ErrCapabilityNotEnabled) Error() string {
+	return fmt.Sprintf("capability %s is not enabled on the bundle ID", e.Type)
+}
+
+// ErrIdentifierNotRegistered is returned by LinkIdentifiers for an identifier the team has not
+// registered.
+type ErrIdentifierNotRegistered struct {
+	Identifier string
+}
+
+func (e ErrIdentifierNotRegistered) Error() string {
+	return fmt.Sprintf("identifier %s is not registered", e.Identifier)
+}
+
+// Identifiers are the identifier resources an app's entitlements refer to, sorted.
+type Identifiers struct {
+	MerchantIDs []string
+	PassTypeIDs []string
+	// AppGroups and CloudContainers cannot be registered or linked through the App Store Connect
+	// API; they are listed so that they can be registered and assigned on the developer website.
+	AppGroups       []string
+	CloudContainers []string
+}
+
+// IdentifiersFromEntitlements returns the merchant IDs, pass type IDs, app groups and iCloud
+// containers listed in the entitlements. Team ID prefixes are removed and wildcards are skipped.
+func IdentifiersFromEntitlements(entitlements map[string]interface{}) Identifiers {
+	return Identifiers{
+		MerchantIDs:     identifierList(entitlements["com.apple.developer.in-app-payments"]),
+		PassTypeIDs:     identifierList(entitlements["com.apple.developer.pass-type-identifiers"]),
+		AppGroups:       identifierList(entitlements["com.apple.security.application-groups"]),
+		CloudContainers: identifierList(entitlements["com.apple.developer.icloud-container-identifiers"]),
+	}
+}
+
+func identifierList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	identifiers := make([]string, 0, len(items))
+
+	for _, item := range items {
+		identifier, _ := item.(string)
+		identifier = strings.TrimPrefix(identifier, teamIdentifierPrefix)
+		identifier = teamPrefix.ReplaceAllString(identifier, "")
+
+		if identifier == "" || strings.Contains(identifier, "*") || strings.Contains(identifier, "$(") {
+			continue
+		}
+
+		identifiers = append(identifiers, identifier)
+	}
+
+	sort.Strings(identifiers)
+
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	return identifiers
+}
+
+// CreatedIdentifiers are the identifiers registered by CreateMissingIdentifiers.
+type CreatedIdentifiers struct {
+	MerchantIDs []asc.MerchantID
+	PassTypeIDs []asc.PassTypeID
+}
+
+// CreateMissingIdentifiers registers the merchant IDs and pass type IDs that the team does not have
+// yet, named after their identifier. It stops at the first error, returning what was created so far.
+// LinkIdentifiers then assigns them to a bundle ID.
+func CreateMissingIdentifiers(ctx context.Context, client *asc.Client, identifiers Identifiers) (*CreatedIdentifiers, error) {
+	created := &CreatedIdentifiers{}
+
+	if len(identifiers.MerchantIDs) > 0 {
+		existing, err := existingMerchantIDs(ctx, client, identifiers.MerchantIDs)
+		if err != nil {
+			return created, err
+		}
+
+		for _, identifier := range identifiers.MerchantIDs {
+			if existing[identifier] != "" {
+				continue
+			}
+
+			res, _, err := client.Provisioning.CreateMerchantID(ctx, identifier, identifier)
+			if err != nil {
+				return created, err
+			}
+
+			created.MerchantIDs = append(created.MerchantIDs, res.Data)
+		}
+	}
+
+	if len(identifiers.PassTypeIDs) > 0 {
+		existing, err := existingPassTypeIDs(ctx, client, identifiers.PassTypeIDs)
+		if err != nil {
+			return created, err
+		}
+
+		for _, identifier := range identifiers.PassTypeIDs {
+			if existing[identifier] != "" {
+				continue
+			}
+
+			res, _, err := client.Provisioning.CreatePassTypeID(ctx, identifier, identifier)
+			if err != nil {
+				return created, err
+			}
+
+			created.PassTypeIDs = append(created.PassTypeIDs, res.Data)
+		}
+	}
+
+	return created, nil
+}
+
+// LinkedIdentifiers are the identifiers linked by LinkIdentifiers.
+type LinkedIdentifiers struct {
+	MerchantIDs []string
+	PassTypeIDs []string
+}
+
+// LinkIdentifiers links the merchant IDs to the bundle ID's Apple Pay capability and the pass type
+// IDs to its Wallet capability, skipping those that are linked already. The identifiers have to be
+// registered, for example by CreateMissingIdentifiers, and the capabilities enabled, for example by
+// Apply. App groups and iCloud containers are left alone.
+func LinkIdentifiers(ctx context.Context, client *asc.Client, bundleID string, identifiers Identifiers) (*LinkedIdentifiers, error) {
+	linked := &LinkedIdentifiers{}
+
+	if len(identifiers.MerchantIDs) == 0 && len(identifiers.PassTypeIDs) == 0 {
+		return linked, nil
+	}
+
+	current, err := Fetch(ctx, client, bundleID)
+	if err != nil {
+		return linked, err
+	}
+
+	if len(identifiers.MerchantIDs) > 0 {
+		capabilityID := capabilityID(current, asc.CapabilityTypeApplePay)
+		if capabilityID == "" {
+			return linked, ErrCapabilityNotEnabled{Type: asc.CapabilityTypeApplePay}
+		}
+
+		existing, err := existingMerchantIDs(ctx, client, identifiers.MerchantIDs)
+		if err != nil {
+			return linked, err
+		}
+
+		linkedIDs, err := linkedMerchantIDs(ctx, client, capabilityID)
+		if err != nil {
+			return linked, err
+		}
+
+		ids, added, err := unlinked(identifiers.MerchantIDs, existing, linkedIDs)
+		if err != nil {
+			return linked, err
+		}
+
+		if len(ids) > 0 {
+			if _, err := client.Provisioning.AddMerchantIDsToCapability(ctx, capabilityID, ids); err != nil {
+				return linked, err
+			}
+
+			linked.MerchantIDs = added
+		}
+	}
+
+	if len(identifiers.PassTypeIDs) > 0 {
+		capabilityID := capabilityID(current, asc.CapabilityTypeWallet)
+		if capabilityID == "" {
+			return linked, ErrCapabilityNotEnabled{Type: asc.CapabilityTypeWallet}
+		}
+
+		existing, err := existingPassTypeIDs(ctx, client, identifiers.PassTypeIDs)
+		if err != nil {
+			return linked, err
+		}
+
+		linkedIDs, err := linkedPassTypeIDs(ctx, client, capabilityID)
+		if err != nil {
+			return linked, err
+		}
+
+		ids, added, err := unlinked(identifiers.PassTypeIDs, existing, linkedIDs)
+		if err != nil {
+			return linked, err
+		}
+
+		if len(ids) > 0 {
+			if _, err := client.Provisioning.AddPassTypeIDsToCapability(ctx, capabilityID, ids); err != nil {
+				return linked, err
+			}
+
+			linked.PassTypeIDs = added
+		}
+	}
+
+	return linked, nil
+}
+
+func capabilityID(capabilities []asc.BundleIDCapability, capabilityType asc.CapabilityType) string {
+	for _, capability := range capabilities {
+		if capability.Attributes != nil && capability.Attributes.CapabilityType != nil && *capability.Attributes.CapabilityType == capabilityType {
+			return capability.ID
+		}
+	}
+
+	return ""
+}
+
+// unlinked returns the resource IDs and identifiers of the identifiers that are not linked yet.
+func unlinked(identifiers []string, existing map[string]string, linked map[string]bool) ([]string, []string, error) {
+	var ids, added []string
+
+	for _, identifier := range identifiers {
+		id := existing[identifier]
+		if id == "" {
+			return nil, nil, ErrIdentifierNotRegistered{Identifier: identifier}
+		}
+
+		if linked[id] {
+			continue
+		}
+
+		ids = append(ids, id)
+		added = append(added, identifier)
+	}
+
+	return ids, added, nil
+}
+
+// existingMerchantIDs maps each of the identifiers that is registered to the ID of its merchant ID.
+func existingMerchantIDs(ctx context.Context, client *asc.Client, identifiers []string) (map[string]string, error) {
+	existing := make(map[string]string)
+	params := &asc.ListMerchantIDsQuery{FilterIdentifier: identifiers, Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListMerchantIDs(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, merchantID := range res.Data {
+			if merchantID.Attributes != nil && merchantID.Attributes.Identifier != nil {
+				existing[*merchantID.Attributes.Identifier] = merchantID.ID
+			}
+		}
+
+		if res.Links.Next == nil {
+			return existing, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// existingPassTypeIDs maps each of the identifiers that is registered to the ID of its pass type ID.
+func existingPassTypeIDs(ctx context.Context, client *asc.Client, identifiers []string) (map[string]string, error) {
+	existing := make(map[string]string)
+	params := &asc.ListPassTypeIDsQuery{FilterIdentifier: identifiers, Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListPassTypeIDs(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, passTypeID := range res.Data {
+			if passTypeID.Attributes != nil && passTypeID.Attributes.Identifier != nil {
+				existing[*passTypeID.Attributes.Identifier] = passTypeID.ID
+			}
+		}
+
+		if res.Links.Next == nil {
+			return existing, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func linkedMerchantIDs(ctx context.Context, client *asc.Client, capabilityID string) (map[string]bool, error) {
+	linked := make(map[string]bool)
+	params := &asc.ListMerchantIDsForCapabilityQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListMerchantIDsForCapability(ctx, capabilityID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, linkage := range res.Data {
+			linked[linkage.ID] = true
+		}
+
+		if res.Links.Next == nil {
+			return linked, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func linkedPassTypeIDs(ctx context.Context, client *asc.Client, capabilityID string) (map[string]bool, error) {
+	linked := make(map[string]bool)
+	params := &asc.ListPassTypeIDsForCapabilityQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListPassTypeIDsForCapability(ctx, capabilityID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, linkage := range res.Data {
+			linked[linkage.ID] = true
+		}
+
+		if res.Links.Next == nil {
+			return linked, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
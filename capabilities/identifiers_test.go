@@ -0,0 +1,112 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func TestIdentifiersFromEntitlements(t *testing.T) {
+	t.Parallel()
+
+	identifiers := IdentifiersFromEntitlements(map[string]interface{}{
+		"com.apple.developer.in-app-payments":              []interface{}{"merchant.com.example.store", "merchant.com.example.app"},
+		"com.apple.developer.pass-type-identifiers":        []interface{}{"$(TeamIdentifierPrefix)pass.com.example.ticket", "$(TeamIdentifierPrefix)*"},
+		"com.apple.security.application-groups":            []interface{}{"group.com.example"},
+		"com.apple.developer.icloud-container-identifiers": []interface{}{"iCloud.com.example"},
+	})
+	assert.Equal(t, Identifiers{
+		MerchantIDs:     []string{"merchant.com.example.app", "merchant.com.example.store"},
+		PassTypeIDs:     []string{"pass.com.example.ticket"},
+		AppGroups:       []string{"group.com.example"},
+		CloudContainers: []string{"iCloud.com.example"},
+	}, identifiers)
+
+	assert.Equal(t, []string{"pass.com.example.coupon"}, identifierList([]interface{}{"ABCDE12345.pass.com.example.coupon"}))
+}
+
+func TestCreateMissingIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, map[string]string{
+		"GET /v1/merchantIds":  `{"data":[{"id":"m1","attributes":{"identifier":"merchant.com.example.app"}}]}`,
+		"POST /v1/merchantIds": `{"data":{"id":"m2","attributes":{"identifier":"merchant.com.example.store"}}}`,
+		"GET /v1/passTypeIds":  `{"data":[]}`,
+		"POST /v1/passTypeIds": `{"data":{"id":"p1","attributes":{"identifier":"pass.com.example.ticket"}}}`,
+	})
+
+	created, err := CreateMissingIdentifiers(context.Background(), client, Identifiers{
+		MerchantIDs: []string{"merchant.com.example.app", "merchant.com.example.store"},
+		PassTypeIDs: []string{"pass.com.example.ticket"},
+		AppGroups:   []string{"group.com.example"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "m2", created.MerchantIDs[0].ID)
+	assert.Equal(t, "p1", created.PassTypeIDs[0].ID)
+	assert.Len(t, api.Requests("POST /v1/merchantIds"), 1)
+	assert.JSONEq(t, `{"data":{"type":"merchantIds","attributes":{"identifier":"merchant.com.example.store","name":"merchant.com.example.store"}}}`, api.Requests("POST /v1/merchantIds")[0])
+	assert.JSONEq(t, `{"data":{"type":"passTypeIds","attributes":{"identifier":"pass.com.example.ticket","name":"pass.com.example.ticket"}}}`, api.Requests("POST /v1/passTypeIds")[0])
+}
+
+func TestLinkIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, map[string]string{
+		"GET /v1/bundleIds/bundle/bundleIdCapabilities":                  `{"data":[{"id":"pay","attributes":{"capabilityType":"APPLE_PAY"}},{"id":"wallet","attributes":{"capabilityType":"WALLET"}}]}`,
+		"GET /v1/merchantIds":                                            `{"data":[{"id":"m1","attributes":{"identifier":"merchant.com.example.app"}},{"id":"m2","attributes":{"identifier":"merchant.com.example.store"}}]}`,
+		"GET /v1/bundleIdCapabilities/pay/relationships/merchantIds":     `{"data":[{"id":"m1","type":"merchantIds"}]}`,
+		"POST /v1/bundleIdCapabilities/pay/relationships/merchantIds":    ``,
+		"GET /v1/passTypeIds":                                            `{"data":[{"id":"p1","attributes":{"identifier":"pass.com.example.ticket"}}]}`,
+		"GET /v1/bundleIdCapabilities/wallet/relationships/passTypeIds":  `{"data":[{"id":"p1","type":"passTypeIds"}]}`,
+		"POST /v1/bundleIdCapabilities/wallet/relationships/passTypeIds": ``,
+	})
+
+	linked, err := LinkIdentifiers(context.Background(), client, "bundle", Identifiers{
+		MerchantIDs: []string{"merchant.com.example.app", "merchant.com.example.store"},
+		PassTypeIDs: []string{"pass.com.example.ticket"},
+		AppGroups:   []string{"group.com.example"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &LinkedIdentifiers{MerchantIDs: []string{"merchant.com.example.store"}}, linked)
+	assert.JSONEq(t, `{"data":[{"id":"m2","type":"merchantIds"}]}`, api.Requests("POST /v1/bundleIdCapabilities/pay/relationships/merchantIds")[0])
+	assert.Empty(t, api.Requests("POST /v1/bundleIdCapabilities/wallet/relationships/passTypeIds"))
+}
+
+func TestLinkIdentifiersNeedsCapabilityAndRegisteredIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, map[string]string{
+		"GET /v1/bundleIds/bundle/bundleIdCapabilities":              `{"data":[{"id":"pay","attributes":{"capabilityType":"APPLE_PAY"}}]}`,
+		"GET /v1/merchantIds":                                        `{"data":[]}`,
+		"GET /v1/bundleIdCapabilities/pay/relationships/merchantIds": `{"data":[]}`,
+	})
+
+	_, err := LinkIdentifiers(context.Background(), client, "bundle", Identifiers{PassTypeIDs: []string{"pass.com.example.ticket"}})
+	assert.Equal(t, ErrCapabilityNotEnabled{Type: asc.CapabilityTypeWallet}, err)
+
+	_, err = LinkIdentifiers(context.Background(), client, "bundle", Identifiers{MerchantIDs: []string{"merchant.com.example.app"}})
+	assert.Equal(t, ErrIdentifierNotRegistered{Identifier: "merchant.com.example.app"}, err)
+}
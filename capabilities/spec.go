@@ -0,0 +1,119 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tutorioapp/asc-go/plist"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidSpec is returned when a YAML spec holds something other than entitlements.
+type ErrInvalidSpec struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrInvalidSpec) Error() string {
+	return fmt.Sprintf("capability spec line %d: %s", e.Line, e.Reason)
+}
+
+// ReadEntitlements reads an .entitlements property list.
+func ReadEntitlements(data []byte) (map[string]interface{}, error) {
+	value, err := plist.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, plist.ErrInvalidPlist{Reason: "entitlements are not a dictionary"}
+	}
+
+	return entitlements, nil
+}
+
+// ReadYAML reads entitlements written as a YAML mapping instead of a property list:
+//
+//	aps-environment: production
+//	com.apple.developer.default-data-protection: NSFileProtectionComplete
+//	com.apple.security.application-groups:
+//	  - group.com.example.shared
+//	com.apple.developer.associated-domains: [applinks:example.com, webcredentials:example.com]
+//
+// Each key maps to true, false, a string, or a list of strings, which are the values the
+// entitlements of a property list can have.
+func ReadYAML(r io.Reader) (map[string]interface{}, error) {
+	entitlements := make(map[string]interface{})
+
+	var document yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&document); errors.Is(err, io.EOF) {
+		return entitlements, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, ErrInvalidSpec{Line: root.Line, Reason: "expected a mapping of entitlements"}
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		if _, ok := entitlements[key.Value]; ok {
+			return nil, ErrInvalidSpec{Line: key.Line, Reason: fmt.Sprintf("%s is listed twice", key.Value)}
+		}
+
+		var v interface{}
+		if err := value.Decode(&v); err != nil {
+			return nil, ErrInvalidSpec{Line: value.Line, Reason: err.Error()}
+		}
+
+		if !entitlementValue(v) {
+			return nil, ErrInvalidSpec{Line: value.Line, Reason: fmt.Sprintf("%s must be true, false, a string or a list of strings", key.Value)}
+		}
+
+		entitlements[key.Value] = v
+	}
+
+	return entitlements, nil
+}
+
+func entitlementValue(v interface{}) bool {
+	switch v := v.(type) {
+	case bool, string:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package capabilities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEntitlements(t *testing.T) {
+	t.Parallel()
+
+	entitlements, err := ReadEntitlements([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>aps-environment</key>
+	<string>development</string>
+	<key>com.apple.developer.associated-domains</key>
+	<array>
+		<string>applinks:example.com</string>
+	</array>
+</dict>
+</plist>`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"aps-environment":                        "development",
+		"com.apple.developer.associated-domains": []interface{}{"applinks:example.com"},
+	}, entitlements)
+
+	_, err = ReadEntitlements([]byte(`<plist><array/></plist>`))
+	assert.Error(t, err)
+}
+
+func TestReadYAML(t *testing.T) {
+	t.Parallel()
+
+	input := "# Example app\n" +
+		"---\n" +
+		"aps-environment: production\n" +
+		"com.apple.developer.healthkit: true # reads steps\n" +
+		"\"com.apple.developer.siri\": false\n" +
+		"com.apple.security.application-groups:\n" +
+		"  - group.com.example.shared\n" +
+		"  - 'group.com.example.#2'\n" +
+		"com.apple.developer.associated-domains: [applinks:example.com, \"webcredentials:example.com\"]\n" +
+		"com.apple.developer.in-app-payments: [merchant.com.example, \"merchant.com.example.a,b\"]\n" +
+		"com.apple.developer.icloud-services: []\n"
+
+	entitlements, err := ReadYAML(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"aps-environment":                        "production",
+		"com.apple.developer.healthkit":          true,
+		"com.apple.developer.siri":               false,
+		"com.apple.security.application-groups":  []interface{}{"group.com.example.shared", "group.com.example.#2"},
+		"com.apple.developer.associated-domains": []interface{}{"applinks:example.com", "webcredentials:example.com"},
+		"com.apple.developer.in-app-payments":    []interface{}{"merchant.com.example", "merchant.com.example.a,b"},
+		"com.apple.developer.icloud-services":    []interface{}{},
+	}, entitlements)
+}
+
+func TestReadYAMLRejectsOtherValues(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadYAML(strings.NewReader("- orphan\n"))
+	assert.Equal(t, ErrInvalidSpec{Line: 1, Reason: "expected a mapping of entitlements"}, err)
+
+	_, err = ReadYAML(strings.NewReader("icloud:\n  services: CloudKit\n"))
+	assert.Equal(t, ErrInvalidSpec{Line: 2, Reason: "icloud must be true, false, a string or a list of strings"}, err)
+
+	_, err = ReadYAML(strings.NewReader("aps-environment: production\naps-environment: development\n"))
+	assert.Equal(t, ErrInvalidSpec{Line: 2, Reason: "aps-environment is listed twice"}, err)
+
+	_, err = ReadYAML(strings.NewReader("count: 3\n"))
+	assert.Equal(t, ErrInvalidSpec{Line: 1, Reason: "count must be true, false, a string or a list of strings"}, err)
+
+	_, err = ReadYAML(strings.NewReader("groups: [a, [b]]\n"))
+	assert.Equal(t, ErrInvalidSpec{Line: 1, Reason: "groups must be true, false, a string or a list of strings"}, err)
+
+	_, err = ReadYAML(strings.NewReader("key: [unterminated\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,486 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package compliance answers the export compliance questions of builds, so that they do not wait in
+"Missing Compliance" until someone answers them by hand.
+
+The answers come from a JSON config read with ReadConfig, or from an app's Info.plist with
+FromInfo. A Runner waits for the build to finish processing, then either marks it as not using
+non-exempt encryption, or assigns it to the app's matching encryption declaration, creating the
+declaration and uploading its document when the app has none:
+
+	encryption, err := compliance.ReadConfig("export-compliance.json")
+	runner := compliance.New(client, compliance.Config{
+		AppID:         "1234567890",
+		Platform:      asc.PlatformIOS,
+		VersionString: "2.1.0",
+		BuildNumber:   "417",
+		Encryption:    *encryption,
+	})
+	result, err := runner.Run(ctx)
+*/
+package compliance
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const (
+	defaultPollInterval = 30 * time.Second
+	defaultTimeout      = time.Hour
+	pageLimit           = 200
+)
+
+// ErrInvalidConfig is returned when a Config is missing required values.
+type ErrInvalidConfig struct {
+	Field string
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("export compliance config: %s is required", e.Field)
+}
+
+// ErrBuildProcessing is returned when a build does not become valid before the configured timeout,
+// or when App Store Connect reports that processing has failed.
+type ErrBuildProcessing struct {
+	BuildNumber string
+	State       string
+}
+
+func (e ErrBuildProcessing) Error() string {
+	if e.State == "" {
+		return fmt.Sprintf("build %s was not found before the timeout elapsed", e.BuildNumber)
+	}
+
+	return fmt.Sprintf("build %s is in processing state %s", e.BuildNumber, e.State)
+}
+
+// ErrUnknownComplianceCode is returned when no usable declaration of the app has the configured
+// compliance code.
+type ErrUnknownComplianceCode struct {
+	Code string
+}
+
+func (e ErrUnknownComplianceCode) Error() string {
+	return fmt.Sprintf("no encryption declaration has compliance code %s", e.Code)
+}
+
+// Config describes the build to answer for.
+type Config struct {
+	// AppID is the App Store Connect ID of the app.
+	AppID string
+	// Platform is the platform of the build, and of the declarations it can be assigned to.
+	Platform asc.Platform
+	// VersionString is the build's CFBundleShortVersionString, such as "2.1.0".
+	VersionString string
+	// BuildNumber is the build's CFBundleVersion.
+	BuildNumber string
+	// Encryption is the answer.
+	Encryption Encryption
+
+	// PollInterval is how often to check the build's processing state. Defaults to 30 seconds.
+	PollInterval time.Duration
+	// Timeout is how long to wait for the build to become valid. Defaults to one hour.
+	Timeout time.Duration
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.AppID == "":
+		return ErrInvalidConfig{Field: "AppID"}
+	case c.Platform == "":
+		return ErrInvalidConfig{Field: "Platform"}
+	case c.VersionString == "":
+		return ErrInvalidConfig{Field: "VersionString"}
+	case c.BuildNumber == "":
+		return ErrInvalidConfig{Field: "BuildNumber"}
+	}
+
+	return nil
+}
+
+// Result describes what a Runner did.
+type Result struct {
+	BuildID string
+	// AlreadyAnswered is true when the build's questions had been answered, in which case nothing
+	// was changed.
+	AlreadyAnswered bool
+	// Declaration is the declaration the build was assigned to, or nil when the build does not use
+	// non-exempt encryption.
+	Declaration *asc.AppEncryptionDeclaration
+	// CreatedDeclaration is true when Declaration was created for this build.
+	CreatedDeclaration bool
+	// Document is the document uploaded for a created declaration, or for a pending one an earlier
+	// run created without it, if any.
+	Document *asc.AppEncryptionDeclarationDocument
+}
+
+// Runner answers the export compliance questions of one build.
+type Runner struct {
+	client *asc.Client
+	config Config
+
+	// Logf, if set, receives a line of progress for each stage.
+	Logf func(format string, args ...interface{})
+
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// New creates a Runner for the build described by config.
+func New(client *asc.Client, config Config) *Runner {
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Runner{
+		client: client,
+		config: config,
+		sleep:  ascutil.Sleep,
+		now:    time.Now,
+	}
+}
+
+// Run waits for the build to finish processing, then answers its export compliance questions. It
+// is safe to run again: a build that has been answered is left alone.
+func (r *Runner) Run(ctx context.Context) (*Result, error) {
+	if err := r.config.validate(); err != nil {
+		return nil, err
+	}
+
+	build, err := r.WaitForBuild(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Apply(ctx, build)
+}
+
+// WaitForBuild polls the build until App Store Connect finishes processing it. Builds only ask for
+// export compliance once they are valid.
+func (r *Runner) WaitForBuild(ctx context.Context) (*asc.Build, error) {
+	deadline := r.now().Add(r.config.Timeout)
+
+	for {
+		build, err := r.findBuild(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var state string
+
+		if build != nil && build.Attributes != nil && build.Attributes.ProcessingState != nil {
+			state = *build.Attributes.ProcessingState
+		}
+
+		switch state {
+		case asc.BuildProcessingStateValid:
+			return build, nil
+		case asc.BuildProcessingStateFailed, asc.BuildProcessingStateInvalid:
+			return nil, ErrBuildProcessing{BuildNumber: r.config.BuildNumber, State: state}
+		}
+
+		if !r.now().Before(deadline) {
+			return nil, ErrBuildProcessing{BuildNumber: r.config.BuildNumber, State: state}
+		}
+
+		r.logf("waiting for build %s (state %q)", r.config.BuildNumber, state)
+
+		if err := r.sleep(ctx, r.config.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *Runner) findBuild(ctx context.Context) (*asc.Build, error) {
+	res, _, err := r.client.Builds.ListBuilds(ctx, &asc.ListBuildsQuery{
+		FilterApp:                       []string{r.config.AppID},
+		FilterVersion:                   []string{r.config.BuildNumber},
+		FilterPreReleaseVersionVersion:  []string{r.config.VersionString},
+		FilterPreReleaseVersionPlatform: []string{string(r.config.Platform)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Data) == 0 {
+		return nil, nil
+	}
+
+	return &res.Data[0], nil
+}
+
+// Apply answers the export compliance questions of a processed build. A build that does not use
+// non-exempt encryption is updated to say so. Otherwise it is assigned to the declaration picked by
+// FindDeclaration, which is created first when the app has none that matches. If the declaration is
+// created but its document cannot be uploaded, the Result is returned with the error, and a later
+// run reuses the declaration and uploads the document again.
+func (r *Runner) Apply(ctx context.Context, build *asc.Build) (*Result, error) {
+	result := &Result{BuildID: build.ID}
+
+	if build.Attributes != nil && build.Attributes.UsesNonExemptEncryption != nil {
+		result.AlreadyAnswered = true
+
+		r.logf("build %s is already answered", r.config.BuildNumber)
+
+		return result, nil
+	}
+
+	encryption := r.config.Encryption
+
+	if !encryption.UsesNonExemptEncryption {
+		if _, _, err := r.client.Builds.UpdateBuild(ctx, build.ID, nil, asc.Bool(false), nil); err != nil {
+			return nil, err
+		}
+
+		r.logf("build %s does not use non-exempt encryption", r.config.BuildNumber)
+
+		return result, nil
+	}
+
+	declarations, err := r.listDeclarations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Declaration = FindDeclaration(declarations, encryption)
+
+	if result.Declaration == nil {
+		if encryption.ComplianceCode != "" {
+			return nil, ErrUnknownComplianceCode{Code: encryption.ComplianceCode}
+		}
+
+		if err := r.createDeclaration(ctx, result); err != nil {
+			if result.CreatedDeclaration {
+				return result, err
+			}
+
+			return nil, err
+		}
+	} else if pending(result.Declaration) && encryption.Document != "" && result.Declaration.Attributes.DocumentName == nil {
+		// The declaration was created by an earlier run that failed to upload its document.
+		if result.Document, err = UploadDocument(ctx, r.client, result.Declaration.ID, encryption.Document); err != nil {
+			return result, err
+		}
+	}
+
+	if _, err := r.client.Builds.AssignBuildsToAppEncryptionDeclaration(ctx, result.Declaration.ID, []string{build.ID}); err != nil {
+		return nil, err
+	}
+
+	r.logf("build %s assigned to encryption declaration %s", r.config.BuildNumber, result.Declaration.ID)
+
+	return result, nil
+}
+
+func (r *Runner) listDeclarations(ctx context.Context) ([]asc.AppEncryptionDeclaration, error) {
+	var declarations []asc.AppEncryptionDeclaration
+
+	params := &asc.ListAppEncryptionDeclarationsQuery{
+		FilterApp:       []string{r.config.AppID},
+		FilterPlatforms: []string{string(r.config.Platform)},
+		Limit:           pageLimit,
+	}
+
+	for {
+		res, _, err := r.client.Builds.ListAppEncryptionDeclarations(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		declarations = append(declarations, res.Data...)
+
+		if res.Links.Next == nil {
+			return declarations, nil
+		}
+
+		cursor := res.Links.Next.Cursor()
+		params.Cursor = &cursor
+	}
+}
+
+func (r *Runner) createDeclaration(ctx context.Context, result *Result) error {
+	encryption := r.config.Encryption
+
+	if encryption.AppDescription == "" {
+		return ErrInvalidConfig{Field: "Encryption.AppDescription"}
+	}
+
+	res, _, err := r.client.Builds.CreateAppEncryptionDeclaration(ctx, asc.AppEncryptionDeclarationCreateRequestAttributes{
+		AppDescription:                  encryption.AppDescription,
+		AvailableOnFrenchStore:          encryption.AvailableOnFrenchStore,
+		ContainsProprietaryCryptography: encryption.ContainsProprietaryCryptography,
+		ContainsThirdPartyCryptography:  encryption.ContainsThirdPartyCryptography,
+	}, r.config.AppID)
+	if err != nil {
+		return err
+	}
+
+	result.Declaration = &res.Data
+	result.CreatedDeclaration = true
+
+	r.logf("created encryption declaration %s", res.Data.ID)
+
+	if encryption.Document == "" {
+		return nil
+	}
+
+	result.Document, err = UploadDocument(ctx, r.client, res.Data.ID, encryption.Document)
+
+	return err
+}
+
+// UploadDocument attaches the file at path to an encryption declaration: it reserves the document,
+// uploads the file's parts and commits it with the file's checksum.
+func UploadDocument(ctx context.Context, client *asc.Client, declarationID, path string) (*asc.AppEncryptionDeclarationDocument, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, _, err := client.Builds.CreateAppEncryptionDeclarationDocument(ctx, filepath.Base(path), stat.Size(), declarationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []asc.UploadOperation
+	if reservation.Data.Attributes != nil {
+		operations = reservation.Data.Attributes.UploadOperations
+	}
+
+	if err := client.Upload(ctx, operations, file); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+
+	checksum := fmt.Sprintf("%x", hash.Sum(nil))
+
+	res, _, err := client.Builds.CommitAppEncryptionDeclarationDocument(ctx, reservation.Data.ID, asc.Bool(true), &checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Data, nil
+}
+
+// FindDeclaration picks the declaration a build with the given answers can be assigned to, or
+// returns nil. With a compliance code, it is the declaration with that code. Otherwise it is one
+// whose answers match, preferring approved declarations, then those in review, then those that have
+// not been submitted yet, such as one created by an earlier run. Rejected, expired and invalid
+// declarations are never picked.
+func FindDeclaration(declarations []asc.AppEncryptionDeclaration, encryption Encryption) *asc.AppEncryptionDeclaration {
+	var inReview, created *asc.AppEncryptionDeclaration
+
+	for i := range declarations {
+		declaration := &declarations[i]
+		attributes := declaration.Attributes
+
+		if attributes == nil {
+			continue
+		}
+
+		var state asc.AppEncryptionDeclarationState
+		if attributes.AppEncryptionDeclarationState != nil {
+			state = *attributes.AppEncryptionDeclarationState
+		}
+
+		switch state {
+		case asc.AppEncryptionDeclarationStateRejected, asc.AppEncryptionDeclarationStateExpired, asc.AppEncryptionDeclarationStateInvalid:
+			continue
+		}
+
+		if encryption.ComplianceCode != "" {
+			if attributes.CodeValue != nil && *attributes.CodeValue == encryption.ComplianceCode {
+				return declaration
+			}
+
+			continue
+		}
+
+		if ascutil.BoolValue(attributes.ContainsProprietaryCryptography) != encryption.ContainsProprietaryCryptography ||
+			ascutil.BoolValue(attributes.ContainsThirdPartyCryptography) != encryption.ContainsThirdPartyCryptography ||
+			ascutil.BoolValue(attributes.AvailableOnFrenchStore) != encryption.AvailableOnFrenchStore {
+			continue
+		}
+
+		switch {
+		case state == asc.AppEncryptionDeclarationStateApproved:
+			return declaration
+		case state == asc.AppEncryptionDeclarationStateInReview:
+			if inReview == nil {
+				inReview = declaration
+			}
+		case created == nil:
+			created = declaration
+		}
+	}
+
+	if inReview != nil {
+		return inReview
+	}
+
+	return created
+}
+
+// pending reports whether a declaration has not been submitted for review yet.
+func pending(declaration *asc.AppEncryptionDeclaration) bool {
+	if declaration.Attributes == nil {
+		return false
+	}
+
+	state := declaration.Attributes.AppEncryptionDeclarationState
+
+	return state == nil || *state == asc.AppEncryptionDeclarationStateCreated
+}
+
+func (r *Runner) logf(format string, args ...interface{}) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
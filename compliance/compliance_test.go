@@ -0,0 +1,256 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package compliance
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func newTestRunner(client *asc.Client, config Config) *Runner {
+	r := New(client, config)
+	r.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	return r
+}
+
+func testConfig(encryption Encryption) Config {
+	return Config{
+		AppID:         "app",
+		Platform:      asc.PlatformIOS,
+		VersionString: "2.1.0",
+		BuildNumber:   "417",
+		Encryption:    encryption,
+	}
+}
+
+const validBuild = `{"data":[{"id":"build","type":"builds","attributes":{"processingState":"VALID"}}]}`
+
+func TestRunMarksExemptBuild(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[]}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","type":"builds","attributes":{"processingState":"PROCESSING"}}]}`)
+	api.On("GET /v1/builds", http.StatusOK, validBuild)
+	api.On("PATCH /v1/builds/build", http.StatusOK, `{"data":{"id":"build","type":"builds"}}`)
+
+	result, err := newTestRunner(apitest.NewClient(t, api), testConfig(Encryption{})).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{BuildID: "build"}, result)
+	assert.Equal(t, 3, api.Count("GET /v1/builds"))
+	assert.JSONEq(t, `{"data":{"id":"build","type":"builds","attributes":{"usesNonExemptEncryption":false}}}`, api.Bodies("PATCH /v1/builds/build")[0])
+}
+
+func TestRunSkipsAnsweredBuild(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","attributes":{"processingState":"VALID","usesNonExemptEncryption":true}}]}`)
+
+	result, err := newTestRunner(apitest.NewClient(t, api), testConfig(Encryption{})).Run(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.AlreadyAnswered)
+	assert.Equal(t, []string{"GET /v1/builds"}, api.Requests())
+}
+
+func TestRunAssignsMatchingDeclaration(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, validBuild)
+	api.On("GET /v1/appEncryptionDeclarations", http.StatusOK, `{"data":[
+		{"id":"rejected","attributes":{"appEncryptionDeclarationState":"REJECTED","containsThirdPartyCryptography":true}},
+		{"id":"proprietary","attributes":{"appEncryptionDeclarationState":"APPROVED","containsProprietaryCryptography":true}},
+		{"id":"approved","attributes":{"appEncryptionDeclarationState":"APPROVED","containsThirdPartyCryptography":true}}
+	]}`)
+	api.On("POST /v1/appEncryptionDeclarations/approved/relationships/builds", http.StatusNoContent, "")
+
+	result, err := newTestRunner(apitest.NewClient(t, api), testConfig(Encryption{
+		UsesNonExemptEncryption:        true,
+		ContainsThirdPartyCryptography: true,
+	})).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "approved", result.Declaration.ID)
+	assert.False(t, result.CreatedDeclaration)
+	assert.JSONEq(t, `{"data":[{"id":"build","type":"builds"}]}`, api.Bodies("POST /v1/appEncryptionDeclarations/approved/relationships/builds")[0])
+	assert.Zero(t, api.Count("POST /v1/appEncryptionDeclarations"))
+}
+
+func TestRunCreatesDeclarationWithDocument(t *testing.T) {
+	t.Parallel()
+
+	document := filepath.Join(t.TempDir(), "french-declaration.pdf")
+	assert.NoError(t, os.WriteFile(document, []byte("%PDF-1.4"), 0o600))
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, validBuild)
+	api.On("GET /v1/appEncryptionDeclarations", http.StatusOK, `{"data":[]}`)
+	api.On("POST /v1/appEncryptionDeclarations", http.StatusCreated, `{"data":{"id":"new","type":"appEncryptionDeclarations"}}`)
+	api.On("POST /v1/appEncryptionDeclarationDocuments", http.StatusCreated, `{"data":{"id":"doc","type":"appEncryptionDeclarationDocuments","attributes":{
+		"uploadOperations":[{"method":"PUT","url":"https://uploads.example.com/upload","offset":0,"length":8}]
+	}}}`)
+	api.On("PUT /upload", http.StatusOK, "")
+	api.On("PATCH /v1/appEncryptionDeclarationDocuments/doc", http.StatusOK, `{"data":{"id":"doc","type":"appEncryptionDeclarationDocuments"}}`)
+	api.On("POST /v1/appEncryptionDeclarations/new/relationships/builds", http.StatusNoContent, "")
+
+	result, err := newTestRunner(apitest.NewClient(t, api), testConfig(Encryption{
+		UsesNonExemptEncryption:        true,
+		ContainsThirdPartyCryptography: true,
+		AvailableOnFrenchStore:         true,
+		AppDescription:                 "Messages are end-to-end encrypted.",
+		Document:                       document,
+	})).Run(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.CreatedDeclaration)
+	assert.Equal(t, "new", result.Declaration.ID)
+	assert.Equal(t, "doc", result.Document.ID)
+
+	assert.JSONEq(t, `{"data":{"type":"appEncryptionDeclarations",
+		"attributes":{"appDescription":"Messages are end-to-end encrypted.","availableOnFrenchStore":true,"containsProprietaryCryptography":false,"containsThirdPartyCryptography":true},
+		"relationships":{"app":{"data":{"id":"app","type":"apps"}}}}}`, api.Bodies("POST /v1/appEncryptionDeclarations")[0])
+	assert.JSONEq(t, `{"data":{"type":"appEncryptionDeclarationDocuments",
+		"attributes":{"fileName":"french-declaration.pdf","fileSize":8},
+		"relationships":{"appEncryptionDeclaration":{"data":{"id":"new","type":"appEncryptionDeclarations"}}}}}`, api.Bodies("POST /v1/appEncryptionDeclarationDocuments")[0])
+	assert.Equal(t, "%PDF-1.4", api.Bodies("PUT /upload")[0])
+	assert.JSONEq(t, fmt.Sprintf(`{"data":{"id":"doc","type":"appEncryptionDeclarationDocuments","attributes":{"uploaded":true,"sourceFileChecksum":"%x"}}}`, md5.Sum([]byte("%PDF-1.4"))),
+		api.Bodies("PATCH /v1/appEncryptionDeclarationDocuments/doc")[0])
+	assert.Equal(t, 1, api.Count("POST /v1/appEncryptionDeclarations/new/relationships/builds"))
+}
+
+func TestRunResumesDeclarationWhoseDocumentFailed(t *testing.T) {
+	t.Parallel()
+
+	document := filepath.Join(t.TempDir(), "french-declaration.pdf")
+	assert.NoError(t, os.WriteFile(document, []byte("%PDF-1.4"), 0o600))
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, validBuild)
+	api.On("GET /v1/appEncryptionDeclarations", http.StatusOK, `{"data":[]}`)
+	api.On("GET /v1/appEncryptionDeclarations", http.StatusOK, `{"data":[{"id":"new","attributes":{"appEncryptionDeclarationState":"CREATED","containsThirdPartyCryptography":true}}]}`)
+	api.On("POST /v1/appEncryptionDeclarations", http.StatusCreated, `{"data":{"id":"new","type":"appEncryptionDeclarations"}}`)
+	api.On("POST /v1/appEncryptionDeclarationDocuments", http.StatusInternalServerError, `{"errors":[{"status":"500"}]}`)
+	api.On("POST /v1/appEncryptionDeclarationDocuments", http.StatusCreated, `{"data":{"id":"doc","type":"appEncryptionDeclarationDocuments","attributes":{
+		"uploadOperations":[{"method":"PUT","url":"https://uploads.example.com/upload","offset":0,"length":8}]
+	}}}`)
+	api.On("PUT /upload", http.StatusOK, "")
+	api.On("PATCH /v1/appEncryptionDeclarationDocuments/doc", http.StatusOK, `{"data":{"id":"doc","type":"appEncryptionDeclarationDocuments"}}`)
+	api.On("POST /v1/appEncryptionDeclarations/new/relationships/builds", http.StatusNoContent, "")
+
+	client := apitest.NewClient(t, api)
+	config := testConfig(Encryption{
+		UsesNonExemptEncryption:        true,
+		ContainsThirdPartyCryptography: true,
+		AppDescription:                 "Messages are end-to-end encrypted.",
+		Document:                       document,
+	})
+
+	result, err := newTestRunner(client, config).Run(context.Background())
+	assert.Error(t, err)
+	assert.True(t, result.CreatedDeclaration)
+	assert.Equal(t, "new", result.Declaration.ID)
+	assert.Zero(t, api.Count("POST /v1/appEncryptionDeclarations/new/relationships/builds"))
+
+	result, err = newTestRunner(client, config).Run(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.CreatedDeclaration)
+	assert.Equal(t, "new", result.Declaration.ID)
+	assert.Equal(t, "doc", result.Document.ID)
+	assert.Equal(t, 1, api.Count("POST /v1/appEncryptionDeclarations"))
+	assert.Equal(t, 1, api.Count("POST /v1/appEncryptionDeclarations/new/relationships/builds"))
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, validBuild)
+	api.On("GET /v1/appEncryptionDeclarations", http.StatusOK, `{"data":[{"id":"other","attributes":{"appEncryptionDeclarationState":"APPROVED","codeValue":"abc","containsProprietaryCryptography":true}}]}`)
+
+	client := apitest.NewClient(t, api)
+
+	_, err := newTestRunner(client, testConfig(Encryption{UsesNonExemptEncryption: true, ComplianceCode: "xyz"})).Run(context.Background())
+	assert.Equal(t, ErrUnknownComplianceCode{Code: "xyz"}, err)
+
+	_, err = newTestRunner(client, testConfig(Encryption{UsesNonExemptEncryption: true})).Run(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "Encryption.AppDescription"}, err)
+
+	_, err = newTestRunner(client, Config{AppID: "app"}).Run(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "Platform"}, err)
+
+	invalid := apitest.NewQueue()
+	invalid.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","attributes":{"processingState":"INVALID"}}]}`)
+
+	_, err = newTestRunner(apitest.NewClient(t, invalid), testConfig(Encryption{})).Run(context.Background())
+	assert.Equal(t, ErrBuildProcessing{BuildNumber: "417", State: "INVALID"}, err)
+}
+
+func TestWaitForBuildTimesOut(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[]}`)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runner := newTestRunner(apitest.NewClient(t, api), testConfig(Encryption{}))
+	runner.now = func() time.Time { return now }
+	runner.sleep = func(ctx context.Context, d time.Duration) error {
+		now = now.Add(d)
+
+		return nil
+	}
+
+	_, err := runner.WaitForBuild(context.Background())
+	assert.Equal(t, ErrBuildProcessing{BuildNumber: "417"}, err)
+	assert.Equal(t, 121, api.Count("GET /v1/builds"))
+}
+
+func TestFindDeclaration(t *testing.T) {
+	t.Parallel()
+
+	state := func(s asc.AppEncryptionDeclarationState) *asc.AppEncryptionDeclarationState { return &s }
+	declarations := []asc.AppEncryptionDeclaration{
+		{ID: "review", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateInReview), CodeValue: asc.String("r")}},
+		{ID: "expired", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateExpired), CodeValue: asc.String("e")}},
+		{ID: "approved", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateApproved), CodeValue: asc.String("a")}},
+		{ID: "french", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateInReview), AvailableOnFrenchStore: asc.Bool(true)}},
+		{ID: "created", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateCreated), ContainsProprietaryCryptography: asc.Bool(true)}},
+		{ID: "rejected", Attributes: &asc.AppEncryptionDeclarationAttributes{AppEncryptionDeclarationState: state(asc.AppEncryptionDeclarationStateRejected), ContainsThirdPartyCryptography: asc.Bool(true)}},
+		{ID: "unknown"},
+	}
+
+	assert.Equal(t, "approved", FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true}).ID)
+	assert.Equal(t, "french", FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true, AvailableOnFrenchStore: true}).ID)
+	assert.Equal(t, "review", FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true, ComplianceCode: "r"}).ID)
+	assert.Nil(t, FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true, ComplianceCode: "e"}))
+	assert.Equal(t, "created", FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true, ContainsProprietaryCryptography: true}).ID)
+	assert.Nil(t, FindDeclaration(declarations, Encryption{UsesNonExemptEncryption: true, ContainsThirdPartyCryptography: true}))
+}
@@ -0,0 +1,104 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package compliance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tutorioapp/asc-go/ipa"
+)
+
+// ErrMissingEncryptionKey happens when an app's Info.plist does not say whether it uses non-exempt
+// encryption.
+var ErrMissingEncryptionKey = errors.New("Info.plist does not set ITSAppUsesNonExemptEncryption")
+
+// Encryption is an app's answer to the export compliance questions.
+type Encryption struct {
+	// UsesNonExemptEncryption is true when the app uses encryption beyond what is exempt, such as
+	// HTTPS through the operating system. Builds of apps that do not are marked as such and need no
+	// declaration.
+	UsesNonExemptEncryption bool `json:"usesNonExemptEncryption"`
+	// ComplianceCode is the code of an approved declaration, as App Store Connect shows it and as
+	// ITSEncryptionExportComplianceCode holds it. When set, builds are assigned to that declaration
+	// and none is ever created.
+	ComplianceCode string `json:"complianceCode,omitempty"`
+
+	// The remaining fields describe the declaration to find or create when ComplianceCode is empty.
+	ContainsProprietaryCryptography bool `json:"containsProprietaryCryptography,omitempty"`
+	ContainsThirdPartyCryptography  bool `json:"containsThirdPartyCryptography,omitempty"`
+	AvailableOnFrenchStore          bool `json:"availableOnFrenchStore,omitempty"`
+	// AppDescription explains how the app uses encryption. It is required to create a declaration.
+	AppDescription string `json:"appDescription,omitempty"`
+	// Document is the path of a document to attach to a created declaration, such as a French import
+	// declaration when AvailableOnFrenchStore is set. ReadConfig resolves it relative to the config.
+	Document string `json:"document,omitempty"`
+}
+
+// ReadConfig reads an Encryption from a JSON file, such as:
+//
+//	{
+//	  "usesNonExemptEncryption": true,
+//	  "containsThirdPartyCryptography": true,
+//	  "availableOnFrenchStore": true,
+//	  "appDescription": "Messages are end-to-end encrypted with libsodium.",
+//	  "document": "french-declaration.pdf"
+//	}
+func ReadConfig(path string) (*Encryption, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var encryption Encryption
+	if err := json.Unmarshal(data, &encryption); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if encryption.Document != "" && !filepath.IsAbs(encryption.Document) {
+		encryption.Document = filepath.Join(filepath.Dir(path), encryption.Document)
+	}
+
+	return &encryption, nil
+}
+
+// FromInfo reads an Encryption from the ITSAppUsesNonExemptEncryption and
+// ITSEncryptionExportComplianceCode keys of an app's Info.plist. An app that uses non-exempt
+// encryption without naming a compliance code can only be matched with a declaration that App Store
+// Connect already has, since Info.plist does not describe the encryption.
+func FromInfo(info ipa.Info) (*Encryption, error) {
+	if info.UsesNonExemptEncryption == nil {
+		return nil, ErrMissingEncryptionKey
+	}
+
+	encryption := &Encryption{UsesNonExemptEncryption: *info.UsesNonExemptEncryption}
+
+	if code, ok := info.Plist["ITSEncryptionExportComplianceCode"]; ok {
+		if encryption.ComplianceCode, ok = code.(string); !ok {
+			return nil, ipa.ErrInvalidArchive{Reason: "Info.plist has an invalid ITSEncryptionExportComplianceCode"}
+		}
+	}
+
+	return encryption, nil
+}
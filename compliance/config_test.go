@@ -0,0 +1,78 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package compliance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/ipa"
+)
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export-compliance.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{
+		"usesNonExemptEncryption": true,
+		"containsThirdPartyCryptography": true,
+		"appDescription": "TLS pinning with BoringSSL.",
+		"document": "docs/ccats.pdf"
+	}`), 0o600))
+
+	encryption, err := ReadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Encryption{
+		UsesNonExemptEncryption:        true,
+		ContainsThirdPartyCryptography: true,
+		AppDescription:                 "TLS pinning with BoringSSL.",
+		Document:                       filepath.Join(dir, "docs", "ccats.pdf"),
+	}, encryption)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"usesNonExemptEncryption": "yes"}`), 0o600))
+	_, err = ReadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestFromInfo(t *testing.T) {
+	t.Parallel()
+
+	encryption, err := FromInfo(ipa.Info{
+		UsesNonExemptEncryption: asc.Bool(true),
+		Plist:                   map[string]interface{}{"ITSEncryptionExportComplianceCode": "abc-123"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Encryption{UsesNonExemptEncryption: true, ComplianceCode: "abc-123"}, encryption)
+
+	encryption, err = FromInfo(ipa.Info{UsesNonExemptEncryption: asc.Bool(false)})
+	assert.NoError(t, err)
+	assert.Equal(t, &Encryption{}, encryption)
+
+	_, err = FromInfo(ipa.Info{})
+	assert.Equal(t, ErrMissingEncryptionKey, err)
+
+	_, err = FromInfo(ipa.Info{UsesNonExemptEncryption: asc.Bool(true), Plist: map[string]interface{}{"ITSEncryptionExportComplianceCode": 1}})
+	assert.IsType(t, ipa.ErrInvalidArchive{}, err)
+}
@@ -0,0 +1,397 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package devices registers devices in bulk from the files Apple's developer website accepts.
+
+A file is read with ReadFile, or ReadCSV for a comma-separated variant, and compared against the
+team's registered devices by NewPlan. The plan validates each UDID for its platform, skips devices
+that are already registered, and rejects devices that would go over the yearly device limit, so it
+can be reviewed before Apply registers the rest:
+
+	entries, err := devices.ReadFile(file)
+	registered, err := devices.Fetch(ctx, client)
+	plan := devices.NewPlan(registered, entries, devices.Options{MembershipStart: renewed})
+	report, err := plan.Apply(ctx, client)
+
+The limit applies to each product family separately: a team can register 100 iPhones, 100 iPads,
+100 Macs and so on per membership year. Devices registered before the current membership year
+only count when MembershipStart is not set, since the API does not know when the year started.
+*/
+package devices
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const (
+	// DefaultLimit is the number of devices of each class a team can register per membership year.
+	DefaultLimit = 100
+	// MaxNameLength is the longest device name App Store Connect accepts.
+	MaxNameLength = 50
+
+	deviceStatusEnabled = "ENABLED"
+	pageLimit           = 200
+)
+
+var (
+	// iOS devices have a 40 character hexadecimal UDID, or an 8-16 one since the A12 chip.
+	iOSUDID = regexp.MustCompile(`^([0-9a-fA-F]{40}|[0-9a-fA-F]{8}-[0-9a-fA-F]{16})$`)
+	// Macs are identified by their hardware UUID, or by an 8-16 UDID on Apple silicon.
+	macUDID = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{8}-[0-9a-fA-F]{16})$`)
+)
+
+// Class is the product family of a device, which its yearly limit applies to. The values are the
+// API's device classes.
+type Class string
+
+const (
+	// ClassIPhone is an iPhone.
+	ClassIPhone Class = "IPHONE"
+	// ClassIPad is an iPad.
+	ClassIPad Class = "IPAD"
+	// ClassIPod is an iPod touch.
+	ClassIPod Class = "IPOD"
+	// ClassAppleWatch is an Apple Watch.
+	ClassAppleWatch Class = "APPLE_WATCH"
+	// ClassAppleTV is an Apple TV.
+	ClassAppleTV Class = "APPLE_TV"
+	// ClassMac is a Mac.
+	ClassMac Class = "MAC"
+)
+
+// ValidUDID reports whether udid is well-formed for a device of the platform.
+func ValidUDID(udid string, platform asc.BundleIDPlatform) bool {
+	switch platform {
+	case asc.BundleIDPlatformiOS:
+		return iOSUDID.MatchString(udid)
+	case asc.BundleIDPlatformMacOS:
+		return macUDID.MatchString(udid)
+	}
+
+	return false
+}
+
+// Device is a device registered with the team.
+type Device struct {
+	ID       string
+	UDID     string
+	Name     string
+	Platform asc.BundleIDPlatform
+	Class    Class
+	// AddedDate is when the device was registered, or zero when App Store Connect does not say.
+	AddedDate time.Time
+	// Enabled is false for devices that were disabled. They still count towards the yearly limit
+	// until the membership is renewed.
+	Enabled bool
+}
+
+// Fetch reads every device registered with the team, enabled or not.
+func Fetch(ctx context.Context, client *asc.Client) ([]Device, error) {
+	var devices []Device
+
+	params := &asc.ListDevicesQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListDevices(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range res.Data {
+			device := Device{ID: data.ID}
+
+			if attributes := data.Attributes; attributes != nil {
+				if attributes.UDID != nil {
+					device.UDID = *attributes.UDID
+				}
+
+				if attributes.Name != nil {
+					device.Name = *attributes.Name
+				}
+
+				if attributes.Platform != nil {
+					device.Platform = *attributes.Platform
+				}
+
+				if attributes.DeviceClass != nil {
+					device.Class = Class(*attributes.DeviceClass)
+				}
+
+				if attributes.AddedDate != nil {
+					device.AddedDate = attributes.AddedDate.Time
+				}
+
+				device.Enabled = attributes.Status != nil && *attributes.Status == deviceStatusEnabled
+			}
+
+			devices = append(devices, device)
+		}
+
+		if res.Links.Next == nil {
+			return devices, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// Options changes how a Plan is computed.
+type Options struct {
+	// DefaultPlatform is used for entries without a platform. Defaults to iOS.
+	DefaultPlatform asc.BundleIDPlatform
+	// DefaultClass is the class iOS entries without a class are counted against, since a UDID does
+	// not tell an iPhone from an iPad. Defaults to ClassIPhone. Mac entries are always ClassMac.
+	DefaultClass Class
+	// MembershipStart is when the current membership year started. Devices added before it do not
+	// count towards the limit. When it is zero, every registered device counts.
+	MembershipStart time.Time
+	// Limit is the number of devices of each class that can be registered. Defaults to
+	// DefaultLimit.
+	Limit int
+}
+
+// Skip is an entry whose device is already registered.
+type Skip struct {
+	Entry  Entry
+	Device Device
+}
+
+// Rejection is an entry that cannot be registered.
+type Rejection struct {
+	Entry  Entry
+	Reason string
+}
+
+// Usage counts the devices of a class against the yearly limit.
+type Usage struct {
+	// Registered counts the devices registered in the membership year, including disabled ones.
+	Registered int
+	// Planned counts the devices the plan registers.
+	Planned int
+	Limit   int
+}
+
+// Remaining returns how many more devices can be registered once the plan is applied.
+func (u Usage) Remaining() int {
+	if remaining := u.Limit - u.Registered - u.Planned; remaining > 0 {
+		return remaining
+	}
+
+	return 0
+}
+
+// Plan lists the devices to register, and why the other entries are left out.
+type Plan struct {
+	Register []Entry
+	Skipped  []Skip
+	Rejected []Rejection
+	Usage    map[Class]Usage
+}
+
+// Empty reports whether the plan has nothing to register.
+func (p *Plan) Empty() bool {
+	return len(p.Register) == 0
+}
+
+// NewPlan compares the entries with the registered devices. UDIDs are compared ignoring case.
+// Entries are taken in order, so when the limit is reached the last entries of the file are the
+// ones rejected.
+func NewPlan(registered []Device, entries []Entry, options Options) *Plan {
+	if options.DefaultPlatform == "" {
+		options.DefaultPlatform = asc.BundleIDPlatformiOS
+	}
+
+	if options.DefaultClass == "" {
+		options.DefaultClass = ClassIPhone
+	}
+
+	if options.Limit <= 0 {
+		options.Limit = DefaultLimit
+	}
+
+	plan := &Plan{Usage: make(map[Class]Usage)}
+	existing := make(map[string]Device, len(registered))
+
+	for _, device := range registered {
+		existing[normalizeUDID(device.UDID)] = device
+
+		// A device without an added date is counted, since it may have been added this year.
+		if !device.AddedDate.IsZero() && device.AddedDate.Before(options.MembershipStart) {
+			continue
+		}
+
+		class := classOf(device.Class, device.Platform, options.DefaultClass)
+		usage := plan.usage(class, options.Limit)
+		usage.Registered++
+		plan.Usage[class] = usage
+	}
+
+	listed := make(map[string]int, len(entries))
+
+	for _, entry := range entries {
+		if entry.Platform == "" {
+			entry.Platform = options.DefaultPlatform
+		}
+
+		udid := normalizeUDID(entry.UDID)
+
+		if device, ok := existing[udid]; ok {
+			plan.Skipped = append(plan.Skipped, Skip{Entry: entry, Device: device})
+
+			continue
+		}
+
+		if reason := validate(entry); reason != "" {
+			plan.Rejected = append(plan.Rejected, Rejection{Entry: entry, Reason: reason})
+
+			continue
+		}
+
+		if line, ok := listed[udid]; ok {
+			plan.Rejected = append(plan.Rejected, Rejection{Entry: entry, Reason: fmt.Sprintf("already listed on line %d", line)})
+
+			continue
+		}
+
+		class := classOf(entry.Class, entry.Platform, options.DefaultClass)
+		usage := plan.usage(class, options.Limit)
+
+		if usage.Remaining() == 0 {
+			plan.Rejected = append(plan.Rejected, Rejection{Entry: entry, Reason: fmt.Sprintf("the limit of %d %s devices is reached", usage.Limit, class)})
+
+			continue
+		}
+
+		usage.Planned++
+		plan.Usage[class] = usage
+		listed[udid] = entry.Line
+		plan.Register = append(plan.Register, entry)
+	}
+
+	return plan
+}
+
+func (p *Plan) usage(class Class, limit int) Usage {
+	usage, ok := p.Usage[class]
+	if !ok {
+		usage.Limit = limit
+	}
+
+	return usage
+}
+
+// classOf returns the class a device is counted against.
+func classOf(class Class, platform asc.BundleIDPlatform, defaultClass Class) Class {
+	switch {
+	case class != "":
+		return class
+	case platform == asc.BundleIDPlatformMacOS:
+		return ClassMac
+	}
+
+	return defaultClass
+}
+
+// validate returns why the entry cannot be registered, or an empty string.
+func validate(entry Entry) string {
+	switch {
+	case entry.Name == "":
+		return "the device has no name"
+	case utf8.RuneCountInString(entry.Name) > MaxNameLength:
+		return fmt.Sprintf("the device name is longer than %d characters", MaxNameLength)
+	case entry.Platform != asc.BundleIDPlatformiOS && entry.Platform != asc.BundleIDPlatformMacOS:
+		return fmt.Sprintf("unknown platform %s", entry.Platform)
+	case !ValidUDID(entry.UDID, entry.Platform):
+		return fmt.Sprintf("%q is not a valid %s UDID", entry.UDID, entry.Platform)
+	case entry.Class != "" && (entry.Class == ClassMac) != (entry.Platform == asc.BundleIDPlatformMacOS):
+		return fmt.Sprintf("a %s device cannot have the %s platform", entry.Class, entry.Platform)
+	}
+
+	return ""
+}
+
+// Added is a device registered by Apply.
+type Added struct {
+	Entry  Entry
+	Device asc.Device
+}
+
+// Failure records an entry that App Store Connect refused to register.
+type Failure struct {
+	Entry Entry
+	Err   error
+}
+
+// Report lists what happened to every entry of the plan.
+type Report struct {
+	Added    []Added
+	Skipped  []Skip
+	Rejected []Rejection
+	Failures []Failure
+}
+
+// ErrApply is returned by Apply when some devices could not be registered. The failures are also
+// listed in the Report.
+type ErrApply struct {
+	Failures []Failure
+}
+
+func (e ErrApply) Error() string {
+	return fmt.Sprintf("%d devices failed to register, first: %s", len(e.Failures), e.Failures[0].Err)
+}
+
+// Apply registers each device of the plan with CreateDevice, continuing past devices App Store
+// Connect refuses. Those are listed in Report.Failures, and the error is then an ErrApply.
+func (p *Plan) Apply(ctx context.Context, client *asc.Client) (*Report, error) {
+	report := &Report{Skipped: p.Skipped, Rejected: p.Rejected}
+
+	for _, entry := range p.Register {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		res, _, err := client.Provisioning.CreateDevice(ctx, entry.Name, entry.UDID, entry.Platform)
+		if err != nil {
+			report.Failures = append(report.Failures, Failure{Entry: entry, Err: err})
+
+			continue
+		}
+
+		report.Added = append(report.Added, Added{Entry: entry, Device: res.Data})
+	}
+
+	if len(report.Failures) > 0 {
+		return report, ErrApply{Failures: report.Failures}
+	}
+
+	return report, nil
+}
+
+func normalizeUDID(udid string) string {
+	return strings.ToLower(strings.TrimSpace(udid))
+}
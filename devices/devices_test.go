@@ -0,0 +1,182 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package devices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func TestValidUDID(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ValidUDID("00008030-000000000000001E", asc.BundleIDPlatformiOS))
+	assert.True(t, ValidUDID("a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", asc.BundleIDPlatformiOS))
+	assert.False(t, ValidUDID("4C4C4544-0000-1000-8000-000000000000", asc.BundleIDPlatformiOS))
+	assert.True(t, ValidUDID("4C4C4544-0000-1000-8000-000000000000", asc.BundleIDPlatformMacOS))
+	assert.True(t, ValidUDID("00008103-000000000000001E", asc.BundleIDPlatformMacOS))
+	assert.False(t, ValidUDID("a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", asc.BundleIDPlatformMacOS))
+	assert.False(t, ValidUDID("00008030-000000000000001E", "WATCH_OS"))
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, map[string]string{
+		"GET /v1/devices": `{"data":[{"id":"d1","attributes":{"name":"iPhone","udid":"00008030-000000000000001E","platform":"IOS","deviceClass":"IPHONE","addedDate":"2024-03-01T10:00:00.000+0000","status":"ENABLED"}},{"id":"d2","attributes":{"name":"Mac","udid":"4C4C4544-0000-1000-8000-000000000000","platform":"MAC_OS","status":"DISABLED"}}]}`,
+	})
+
+	devices, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+	assert.True(t, devices[0].AddedDate.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)))
+
+	devices[0].AddedDate = time.Time{}
+	assert.Equal(t, []Device{
+		{ID: "d1", UDID: "00008030-000000000000001E", Name: "iPhone", Platform: asc.BundleIDPlatformiOS, Class: ClassIPhone, Enabled: true},
+		{ID: "d2", UDID: "4C4C4544-0000-1000-8000-000000000000", Name: "Mac", Platform: asc.BundleIDPlatformMacOS},
+	}, devices)
+}
+
+func TestNewPlan(t *testing.T) {
+	t.Parallel()
+
+	registered := []Device{
+		{ID: "d1", UDID: "00008030-000000000000001E", Platform: asc.BundleIDPlatformiOS, Enabled: true},
+		{ID: "d2", UDID: "00008030-000000000000002E", Platform: asc.BundleIDPlatformiOS},
+	}
+
+	entries := []Entry{
+		{Line: 1, UDID: "00008030-000000000000001e", Name: "Known"},
+		{Line: 2, UDID: "00008030-000000000000003E", Name: "New"},
+		{Line: 3, UDID: "00008030-000000000000003E", Name: "Twice"},
+		{Line: 4, UDID: "not-a-udid", Name: "Broken"},
+		{Line: 5, UDID: "00008030-000000000000004E", Name: ""},
+		{Line: 6, UDID: "00008030-000000000000005E", Name: "Over the limit"},
+		{Line: 7, UDID: "4C4C4544-0000-1000-8000-000000000000", Name: "Mac", Platform: asc.BundleIDPlatformMacOS},
+	}
+
+	plan := NewPlan(registered, entries, Options{Limit: 3})
+	assert.False(t, plan.Empty())
+	assert.Equal(t, []int{2, 7}, lines(plan.Register))
+	assert.Equal(t, asc.BundleIDPlatformiOS, plan.Register[0].Platform)
+	assert.Equal(t, []Skip{{Entry: Entry{Line: 1, UDID: "00008030-000000000000001e", Name: "Known", Platform: asc.BundleIDPlatformiOS}, Device: registered[0]}}, plan.Skipped)
+
+	var reasons []string
+	for _, rejection := range plan.Rejected {
+		reasons = append(reasons, fmt.Sprintf("%d: %s", rejection.Entry.Line, rejection.Reason))
+	}
+
+	assert.Equal(t, []string{
+		"3: already listed on line 2",
+		`4: "not-a-udid" is not a valid IOS UDID`,
+		"5: the device has no name",
+		"6: the limit of 3 IPHONE devices is reached",
+	}, reasons)
+	assert.Equal(t, map[Class]Usage{
+		ClassIPhone: {Registered: 2, Planned: 1, Limit: 3},
+		ClassMac:    {Planned: 1, Limit: 3},
+	}, plan.Usage)
+	assert.Equal(t, 2, plan.Usage[ClassMac].Remaining())
+}
+
+func TestNewPlanCountsClassesInTheMembershipYear(t *testing.T) {
+	t.Parallel()
+
+	renewed := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	registered := []Device{
+		{UDID: "00008030-000000000000001E", Platform: asc.BundleIDPlatformiOS, Class: ClassIPhone, AddedDate: renewed.AddDate(0, 0, -1)},
+		{UDID: "00008030-000000000000002E", Platform: asc.BundleIDPlatformiOS, Class: ClassIPhone, AddedDate: renewed},
+		{UDID: "00008030-000000000000003E", Platform: asc.BundleIDPlatformiOS, Class: ClassIPad},
+	}
+
+	entries := []Entry{
+		{Line: 1, UDID: "00008030-000000000000004E", Name: "iPhone"},
+		{Line: 2, UDID: "00008030-000000000000005E", Name: "Second iPhone"},
+		{Line: 3, UDID: "00008030-000000000000006E", Name: "iPad", Class: ClassIPad},
+		{Line: 4, UDID: "00008030-000000000000007E", Name: "Watch", Class: ClassAppleWatch},
+		{Line: 5, UDID: "00008030-000000000000008E", Name: "Not a Mac", Class: ClassMac},
+	}
+
+	plan := NewPlan(registered, entries, Options{Limit: 2, MembershipStart: renewed})
+	assert.Equal(t, []int{1, 3, 4}, lines(plan.Register))
+
+	var reasons []string
+	for _, rejection := range plan.Rejected {
+		reasons = append(reasons, fmt.Sprintf("%d: %s", rejection.Entry.Line, rejection.Reason))
+	}
+
+	assert.Equal(t, []string{
+		"2: the limit of 2 IPHONE devices is reached",
+		"5: a MAC device cannot have the IOS platform",
+	}, reasons)
+	assert.Equal(t, map[Class]Usage{
+		ClassIPhone:     {Registered: 1, Planned: 1, Limit: 2},
+		ClassIPad:       {Registered: 1, Planned: 1, Limit: 2},
+		ClassAppleWatch: {Planned: 1, Limit: 2},
+	}, plan.Usage)
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, map[string]string{
+		"POST /v1/devices": `{"data":{"id":"d3","attributes":{"name":"New"}}}`,
+	})
+
+	plan := &Plan{
+		Register: []Entry{{Line: 2, UDID: "00008030-000000000000003E", Name: "New", Platform: asc.BundleIDPlatformiOS}},
+		Rejected: []Rejection{{Entry: Entry{Line: 4}, Reason: "invalid"}},
+	}
+
+	report, err := plan.Apply(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Equal(t, "d3", report.Added[0].Device.ID)
+	assert.Equal(t, plan.Rejected, report.Rejected)
+	assert.JSONEq(t, `{"data":{"type":"devices","attributes":{"name":"New","platform":"IOS","udid":"00008030-000000000000003E"}}}`, api.Requests("POST /v1/devices")[0])
+}
+
+func TestApplyReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, map[string]string{})
+
+	plan := &Plan{Register: []Entry{{Line: 1, UDID: "00008030-000000000000003E", Name: "New", Platform: asc.BundleIDPlatformiOS}}}
+
+	report, err := plan.Apply(context.Background(), client)
+	assert.IsType(t, ErrApply{}, err)
+	assert.Len(t, report.Failures, 1)
+	assert.Empty(t, report.Added)
+}
+
+func lines(entries []Entry) []int {
+	out := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, entry.Line)
+	}
+
+	return out
+}
@@ -0,0 +1,168 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package devices
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+// ErrInvalidRow is returned when a line of a device file cannot be read.
+type ErrInvalidRow struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrInvalidRow) Error() string {
+	return fmt.Sprintf("device file line %d: %s", e.Line, e.Reason)
+}
+
+// Entry is one device listed in a file.
+type Entry struct {
+	// Line is the line of the file the device was read from.
+	Line int
+	UDID string
+	Name string
+	// Platform is empty when the file has no platform column, in which case
+	// Options.DefaultPlatform is used.
+	Platform asc.BundleIDPlatform
+	// Class is empty when the file has no class column, in which case iOS devices are counted as
+	// Options.DefaultClass and Macs as ClassMac.
+	Class Class
+}
+
+// ReadFile reads Apple's multiple device upload format: a tab-separated file with the device ID,
+// the device name and, optionally, the platform ("ios" or "mac") on each line. The header row
+// Apple's template starts with is skipped. A fourth column, which Apple's format does not have, may
+// give the device class ("iphone", "ipad", "ipod", "watch", "tv" or "mac").
+func ReadFile(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '\t'
+	reader.LazyQuotes = true
+
+	return read(reader)
+}
+
+// ReadCSV reads the same columns as ReadFile, separated by commas.
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	return read(csv.NewReader(r))
+}
+
+func read(reader *csv.Reader) ([]Entry, error) {
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var entries []Entry
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if ascutil.BlankRecord(record) || (first && isHeader(record)) {
+			continue
+		}
+
+		if len(record) < 2 {
+			return nil, ErrInvalidRow{Line: line, Reason: "expected device ID and device name"}
+		}
+
+		entry := Entry{
+			Line: line,
+			UDID: strings.TrimSpace(record[0]),
+			Name: strings.TrimSpace(record[1]),
+		}
+
+		if len(record) > 2 {
+			platform, err := parsePlatform(record[2])
+			if err != nil {
+				return nil, ErrInvalidRow{Line: line, Reason: err.Error()}
+			}
+
+			entry.Platform = platform
+		}
+
+		if len(record) > 3 {
+			class, err := parseClass(record[3])
+			if err != nil {
+				return nil, ErrInvalidRow{Line: line, Reason: err.Error()}
+			}
+
+			entry.Class = class
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parsePlatform accepts the platform names of Apple's upload file as well as the API's.
+func parsePlatform(field string) (asc.BundleIDPlatform, error) {
+	switch strings.ToLower(strings.TrimSpace(field)) {
+	case "":
+		return "", nil
+	case "ios":
+		return asc.BundleIDPlatformiOS, nil
+	case "mac", "macos", "mac_os":
+		return asc.BundleIDPlatformMacOS, nil
+	}
+
+	return "", fmt.Errorf("unknown platform %q", strings.TrimSpace(field))
+}
+
+// parseClass accepts short class names as well as the API's.
+func parseClass(field string) (Class, error) {
+	switch strings.ToLower(strings.TrimSpace(field)) {
+	case "":
+		return "", nil
+	case "iphone":
+		return ClassIPhone, nil
+	case "ipad":
+		return ClassIPad, nil
+	case "ipod":
+		return ClassIPod, nil
+	case "watch", "apple_watch":
+		return ClassAppleWatch, nil
+	case "tv", "apple_tv":
+		return ClassAppleTV, nil
+	case "mac":
+		return ClassMac, nil
+	}
+
+	return "", fmt.Errorf("unknown device class %q", strings.TrimSpace(field))
+}
+
+func isHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), "device id")
+}
@@ -0,0 +1,72 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package devices
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+
+	input := "Device ID\tDevice Name\tDevice Platform\n" +
+		"00008030-000000000000001E\tAda's iPhone\tios\n" +
+		"\n" +
+		"# Macs\n" +
+		"4C4C4544-0000-1000-8000-000000000000\tBuild Mac\tmac\n" +
+		"a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2\tOld \"iPod\"\n"
+
+	entries, err := ReadFile(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Line: 2, UDID: "00008030-000000000000001E", Name: "Ada's iPhone", Platform: asc.BundleIDPlatformiOS},
+		{Line: 5, UDID: "4C4C4544-0000-1000-8000-000000000000", Name: "Build Mac", Platform: asc.BundleIDPlatformMacOS},
+		{Line: 6, UDID: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", Name: `Old "iPod"`},
+	}, entries)
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+
+	entries, err := ReadCSV(strings.NewReader("00008030-000000000000001E,\"iPhone, Ada's\",IOS\n00008030-000000000000002E,iPad,ios,ipad\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Line: 1, UDID: "00008030-000000000000001E", Name: "iPhone, Ada's", Platform: asc.BundleIDPlatformiOS},
+		{Line: 2, UDID: "00008030-000000000000002E", Name: "iPad", Platform: asc.BundleIDPlatformiOS, Class: ClassIPad},
+	}, entries)
+}
+
+func TestReadFileRejectsInvalidRows(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadFile(strings.NewReader("00008030-000000000000001E\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: "expected device ID and device name"}, err)
+
+	_, err = ReadFile(strings.NewReader("00008030-000000000000001E\tiPhone\twatchos\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: `unknown platform "watchos"`}, err)
+
+	_, err = ReadFile(strings.NewReader("00008030-000000000000001E\tiPhone\tios\tvision\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: `unknown device class "vision"`}, err)
+}
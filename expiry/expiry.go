@@ -0,0 +1,223 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package expiry reports certificates and provisioning profiles that are about to expire, and rotates
+certificates before they do.
+
+Fetch reads an Inventory of the team's certificates and profiles, mapping each certificate to the
+profiles signed with it. Expiring lists what expires within a number of days:
+
+	inventory, err := expiry.Fetch(ctx, client)
+	report := inventory.Expiring(time.Now(), 30)
+	for _, certificate := range report.Certificates {
+		log.Printf("%s expires on %s, used by %d profiles", certificate.Name, certificate.ExpirationDate, len(certificate.Profiles))
+	}
+
+A Rotation replaces a certificate in stages: it creates a new certificate, regenerates the
+profiles that depend on the old one, and optionally revokes the old one. Each stage is described
+before it runs, so it can be confirmed:
+
+	rotation, err := inventory.PlanRotation(certificateID, expiry.RotationOptions{Revoke: true, OutputDir: "profiles"})
+	for rotation.Stage() != expiry.StageDone {
+		if !confirm(rotation.Describe()) {
+			break
+		}
+		if err := rotation.Step(ctx, client); err != nil {
+			return err
+		}
+	}
+	bundle, err := rotation.Identity.PKCS12(password)
+*/
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/profiles"
+)
+
+const (
+	pageLimit = 200
+	// certificateLimit is the most certificates the API includes per profile.
+	certificateLimit = 50
+)
+
+// ErrUnknownCertificate is returned when asked to rotate a certificate that is not in the inventory.
+type ErrUnknownCertificate struct {
+	ID string
+}
+
+func (e ErrUnknownCertificate) Error() string {
+	return fmt.Sprintf("certificate %s is not in the inventory", e.ID)
+}
+
+// Certificate is a signing certificate and the profiles that depend on it.
+type Certificate struct {
+	profiles.Certificate
+	// Profiles are the IDs of the profiles that include the certificate.
+	Profiles []string
+}
+
+// Inventory is the team's certificates and profiles. The profiles' Certificates only have an ID,
+// and their Devices are not read.
+type Inventory struct {
+	Certificates []Certificate
+	Profiles     []profiles.Profile
+}
+
+// Fetch reads every certificate and profile of the team.
+func Fetch(ctx context.Context, client *asc.Client) (*Inventory, error) {
+	inventory := &Inventory{}
+
+	certificates, err := profiles.ListCertificates(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certificate := range certificates {
+		inventory.Certificates = append(inventory.Certificates, Certificate{Certificate: certificate})
+	}
+
+	params := &asc.ListProfilesQuery{
+		Include:           []string{"certificates", "bundleId"},
+		Limit:             pageLimit,
+		LimitCertificates: certificateLimit,
+	}
+
+	for {
+		res, _, err := client.Provisioning.ListProfiles(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		identifiers := make(map[string]string)
+
+		for _, included := range res.Included {
+			if bundleID := included.BundleID(); bundleID != nil && bundleID.Attributes != nil && bundleID.Attributes.IDentifier != nil {
+				identifiers[bundleID.ID] = *bundleID.Attributes.IDentifier
+			}
+		}
+
+		for _, data := range res.Data {
+			profile := profiles.NewProfile(data)
+			profile.BundleIdentifier = identifiers[profile.BundleID]
+			inventory.Profiles = append(inventory.Profiles, profile)
+		}
+
+		if res.Links.Next == nil {
+			break
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+
+	inventory.link()
+
+	return inventory, nil
+}
+
+// link fills in the profiles that depend on each certificate.
+func (i *Inventory) link() {
+	index := make(map[string]int, len(i.Certificates))
+	for n, certificate := range i.Certificates {
+		index[certificate.ID] = n
+		i.Certificates[n].Profiles = nil
+	}
+
+	for _, profile := range i.Profiles {
+		for _, certificate := range profile.Certificates {
+			if n, ok := index[certificate.ID]; ok {
+				i.Certificates[n].Profiles = append(i.Certificates[n].Profiles, profile.ID)
+			}
+		}
+	}
+}
+
+// Certificate returns the certificate with the given ID.
+func (i *Inventory) Certificate(id string) (Certificate, bool) {
+	for _, certificate := range i.Certificates {
+		if certificate.ID == id {
+			return certificate, true
+		}
+	}
+
+	return Certificate{}, false
+}
+
+// Dependents returns the profiles that include the certificate.
+func (i *Inventory) Dependents(certificateID string) []profiles.Profile {
+	var dependents []profiles.Profile
+
+	for _, profile := range i.Profiles {
+		for _, certificate := range profile.Certificates {
+			if certificate.ID == certificateID {
+				dependents = append(dependents, profile)
+
+				break
+			}
+		}
+	}
+
+	return dependents
+}
+
+// Report lists the certificates and profiles that expire soon, the earliest first. Anything that
+// already expired is included.
+type Report struct {
+	Certificates []Certificate
+	Profiles     []profiles.Profile
+}
+
+// Empty reports whether nothing expires soon.
+func (r *Report) Empty() bool {
+	return len(r.Certificates) == 0 && len(r.Profiles) == 0
+}
+
+// Expiring returns the certificates and profiles that expire within days of now.
+func (i *Inventory) Expiring(now time.Time, days int) *Report {
+	deadline := now.AddDate(0, 0, days)
+	report := &Report{}
+
+	for _, certificate := range i.Certificates {
+		if !certificate.ExpirationDate.IsZero() && certificate.ExpirationDate.Before(deadline) {
+			report.Certificates = append(report.Certificates, certificate)
+		}
+	}
+
+	for _, profile := range i.Profiles {
+		if !profile.ExpirationDate.IsZero() && profile.ExpirationDate.Before(deadline) {
+			report.Profiles = append(report.Profiles, profile)
+		}
+	}
+
+	sort.SliceStable(report.Certificates, func(a, b int) bool {
+		return report.Certificates[a].ExpirationDate.Before(report.Certificates[b].ExpirationDate)
+	})
+	sort.SliceStable(report.Profiles, func(a, b int) bool {
+		return report.Profiles[a].ExpirationDate.Before(report.Profiles[b].ExpirationDate)
+	})
+
+	return report
+}
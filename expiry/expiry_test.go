@@ -0,0 +1,100 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+	"github.com/tutorioapp/asc-go/profiles"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/certificates": `{"data":[
+			{"id":"c1","attributes":{"displayName":"CI","serialNumber":"AAA","certificateType":"DISTRIBUTION","expirationDate":"2024-06-20T00:00:00.000+0000"}},
+			{"id":"c2","attributes":{"name":"Ada","serialNumber":"BBB","certificateType":"DEVELOPMENT","expirationDate":"2025-01-01T00:00:00.000+0000"}},
+			{"id":"c3","attributes":{"displayName":"Old","serialNumber":"CCC","certificateType":"DISTRIBUTION","expirationDate":"2024-05-01T00:00:00.000+0000"}}
+		]}`,
+		"GET /v1/profiles": `{"data":[
+			{"id":"p1","attributes":{"name":"Example App Store","profileType":"IOS_APP_STORE","expirationDate":"2024-06-20T00:00:00.000+0000"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}},"certificates":{"data":[{"type":"certificates","id":"c1"}]}}},
+			{"id":"p2","attributes":{"name":"Example Ad Hoc","profileType":"IOS_APP_ADHOC","expirationDate":"2024-06-10T00:00:00.000+0000"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}},"certificates":{"data":[{"type":"certificates","id":"c1"},{"type":"certificates","id":"c3"}]}}},
+			{"id":"p3","attributes":{"name":"Example Development","profileType":"IOS_APP_DEVELOPMENT","expirationDate":"2025-01-01T00:00:00.000+0000"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}},"certificates":{"data":[{"type":"certificates","id":"c2"}]}}}
+		],"included":[{"type":"bundleIds","id":"b1","attributes":{"identifier":"com.example.app"}}]}`,
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	inventory, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Len(t, inventory.Certificates, 3)
+	assert.Equal(t, "Ada", inventory.Certificates[1].Name)
+	assert.Equal(t, []string{"p1", "p2"}, inventory.Certificates[0].Profiles)
+	assert.Equal(t, []string{"p3"}, inventory.Certificates[1].Profiles)
+	assert.Equal(t, []string{"p2"}, inventory.Certificates[2].Profiles)
+	assert.Equal(t, "com.example.app", inventory.Profiles[0].BundleIdentifier)
+	assert.Equal(t, []profiles.Certificate{{ID: "c1"}, {ID: "c3"}}, inventory.Profiles[1].Certificates)
+	assert.Equal(t, []string{"p1", "p2"}, profileIDs(inventory.Dependents("c1")))
+}
+
+func TestExpiring(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	inventory, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+
+	report := inventory.Expiring(testNow, 30)
+	assert.False(t, report.Empty())
+	assert.Equal(t, []string{"c3", "c1"}, certificateIDs(report.Certificates))
+	assert.Equal(t, []string{"p2", "p1"}, profileIDs(report.Profiles))
+
+	report = inventory.Expiring(testNow.AddDate(-1, 0, 0), 30)
+	assert.True(t, report.Empty())
+}
+
+func certificateIDs(certificates []Certificate) []string {
+	ids := make([]string, 0, len(certificates))
+	for _, certificate := range certificates {
+		ids = append(ids, certificate.ID)
+	}
+
+	return ids
+}
+
+func profileIDs(dependents []profiles.Profile) []string {
+	ids := make([]string, 0, len(dependents))
+	for _, profile := range dependents {
+		ids = append(ids, profile.ID)
+	}
+
+	return ids
+}
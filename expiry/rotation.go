@@ -0,0 +1,232 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/profiles"
+	"github.com/tutorioapp/asc-go/signing"
+)
+
+// Stage is the next step of a Rotation.
+type Stage string
+
+const (
+	// StageCreateCertificate creates the replacement certificate.
+	StageCreateCertificate Stage = "create-certificate"
+	// StageRegenerateProfiles regenerates the profiles that depend on the old certificate with the
+	// new one.
+	StageRegenerateProfiles Stage = "regenerate-profiles"
+	// StageRevokeCertificate revokes the old certificate.
+	StageRevokeCertificate Stage = "revoke-certificate"
+	// StageDone means the rotation is complete.
+	StageDone Stage = "done"
+)
+
+// RotationOptions changes how a certificate is rotated.
+type RotationOptions struct {
+	// Identity describes the replacement certificate. Its CertificateType defaults to the old
+	// certificate's type and its CommonName to the old certificate's name.
+	Identity signing.IdentityOptions
+	// Revoke revokes the old certificate once the profiles are regenerated. Without it, the old
+	// certificate is left to expire.
+	Revoke bool
+	// OutputDir, if set, is where the regenerated profiles are written.
+	OutputDir string
+}
+
+// Rotation replaces a certificate one stage at a time. A stage that fails can be retried by
+// calling Step again; the rotation never moves past a stage that did not complete.
+type Rotation struct {
+	Old Certificate
+	// Profiles are the profiles that depend on the old certificate.
+	Profiles []profiles.Profile
+	// Identity is the replacement certificate and its private key, once created. The private key
+	// only exists in memory and must be exported before the Rotation is discarded.
+	Identity *signing.Identity
+	// Regenerated are the profiles regenerated so far.
+	Regenerated []profiles.Regenerated
+
+	options RotationOptions
+	stage   Stage
+	pending []profiles.Profile
+	// devices are the devices of each profile, listed before the profile is first deleted.
+	devices map[string][]profiles.Device
+}
+
+// PlanRotation prepares the rotation of a certificate. Nothing changes until Step is called.
+func (i *Inventory) PlanRotation(certificateID string, options RotationOptions) (*Rotation, error) {
+	old, ok := i.Certificate(certificateID)
+	if !ok {
+		return nil, ErrUnknownCertificate{ID: certificateID}
+	}
+
+	if options.Identity.CertificateType == "" {
+		options.Identity.CertificateType = old.Type
+	}
+
+	if options.Identity.CommonName == "" {
+		options.Identity.CommonName = old.Name
+	}
+
+	dependents := i.Dependents(certificateID)
+
+	return &Rotation{
+		Old:      old,
+		Profiles: dependents,
+		options:  options,
+		stage:    StageCreateCertificate,
+		pending:  dependents,
+	}, nil
+}
+
+// Stage returns the stage the next call to Step runs.
+func (r *Rotation) Stage() Stage {
+	return r.stage
+}
+
+// Describe explains what the next call to Step does, so that it can be confirmed.
+func (r *Rotation) Describe() string {
+	switch r.stage {
+	case StageCreateCertificate:
+		return fmt.Sprintf("create a %s certificate to replace %s (serial %s, expires %s)",
+			r.options.Identity.CertificateType, r.Old.Name, r.Old.SerialNumber, r.Old.ExpirationDate.Format("2006-01-02"))
+	case StageRegenerateProfiles:
+		names := make([]string, 0, len(r.pending))
+		for _, profile := range r.pending {
+			names = append(names, profile.Name)
+		}
+
+		return fmt.Sprintf("regenerate %d profiles with the new certificate: %s", len(r.pending), strings.Join(names, ", "))
+	case StageRevokeCertificate:
+		return fmt.Sprintf("revoke certificate %s (serial %s)", r.Old.Name, r.Old.SerialNumber)
+	default:
+		return "the rotation is complete"
+	}
+}
+
+// Step runs the current stage and moves to the next one if it succeeds.
+func (r *Rotation) Step(ctx context.Context, client *asc.Client) error {
+	switch r.stage {
+	case StageCreateCertificate:
+		identity, err := signing.CreateIdentity(ctx, client, r.options.Identity)
+		if err != nil {
+			return err
+		}
+
+		r.Identity = identity
+		r.stage = StageRegenerateProfiles
+
+		if len(r.pending) == 0 {
+			r.stage = r.afterProfiles()
+		}
+	case StageRegenerateProfiles:
+		if err := r.regenerate(ctx, client); err != nil {
+			return err
+		}
+
+		r.stage = r.afterProfiles()
+	case StageRevokeCertificate:
+		if _, err := client.Provisioning.RevokeCertificate(ctx, r.Old.ID); err != nil {
+			return err
+		}
+
+		r.stage = StageDone
+	}
+
+	return nil
+}
+
+func (r *Rotation) afterProfiles() Stage {
+	if r.options.Revoke {
+		return StageRevokeCertificate
+	}
+
+	return StageDone
+}
+
+// regenerate recreates the pending profiles with the new certificate in place of the old one,
+// keeping their devices. Profiles that are not regenerated stay pending. The devices are listed
+// once, before any profile is deleted, since a deleted profile's devices can no longer be listed.
+func (r *Rotation) regenerate(ctx context.Context, client *asc.Client) error {
+	if r.devices == nil {
+		r.devices = make(map[string][]profiles.Device, len(r.pending))
+	}
+
+	for _, profile := range r.pending {
+		if _, ok := r.devices[profile.ID]; ok {
+			continue
+		}
+
+		devices, err := profiles.ListDevicesInProfile(ctx, client, profile.ID)
+		if err != nil {
+			return err
+		}
+
+		r.devices[profile.ID] = devices
+	}
+
+	plan := &profiles.Plan{}
+
+	for _, profile := range r.pending {
+		certificates := []profiles.Certificate{{ID: r.Identity.Resource.ID}}
+
+		for _, certificate := range profile.Certificates {
+			if certificate.ID != r.Old.ID {
+				certificates = append(certificates, certificate)
+			}
+		}
+
+		plan.Changes = append(plan.Changes, profiles.Change{
+			Profile:      profile,
+			Devices:      r.devices[profile.ID],
+			Certificates: certificates,
+		})
+	}
+
+	result, err := plan.Apply(ctx, client, r.options.OutputDir)
+	if result == nil {
+		return err
+	}
+
+	r.Regenerated = append(r.Regenerated, result.Regenerated...)
+
+	done := make(map[string]bool, len(result.Regenerated))
+	for _, regenerated := range result.Regenerated {
+		done[regenerated.Old.ID] = true
+	}
+
+	var pending []profiles.Profile
+
+	for _, profile := range r.pending {
+		if !done[profile.ID] {
+			pending = append(pending, profile)
+		}
+	}
+
+	r.pending = pending
+
+	return err
+}
@@ -0,0 +1,203 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package expiry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+	"github.com/tutorioapp/asc-go/signing"
+)
+
+// rotationRoutes extends testRoutes with the requests a rotation of c1 makes.
+func rotationRoutes(t *testing.T) map[string]string {
+	t.Helper()
+
+	key, err := signing.GenerateKey(signing.KeyAlgorithmECDSA, 0)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "iPhone Distribution: Team"},
+		NotBefore:    testNow,
+		NotAfter:     testNow.AddDate(1, 0, 0),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+
+	routes := testRoutes()
+	routes["POST /v1/certificates"] = fmt.Sprintf(`{"data":{"id":"c4","attributes":{"certificateContent":%q}}}`, base64.StdEncoding.EncodeToString(der))
+	routes["GET /v1/profiles/p1/devices"] = `{"data":[]}`
+	routes["GET /v1/profiles/p2/devices"] = `{"data":[{"id":"d1"},{"id":"d2"}]}`
+	routes["DELETE /v1/profiles/p1"] = ""
+	routes["DELETE /v1/profiles/p2"] = ""
+	routes["POST /v1/profiles"] = `{"data":{"id":"p9","attributes":{"profileContent":"cHJvZmlsZQ=="}}}`
+	routes["DELETE /v1/certificates/c1"] = ""
+
+	return routes
+}
+
+func TestRotation(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, rotationRoutes(t))
+
+	inventory, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+
+	rotation, err := inventory.PlanRotation("c1", RotationOptions{Revoke: true, OutputDir: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, profileIDs(rotation.Profiles))
+
+	assert.Equal(t, StageCreateCertificate, rotation.Stage())
+	assert.Equal(t, "create a DISTRIBUTION certificate to replace CI (serial AAA, expires 2024-06-20)", rotation.Describe())
+	assert.NoError(t, rotation.Step(context.Background(), client))
+	assert.Equal(t, "c4", rotation.Identity.Resource.ID)
+
+	var certificate struct {
+		Data struct {
+			Attributes struct {
+				CertificateType string `json:"certificateType"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(api.Requests("POST /v1/certificates")[0]), &certificate))
+	assert.Equal(t, "DISTRIBUTION", certificate.Data.Attributes.CertificateType)
+
+	assert.Equal(t, StageRegenerateProfiles, rotation.Stage())
+	assert.Equal(t, "regenerate 2 profiles with the new certificate: Example App Store, Example Ad Hoc", rotation.Describe())
+	assert.NoError(t, rotation.Step(context.Background(), client))
+	assert.Len(t, rotation.Regenerated, 2)
+	assert.NotEmpty(t, rotation.Regenerated[1].Path)
+
+	relationships := make([]map[string]json.RawMessage, 0, 2)
+
+	for _, body := range api.Requests("POST /v1/profiles") {
+		var profile struct {
+			Data struct {
+				Relationships map[string]json.RawMessage `json:"relationships"`
+			} `json:"data"`
+		}
+
+		assert.NoError(t, json.Unmarshal([]byte(body), &profile))
+		relationships = append(relationships, profile.Data.Relationships)
+	}
+
+	assert.JSONEq(t, `{"data":[{"type":"certificates","id":"c4"}]}`, string(relationships[0]["certificates"]))
+	assert.Nil(t, relationships[0]["devices"])
+	assert.JSONEq(t, `{"data":[{"type":"certificates","id":"c4"},{"type":"certificates","id":"c3"}]}`, string(relationships[1]["certificates"]))
+	assert.JSONEq(t, `{"data":[{"type":"devices","id":"d1"},{"type":"devices","id":"d2"}]}`, string(relationships[1]["devices"]))
+
+	assert.Equal(t, StageRevokeCertificate, rotation.Stage())
+	assert.Equal(t, "revoke certificate CI (serial AAA)", rotation.Describe())
+	assert.Empty(t, api.Requests("DELETE /v1/certificates/c1"))
+	assert.NoError(t, rotation.Step(context.Background(), client))
+	assert.Len(t, api.Requests("DELETE /v1/certificates/c1"), 1)
+
+	assert.Equal(t, StageDone, rotation.Stage())
+	assert.NoError(t, rotation.Step(context.Background(), client))
+}
+
+func TestRotationRetriesFailedProfiles(t *testing.T) {
+	t.Parallel()
+
+	routes := rotationRoutes(t)
+	delete(routes, "GET /v1/profiles/p2/devices")
+	client, api := apitest.NewServer(t, routes)
+
+	inventory, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+
+	rotation, err := inventory.PlanRotation("c1", RotationOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, rotation.Step(context.Background(), client))
+
+	assert.Error(t, rotation.Step(context.Background(), client))
+	assert.Equal(t, StageRegenerateProfiles, rotation.Stage())
+	assert.Empty(t, api.Requests("POST /v1/profiles"))
+
+	api.SetRoute("GET /v1/profiles/p2/devices", `{"data":[]}`)
+
+	assert.NoError(t, rotation.Step(context.Background(), client))
+	assert.Len(t, rotation.Regenerated, 2)
+	assert.Equal(t, StageDone, rotation.Stage())
+}
+
+func TestRotationRetriesProfilesThatWereDeleted(t *testing.T) {
+	t.Parallel()
+
+	routes := rotationRoutes(t)
+	created := routes["POST /v1/profiles"]
+	delete(routes, "POST /v1/profiles")
+	client, api := apitest.NewServer(t, routes)
+
+	inventory, err := Fetch(context.Background(), client)
+	assert.NoError(t, err)
+
+	rotation, err := inventory.PlanRotation("c1", RotationOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, rotation.Step(context.Background(), client))
+
+	assert.Error(t, rotation.Step(context.Background(), client))
+	assert.Equal(t, StageRegenerateProfiles, rotation.Stage())
+	assert.Len(t, api.Requests("DELETE /v1/profiles/p2"), 1)
+
+	api.RemoveRoute("GET /v1/profiles/p1/devices")
+	api.RemoveRoute("GET /v1/profiles/p2/devices")
+	api.SetRoute("POST /v1/profiles", created)
+
+	assert.NoError(t, rotation.Step(context.Background(), client))
+	assert.Len(t, rotation.Regenerated, 2)
+	assert.Equal(t, StageDone, rotation.Stage())
+	assert.Len(t, api.Requests("GET /v1/profiles/p2/devices"), 1)
+
+	var profile struct {
+		Data struct {
+			Relationships map[string]json.RawMessage `json:"relationships"`
+		} `json:"data"`
+	}
+
+	requests := api.Requests("POST /v1/profiles")
+	assert.NoError(t, json.Unmarshal([]byte(requests[len(requests)-1]), &profile))
+	assert.JSONEq(t, `{"data":[{"type":"devices","id":"d1"},{"type":"devices","id":"d2"}]}`, string(profile.Data.Relationships["devices"]))
+}
+
+func TestPlanRotationRejectsUnknownCertificates(t *testing.T) {
+	t.Parallel()
+
+	inventory := &Inventory{}
+
+	_, err := inventory.PlanRotation("c1", RotationOptions{})
+	assert.Equal(t, ErrUnknownCertificate{ID: "c1"}, err)
+}
@@ -0,0 +1,448 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package feedback downloads TestFlight feedback to disk so it can be forwarded to a bug tracker.
+
+Each submission is written to its own directory, named after its ID, under "screenshots" or
+"crashes". The directory holds the screenshots or the crash log, and a feedback.json sidecar with
+the submission's metadata. The sidecar is written last, so a directory that has one is complete and
+is skipped on later runs. A submission that cannot be downloaded, for example because a screenshot
+URL has expired, does not stop the others: its error is gathered into an ErrDownload, and the next
+run tries it again:
+
+	downloader := feedback.NewDownloader(client, "feedback")
+	items, err := downloader.DownloadCrashes(ctx, "1234567890", &asc.ListBetaFeedbackSubmissionsForAppQuery{
+		FilterBuild: []string{buildID},
+	})
+	for _, item := range items {
+		if !item.Skipped {
+			fileIssue(item.Metadata, item.Files)
+		}
+	}
+*/
+package feedback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const (
+	// MetadataFileName is the name of the sidecar written next to each submission's files.
+	MetadataFileName = "feedback.json"
+	// CrashLogFileName is the name of the file a crash log is written to.
+	CrashLogFileName = "crash.log"
+
+	pageLimit = 200
+)
+
+// ErrInvalidSubmissionID is returned for a submission whose ID cannot be used as a directory name,
+// such as one containing a path separator.
+type ErrInvalidSubmissionID struct {
+	ID string
+}
+
+func (e ErrInvalidSubmissionID) Error() string {
+	return fmt.Sprintf("feedback submission ID %q is not a valid directory name", e.ID)
+}
+
+// ErrDownload is returned when some submissions could not be downloaded. The items of the others
+// are returned along with it.
+type ErrDownload struct {
+	// Failures maps the ID of each submission that failed to its error.
+	Failures map[string]error
+}
+
+func (e ErrDownload) Error() string {
+	return fmt.Sprintf("%d feedback submissions could not be downloaded", len(e.Failures))
+}
+
+// Kind is the kind of feedback a tester sent.
+type Kind string
+
+const (
+	// KindScreenshot is feedback sent with one or more screenshots.
+	KindScreenshot Kind = "screenshot"
+	// KindCrash is feedback sent after a crash, with a crash log.
+	KindCrash Kind = "crash"
+)
+
+// Metadata is the content of the sidecar written for each submission.
+type Metadata struct {
+	ID           string                               `json:"id"`
+	Kind         Kind                                 `json:"kind"`
+	AppID        string                               `json:"appId"`
+	BuildID      string                               `json:"buildId,omitempty"`
+	TesterID     string                               `json:"testerId,omitempty"`
+	Attributes   asc.BetaFeedbackSubmissionAttributes `json:"attributes"`
+	Files        []string                             `json:"files,omitempty"`
+	DownloadedAt time.Time                            `json:"downloadedAt"`
+}
+
+// Item is a submission handled by a Downloader.
+type Item struct {
+	Metadata Metadata
+	// Dir is the directory the submission was written to.
+	Dir string
+	// Files are the absolute paths of the screenshots or crash log.
+	Files []string
+	// Skipped is true when the submission had already been downloaded by an earlier run, in
+	// which case Metadata is read back from its sidecar.
+	Skipped bool
+}
+
+// Downloader saves feedback submissions under a directory.
+type Downloader struct {
+	client *asc.Client
+	dir    string
+
+	// Logf, if set, receives a line for each submission.
+	Logf func(format string, args ...interface{})
+
+	now func() time.Time
+}
+
+// NewDownloader creates a Downloader that writes under dir.
+func NewDownloader(client *asc.Client, dir string) *Downloader {
+	return &Downloader{
+		client: client,
+		dir:    dir,
+		now:    time.Now,
+	}
+}
+
+// DownloadScreenshots saves every screenshot submission of the app matching the query, following
+// every page of results. The query's Cursor is ignored. Submissions that fail are reported in an
+// ErrDownload once the others are saved.
+func (d *Downloader) DownloadScreenshots(ctx context.Context, appID string, query *asc.ListBetaFeedbackSubmissionsForAppQuery) ([]Item, error) {
+	params := copyQuery(query)
+
+	var items []Item
+
+	failures := make(map[string]error)
+
+	for {
+		res, _, err := d.client.TestFlight.ListBetaFeedbackScreenshotSubmissionsForApp(ctx, appID, params)
+		if err != nil {
+			return items, err
+		}
+
+		for _, submission := range res.Data {
+			item, err := d.saveScreenshotSubmission(ctx, appID, submission)
+			if err != nil {
+				if ctx.Err() != nil {
+					return items, ctx.Err()
+				}
+
+				d.logf("%s %s: %v", KindScreenshot, submission.ID, err)
+				failures[submission.ID] = err
+
+				continue
+			}
+
+			items = append(items, *item)
+		}
+
+		if res.Links.Next == nil {
+			return items, downloadError(failures)
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// DownloadCrashes saves every crash submission of the app matching the query, along with its
+// crash log, following every page of results. The query's Cursor is ignored. Submissions that fail
+// are reported in an ErrDownload once the others are saved.
+func (d *Downloader) DownloadCrashes(ctx context.Context, appID string, query *asc.ListBetaFeedbackSubmissionsForAppQuery) ([]Item, error) {
+	params := copyQuery(query)
+
+	var items []Item
+
+	failures := make(map[string]error)
+
+	for {
+		res, _, err := d.client.TestFlight.ListBetaFeedbackCrashSubmissionsForApp(ctx, appID, params)
+		if err != nil {
+			return items, err
+		}
+
+		for _, submission := range res.Data {
+			item, err := d.saveCrashSubmission(ctx, appID, submission)
+			if err != nil {
+				if ctx.Err() != nil {
+					return items, ctx.Err()
+				}
+
+				d.logf("%s %s: %v", KindCrash, submission.ID, err)
+				failures[submission.ID] = err
+
+				continue
+			}
+
+			items = append(items, *item)
+		}
+
+		if res.Links.Next == nil {
+			return items, downloadError(failures)
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func (d *Downloader) saveScreenshotSubmission(ctx context.Context, appID string, submission asc.BetaFeedbackScreenshotSubmission) (*Item, error) {
+	dir, err := d.submissionDir("screenshots", submission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item, err := d.existing(dir); item != nil || err != nil {
+		return item, err
+	}
+
+	metadata := Metadata{ID: submission.ID, Kind: KindScreenshot, AppID: appID}
+	metadata.BuildID, metadata.TesterID = relatedIDs(submission.Relationships)
+
+	var screenshots []asc.BetaFeedbackScreenshotImage
+
+	if submission.Attributes != nil {
+		metadata.Attributes = submission.Attributes.BetaFeedbackSubmissionAttributes
+		screenshots = submission.Attributes.Screenshots
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	for i, screenshot := range screenshots {
+		name := fmt.Sprintf("screenshot-%d%s", i+1, screenshotExtension(screenshot))
+
+		err := writeFile(filepath.Join(dir, name), func(w io.Writer) error {
+			_, err := d.client.TestFlight.DownloadBetaFeedbackScreenshot(ctx, screenshot, w)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		metadata.Files = append(metadata.Files, name)
+	}
+
+	return d.finish(dir, metadata)
+}
+
+func (d *Downloader) saveCrashSubmission(ctx context.Context, appID string, submission asc.BetaFeedbackCrashSubmission) (*Item, error) {
+	dir, err := d.submissionDir("crashes", submission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item, err := d.existing(dir); item != nil || err != nil {
+		return item, err
+	}
+
+	metadata := Metadata{ID: submission.ID, Kind: KindCrash, AppID: appID}
+	metadata.BuildID, metadata.TesterID = relatedIDs(submission.Relationships)
+
+	if submission.Attributes != nil {
+		metadata.Attributes = *submission.Attributes
+	}
+
+	log, _, err := d.client.TestFlight.GetCrashLogForBetaFeedbackCrashSubmission(ctx, submission.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	if log.Data.Attributes != nil && log.Data.Attributes.LogText != nil {
+		err := writeFile(filepath.Join(dir, CrashLogFileName), func(w io.Writer) error {
+			_, err := io.WriteString(w, *log.Data.Attributes.LogText)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		metadata.Files = append(metadata.Files, CrashLogFileName)
+	}
+
+	return d.finish(dir, metadata)
+}
+
+// submissionDir returns the directory of a submission. IDs come from the server, so they are
+// checked to stay inside the downloader's directory.
+func (d *Downloader) submissionDir(kind string, id string) (string, error) {
+	if id == "" || id == "." || strings.Contains(id, "..") || strings.ContainsAny(id, `/\`) || filepath.Base(id) != id {
+		return "", ErrInvalidSubmissionID{ID: id}
+	}
+
+	return filepath.Join(d.dir, kind, id), nil
+}
+
+// existing returns the item recorded in dir by an earlier run, or nil if there is none.
+func (d *Downloader) existing(dir string) (*Item, error) {
+	data, err := os.ReadFile(filepath.Join(dir, MetadataFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+
+	d.logf("%s %s: already downloaded", metadata.Kind, metadata.ID)
+
+	return newItem(dir, metadata, true), nil
+}
+
+// finish writes the sidecar, which marks the submission as complete.
+func (d *Downloader) finish(dir string, metadata Metadata) (*Item, error) {
+	metadata.DownloadedAt = d.now()
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFile(filepath.Join(dir, MetadataFileName), func(w io.Writer) error {
+		_, err := w.Write(data)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logf("%s %s: downloaded %d files", metadata.Kind, metadata.ID, len(metadata.Files))
+
+	return newItem(dir, metadata, false), nil
+}
+
+func (d *Downloader) logf(format string, args ...interface{}) {
+	if d.Logf != nil {
+		d.Logf(format, args...)
+	}
+}
+
+func downloadError(failures map[string]error) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return ErrDownload{Failures: failures}
+}
+
+func newItem(dir string, metadata Metadata, skipped bool) *Item {
+	item := &Item{Metadata: metadata, Dir: dir, Skipped: skipped}
+	for _, name := range metadata.Files {
+		item.Files = append(item.Files, filepath.Join(dir, name))
+	}
+
+	return item
+}
+
+// writeFile writes to a temporary file next to name and renames it into place once write succeeds,
+// so that an interrupted download never leaves a partial file behind.
+func writeFile(name string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+func relatedIDs(relationships *asc.BetaFeedbackSubmissionRelationships) (buildID, testerID string) {
+	if relationships == nil {
+		return "", ""
+	}
+
+	if relationships.Build != nil && relationships.Build.Data != nil {
+		buildID = relationships.Build.Data.ID
+	}
+
+	if relationships.Tester != nil && relationships.Tester.Data != nil {
+		testerID = relationships.Tester.Data.ID
+	}
+
+	return buildID, testerID
+}
+
+func screenshotExtension(screenshot asc.BetaFeedbackScreenshotImage) string {
+	if screenshot.URL != nil {
+		if u, err := url.Parse(*screenshot.URL); err == nil {
+			if ext := path.Ext(u.Path); ext != "" {
+				return ext
+			}
+		}
+	}
+
+	return ".png"
+}
+
+func copyQuery(query *asc.ListBetaFeedbackSubmissionsForAppQuery) *asc.ListBetaFeedbackSubmissionsForAppQuery {
+	params := &asc.ListBetaFeedbackSubmissionsForAppQuery{}
+	if query != nil {
+		*params = *query
+	}
+
+	params.Cursor = ""
+
+	if params.Limit == 0 {
+		params.Limit = pageLimit
+	}
+
+	return params
+}
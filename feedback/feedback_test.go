@@ -0,0 +1,159 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package feedback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func newTestDownloader(t *testing.T, routes map[string]string) (*Downloader, *apitest.Server) {
+	t.Helper()
+
+	client, api := apitest.NewServer(t, routes)
+
+	return NewDownloader(client, t.TempDir()), api
+}
+
+func TestDownloadScreenshots(t *testing.T) {
+	t.Parallel()
+
+	downloader, api := newTestDownloader(t, map[string]string{
+		"GET /v1/apps/app/betaFeedbackScreenshotSubmissions": `{"data":[{"id":"s1","attributes":{"comment":"Overlap","deviceModel":"iPhone15,2","screenshots":[{"url":"https://cdn.example.com/a/1.jpeg"},{"url":"https://cdn.example.com/a/2"}]},"relationships":{"build":{"data":{"type":"builds","id":"b1"}},"tester":{"data":{"type":"betaTesters","id":"t1"}}}}],"links":{"self":""}}`,
+		"GET /a/1.jpeg": "first",
+		"GET /a/2":      "second",
+	})
+
+	items, err := downloader.DownloadScreenshots(context.Background(), "app", &asc.ListBetaFeedbackSubmissionsForAppQuery{FilterBuild: []string{"b1"}})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	item := items[0]
+	assert.False(t, item.Skipped)
+	assert.Equal(t, []string{"screenshot-1.jpeg", "screenshot-2.png"}, item.Metadata.Files)
+	assert.Equal(t, "b1", item.Metadata.BuildID)
+	assert.Equal(t, "t1", item.Metadata.TesterID)
+
+	data, err := os.ReadFile(item.Files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+
+	var sidecar Metadata
+
+	data, err = os.ReadFile(filepath.Join(item.Dir, MetadataFileName))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &sidecar))
+	assert.Equal(t, "Overlap", *sidecar.Attributes.Comment)
+	assert.Equal(t, KindScreenshot, sidecar.Kind)
+
+	items, err = downloader.DownloadScreenshots(context.Background(), "app", nil)
+	assert.NoError(t, err)
+	assert.True(t, items[0].Skipped)
+	assert.Len(t, api.Requests("GET /a/1.jpeg"), 1)
+}
+
+func TestDownloadScreenshotsContinuesAfterFailedSubmission(t *testing.T) {
+	t.Parallel()
+
+	downloader, api := newTestDownloader(t, map[string]string{
+		"GET /v1/apps/app/betaFeedbackScreenshotSubmissions": `{"data":[{"id":"s1","attributes":{"screenshots":[{"url":"https://cdn.example.com/expired"}]}},{"id":"s2","attributes":{"screenshots":[{}]}},{"id":"s3","attributes":{"screenshots":[{"url":"https://cdn.example.com/3.png"}]}}],"links":{"self":""}}`,
+		"GET /3.png": "third",
+	})
+
+	items, err := downloader.DownloadScreenshots(context.Background(), "app", nil)
+
+	var downloadErr ErrDownload
+
+	assert.True(t, errors.As(err, &downloadErr))
+	assert.Len(t, downloadErr.Failures, 2)
+	assert.Error(t, downloadErr.Failures["s1"])
+	assert.Equal(t, asc.ErrMissingScreenshotURL, downloadErr.Failures["s2"])
+	assert.Len(t, items, 1)
+	assert.Equal(t, "s3", items[0].Metadata.ID)
+	assert.NoFileExists(t, filepath.Join(downloader.dir, "screenshots", "s1", MetadataFileName))
+
+	api.SetRoute("GET /expired", "first")
+
+	items, err = downloader.DownloadScreenshots(context.Background(), "app", nil)
+	assert.Equal(t, ErrDownload{Failures: map[string]error{"s2": asc.ErrMissingScreenshotURL}}, err)
+	assert.Len(t, items, 2)
+	assert.False(t, items[0].Skipped)
+	assert.True(t, items[1].Skipped)
+}
+
+func TestDownloadCrashes(t *testing.T) {
+	t.Parallel()
+
+	downloader, api := newTestDownloader(t, map[string]string{
+		"GET /v1/apps/app/betaFeedbackCrashSubmissions":    `{"data":[{"id":"c1","attributes":{"comment":"It crashed","osVersion":"17.4"}}],"links":{"self":""}}`,
+		"GET /v1/betaFeedbackCrashSubmissions/c1/crashLog": `{"data":{"id":"c1","attributes":{"logText":"Exception Type: EXC_CRASH"}}}`,
+	})
+
+	items, err := downloader.DownloadCrashes(context.Background(), "app", nil)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, KindCrash, items[0].Metadata.Kind)
+	assert.Equal(t, "17.4", *items[0].Metadata.Attributes.OSVersion)
+
+	data, err := os.ReadFile(filepath.Join(items[0].Dir, CrashLogFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, "Exception Type: EXC_CRASH", string(data))
+
+	_, err = downloader.DownloadCrashes(context.Background(), "app", nil)
+	assert.NoError(t, err)
+	assert.Len(t, api.Requests("GET /v1/betaFeedbackCrashSubmissions/c1/crashLog"), 1)
+}
+
+func TestDownloadCrashesLeavesNoSidecarOnFailure(t *testing.T) {
+	t.Parallel()
+
+	downloader, _ := newTestDownloader(t, map[string]string{
+		"GET /v1/apps/app/betaFeedbackCrashSubmissions": `{"data":[{"id":"c1"}],"links":{"self":""}}`,
+	})
+
+	_, err := downloader.DownloadCrashes(context.Background(), "app", nil)
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(downloader.dir, "crashes", "c1", MetadataFileName))
+}
+
+func TestDownloadRejectsUnsafeSubmissionIDs(t *testing.T) {
+	t.Parallel()
+
+	for _, id := range []string{"..", "../escape", "a/b", `a\b`, ""} {
+		body, _ := json.Marshal(map[string]interface{}{"data": []map[string]string{{"id": id}}, "links": map[string]string{"self": ""}})
+
+		downloader, api := newTestDownloader(t, map[string]string{
+			"GET /v1/apps/app/betaFeedbackCrashSubmissions": string(body),
+		})
+
+		_, err := downloader.DownloadCrashes(context.Background(), "app", nil)
+		assert.Equal(t, ErrDownload{Failures: map[string]error{id: ErrInvalidSubmissionID{ID: id}}}, err, id)
+		assert.Equal(t, 1, api.RequestCount(), id)
+	}
+}
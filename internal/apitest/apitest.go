@@ -0,0 +1,225 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package apitest serves canned App Store Connect responses to the tests of the packages built on
+// asc. Routes are keyed by "METHOD /path"; the query string and the host are ignored, so presigned
+// download URLs can be served by the same server as the API.
+package apitest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+// Server serves one body per route and records the body of every request. An empty body is
+// answered with 204 No Content, and a route that is not set with a 404 error.
+type Server struct {
+	mu       sync.Mutex
+	routes   map[string]string
+	requests map[string][]string
+}
+
+// NewServer starts a Server for routes and returns a client whose requests it serves.
+func NewServer(t testing.TB, routes map[string]string) (*asc.Client, *Server) {
+	t.Helper()
+
+	server := &Server{routes: routes, requests: make(map[string][]string)}
+
+	return NewClient(t, server), server
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	route := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	body, _ := io.ReadAll(r.Body)
+	s.requests[route] = append(s.requests[route], string(body))
+
+	res, ok := s.routes[route]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":"404"}]}`)
+
+		return
+	}
+
+	if res == "" {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	fmt.Fprint(w, res)
+}
+
+// Requests returns the bodies of the requests made to route, in order.
+func (s *Server) Requests(route string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests[route]...)
+}
+
+// RequestCount returns the number of requests received, on any route.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, bodies := range s.requests {
+		n += len(bodies)
+	}
+
+	return n
+}
+
+// SetRoute changes the body served for route.
+func (s *Server) SetRoute(route, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes[route] = body
+}
+
+// RemoveRoute makes route answer with a 404 error.
+func (s *Server) RemoveRoute(route string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.routes, route)
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+// Queue serves a queue of responses per route, for tests that need a route to answer differently
+// over time, and records every request along with its body.
+type Queue struct {
+	mu        sync.Mutex
+	responses map[string][]response
+	requests  []string
+	bodies    map[string][]string
+}
+
+// NewQueue returns a Queue with no routes.
+func NewQueue() *Queue {
+	return &Queue{responses: make(map[string][]response), bodies: make(map[string][]string)}
+}
+
+// On queues a response for route. The last queued response is repeated once the others are used.
+func (q *Queue) On(route string, status int, body string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.responses[route] = append(q.responses[route], response{status: status, body: body})
+}
+
+// Clear drops the responses queued for route.
+func (q *Queue) Clear(route string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.responses, route)
+}
+
+func (q *Queue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	route := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	body, _ := io.ReadAll(r.Body)
+	q.requests = append(q.requests, route)
+	q.bodies[route] = append(q.bodies[route], string(body))
+
+	queued := q.responses[route]
+	if len(queued) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":"404","code":"NOT_FOUND"}]}`)
+
+		return
+	}
+
+	res := queued[0]
+	if len(queued) > 1 {
+		q.responses[route] = queued[1:]
+	}
+
+	w.WriteHeader(res.status)
+	fmt.Fprint(w, res.body)
+}
+
+// Requests returns the routes of every request received, in order.
+func (q *Queue) Requests() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]string(nil), q.requests...)
+}
+
+// Bodies returns the bodies of the requests made to route, in order.
+func (q *Queue) Bodies(route string) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]string(nil), q.bodies[route]...)
+}
+
+// Count returns the number of requests made to route.
+func (q *Queue) Count(route string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.bodies[route])
+}
+
+// NewClient returns a client whose requests, whatever their host, are served by handler. The
+// server is closed when the test ends.
+func NewClient(t testing.TB, handler http.Handler) *asc.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+
+	return asc.NewClient(&http.Client{Transport: rewriteTransport{target: target}})
+}
+
+// rewriteTransport sends every request to the test server.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
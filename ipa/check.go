@@ -0,0 +1,355 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ipa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/capabilities"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const pageLimit = 200
+
+// ProblemKind identifies the check that found a problem.
+type ProblemKind string
+
+const (
+	// ProblemMissingVersion means Info.plist has no CFBundleShortVersionString or CFBundleVersion.
+	ProblemMissingVersion ProblemKind = "MISSING_VERSION"
+	// ProblemMissingEncryptionKey means Info.plist does not set ITSAppUsesNonExemptEncryption, so
+	// export compliance has to be answered by hand for the build.
+	ProblemMissingEncryptionKey ProblemKind = "MISSING_ENCRYPTION_KEY"
+	// ProblemMissingProfile means the app has no embedded provisioning profile.
+	ProblemMissingProfile ProblemKind = "MISSING_PROFILE"
+	// ProblemExpiredProfile means the embedded profile has expired.
+	ProblemExpiredProfile ProblemKind = "EXPIRED_PROFILE"
+	// ProblemProfileMismatch means the embedded profile is for another bundle ID.
+	ProblemProfileMismatch ProblemKind = "PROFILE_MISMATCH"
+	// ProblemUnprovisionedEntitlement means the app is signed with an entitlement its profile does
+	// not grant.
+	ProblemUnprovisionedEntitlement ProblemKind = "UNPROVISIONED_ENTITLEMENT"
+	// ProblemInvalidEntitlement means an entitlement has a value no capability accepts.
+	ProblemInvalidEntitlement ProblemKind = "INVALID_ENTITLEMENT"
+	// ProblemMissingBundleID means the bundle ID is not registered to the team.
+	ProblemMissingBundleID ProblemKind = "MISSING_BUNDLE_ID"
+	// ProblemMissingCapability means an entitlement needs a capability the bundle ID does not have
+	// enabled, or has enabled with other settings.
+	ProblemMissingCapability ProblemKind = "MISSING_CAPABILITY"
+	// ProblemUnknownProfile means the embedded profile is not one of the bundle ID's profiles, usually
+	// because it was deleted.
+	ProblemUnknownProfile ProblemKind = "UNKNOWN_PROFILE"
+	// ProblemInvalidProfile means App Store Connect no longer considers the embedded profile active.
+	ProblemInvalidProfile ProblemKind = "INVALID_PROFILE"
+	// ProblemMissingApp means the bundle ID has no app record to upload builds to.
+	ProblemMissingApp ProblemKind = "MISSING_APP"
+	// ProblemDuplicateBuild means the app already has a build with the same build number.
+	ProblemDuplicateBuild ProblemKind = "DUPLICATE_BUILD"
+)
+
+// Problem is something about an archive that will make its upload fail or the build unusable.
+type Problem struct {
+	Kind    ProblemKind
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Kind, p.Message)
+}
+
+// CheckOptions changes how an archive is checked.
+type CheckOptions struct {
+	// Now is the time the embedded profile's expiration is compared with. Defaults to time.Now().
+	Now time.Time
+}
+
+// Check compares an archive with the team's account and returns the problems it finds, in the order
+// they were found. Checks that only need the archive run first; the bundle ID's capabilities, the
+// embedded profile's state and the app's build numbers are then read from App Store Connect. Build
+// numbers are compared with every build of the app, which is stricter than App Store Connect for
+// iOS apps, where they only need to be unique within a version. An error is only returned when a
+// request fails.
+func Check(ctx context.Context, client *asc.Client, archive *Archive, options CheckOptions) ([]Problem, error) {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	problems := checkArchive(archive, options.Now)
+
+	bundleID, err := findBundleID(ctx, client, archive.Info.BundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bundleID == nil {
+		return append(problems, Problem{
+			Kind:    ProblemMissingBundleID,
+			Message: fmt.Sprintf("bundle ID %s is not registered", archive.Info.BundleID),
+		}), nil
+	}
+
+	found, err := checkCapabilities(ctx, client, archive, bundleID.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, found...)
+
+	if found, err = checkProfileState(ctx, client, archive, bundleID.ID); err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, found...)
+
+	if found, err = checkBuildNumber(ctx, client, archive, bundleID.ID); err != nil {
+		return nil, err
+	}
+
+	return append(problems, found...), nil
+}
+
+// checkArchive runs the checks that do not need App Store Connect.
+func checkArchive(archive *Archive, now time.Time) []Problem {
+	var problems []Problem
+
+	if archive.Info.Version == "" || archive.Info.BuildNumber == "" {
+		problems = append(problems, Problem{
+			Kind:    ProblemMissingVersion,
+			Message: "Info.plist needs both CFBundleShortVersionString and CFBundleVersion",
+		})
+	}
+
+	if archive.Info.UsesNonExemptEncryption == nil {
+		problems = append(problems, Problem{
+			Kind:    ProblemMissingEncryptionKey,
+			Message: "Info.plist does not set ITSAppUsesNonExemptEncryption",
+		})
+	}
+
+	if archive.Profile == nil {
+		return append(problems, Problem{
+			Kind:    ProblemMissingProfile,
+			Message: fmt.Sprintf("%s has no %s", archive.AppPath, profileFileName),
+		})
+	}
+
+	payload := archive.Profile.Payload
+
+	if !payload.ExpirationDate.IsZero() && !payload.ExpirationDate.After(now) {
+		problems = append(problems, Problem{
+			Kind:    ProblemExpiredProfile,
+			Message: fmt.Sprintf("profile %q expired on %s", payload.Name, payload.ExpirationDate.Format("2006-01-02")),
+		})
+	}
+
+	if !matchesBundleID(payload.BundleID(), archive.Info.BundleID) {
+		problems = append(problems, Problem{
+			Kind:    ProblemProfileMismatch,
+			Message: fmt.Sprintf("profile %q is for %s, not %s", payload.Name, payload.BundleID(), archive.Info.BundleID),
+		})
+	}
+
+	keys := make([]string, 0, len(archive.Entitlements))
+	for key := range archive.Entitlements {
+		if _, ok := payload.Entitlements[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		problems = append(problems, Problem{
+			Kind:    ProblemUnprovisionedEntitlement,
+			Message: fmt.Sprintf("entitlement %s is not granted by profile %q", key, payload.Name),
+		})
+	}
+
+	return problems
+}
+
+// matchesBundleID reports whether a profile's bundle ID, which may be a wildcard, covers identifier.
+func matchesBundleID(profile, identifier string) bool {
+	if profile == "*" {
+		return true
+	}
+
+	if prefix := strings.TrimSuffix(profile, "*"); prefix != profile {
+		return strings.HasPrefix(identifier, prefix)
+	}
+
+	return profile == identifier
+}
+
+// findBundleID returns the bundle ID resource with exactly the given identifier, or nil when it is
+// not registered. The identifier filter also matches longer identifiers, so results are compared.
+func findBundleID(ctx context.Context, client *asc.Client, identifier string) (*asc.BundleID, error) {
+	params := &asc.ListBundleIDsQuery{FilterIdentifier: []string{identifier}, Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListBundleIDs(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range res.Data {
+			if attributes := res.Data[i].Attributes; attributes != nil && attributes.IDentifier != nil && *attributes.IDentifier == identifier {
+				return &res.Data[i], nil
+			}
+		}
+
+		if res.Links.Next == nil {
+			return nil, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// checkCapabilities reports the capabilities the signed entitlements need that the bundle ID does
+// not have. Capabilities the bundle ID has but the app does not use are fine.
+func checkCapabilities(ctx context.Context, client *asc.Client, archive *Archive, bundleID string) ([]Problem, error) {
+	if archive.Entitlements == nil {
+		return nil, nil
+	}
+
+	desired, _, err := capabilities.FromEntitlements(archive.Entitlements)
+
+	var errEntitlement capabilities.ErrInvalidEntitlement
+	if errors.As(err, &errEntitlement) {
+		return []Problem{{Kind: ProblemInvalidEntitlement, Message: errEntitlement.Error()}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	current, err := capabilities.Fetch(ctx, client, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := capabilities.NewPlan(current, desired, capabilities.Options{})
+	problems := make([]Problem, 0, len(plan.Enable)+len(plan.Update))
+
+	for _, capability := range plan.Enable {
+		problems = append(problems, Problem{
+			Kind:    ProblemMissingCapability,
+			Message: fmt.Sprintf("bundle ID %s does not have %s enabled", archive.Info.BundleID, capability.Type),
+		})
+	}
+
+	for _, update := range plan.Update {
+		problems = append(problems, Problem{
+			Kind:    ProblemMissingCapability,
+			Message: fmt.Sprintf("bundle ID %s has %s enabled with other settings", archive.Info.BundleID, update.Capability.Type),
+		})
+	}
+
+	return problems, nil
+}
+
+// checkProfileState looks the embedded profile up among the bundle ID's profiles. Wildcard profiles
+// belong to a wildcard bundle ID and are not looked up.
+func checkProfileState(ctx context.Context, client *asc.Client, archive *Archive, bundleID string) ([]Problem, error) {
+	if archive.Profile == nil || strings.HasSuffix(archive.Profile.Payload.BundleID(), "*") {
+		return nil, nil
+	}
+
+	payload := archive.Profile.Payload
+	params := &asc.ListProfilesForBundleIDQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListProfilesForBundleID(ctx, bundleID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, profile := range res.Data {
+			if profile.Attributes == nil || profile.Attributes.UUID == nil || !strings.EqualFold(*profile.Attributes.UUID, payload.UUID) {
+				continue
+			}
+
+			if state := profile.Attributes.ProfileState; state != nil && *state != "ACTIVE" {
+				return []Problem{{
+					Kind:    ProblemInvalidProfile,
+					Message: fmt.Sprintf("profile %q is %s", payload.Name, *state),
+				}}, nil
+			}
+
+			return nil, nil
+		}
+
+		if res.Links.Next == nil {
+			return []Problem{{
+				Kind:    ProblemUnknownProfile,
+				Message: fmt.Sprintf("profile %q (%s) is not a profile of bundle ID %s", payload.Name, payload.UUID, archive.Info.BundleID),
+			}}, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// checkBuildNumber reports a build number the app already used.
+func checkBuildNumber(ctx context.Context, client *asc.Client, archive *Archive, bundleID string) ([]Problem, error) {
+	app, _, err := client.Provisioning.GetAppForBundleID(ctx, bundleID, nil)
+	if ascutil.IsNotFound(err) || (err == nil && app.Data.ID == "") {
+		return []Problem{{
+			Kind:    ProblemMissingApp,
+			Message: fmt.Sprintf("bundle ID %s has no app in App Store Connect", archive.Info.BundleID),
+		}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if archive.Info.BuildNumber == "" {
+		return nil, nil
+	}
+
+	params := &asc.ListBuildsForAppQuery{FieldsBuilds: []string{"version"}, Limit: pageLimit}
+
+	for {
+		res, _, err := client.Builds.ListBuildsForApp(ctx, app.Data.ID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, build := range res.Data {
+			if build.Attributes != nil && build.Attributes.Version != nil && *build.Attributes.Version == archive.Info.BuildNumber {
+				return []Problem{{
+					Kind:    ProblemDuplicateBuild,
+					Message: fmt.Sprintf("build %s was already uploaded", archive.Info.BuildNumber),
+				}}, nil
+			}
+		}
+
+		if res.Links.Next == nil {
+			return nil, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
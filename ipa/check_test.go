@@ -0,0 +1,154 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ipa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func testCheckRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/bundleIds": `{"data":[
+			{"id":"b2","attributes":{"identifier":"com.example.app.widget"}},
+			{"id":"b1","attributes":{"identifier":"com.example.app"}}
+		]}`,
+		"GET /v1/bundleIds/b1/bundleIdCapabilities": `{"data":[{"id":"cap1","attributes":{"capabilityType":"PUSH_NOTIFICATIONS"}}]}`,
+		"GET /v1/bundleIds/b1/profiles": `{"data":[
+			{"id":"p0","attributes":{"uuid":"00000000-0000-0000-0000-000000000000","profileState":"ACTIVE"}},
+			{"id":"p1","attributes":{"uuid":"3F0E6E1C-0000-4000-8000-000000000000","profileState":"INVALID"}}
+		]}`,
+		"GET /v1/bundleIds/b1/app": `{"data":{"id":"app1","type":"apps"}}`,
+		"GET /v1/apps/app1/builds": `{"data":[{"id":"build1","attributes":{"version":"41"}},{"id":"build2","attributes":{"version":"42"}}]}`,
+	}
+}
+
+func testCheckArchive(t *testing.T) *Archive {
+	t.Helper()
+
+	r := testArchive(t, testArchiveFiles(t))
+
+	archive, err := Read(r, r.Size())
+	assert.NoError(t, err)
+
+	return archive
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testCheckRoutes())
+
+	problems, err := Check(context.Background(), client, testCheckArchive(t), CheckOptions{Now: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{Kind: ProblemUnprovisionedEntitlement, Message: `entitlement com.apple.developer.associated-domains is not granted by profile "Example App Store"`},
+		{Kind: ProblemMissingCapability, Message: "bundle ID com.example.app does not have ASSOCIATED_DOMAINS enabled"},
+		{Kind: ProblemInvalidProfile, Message: `profile "Example App Store" is INVALID`},
+		{Kind: ProblemDuplicateBuild, Message: "build 42 was already uploaded"},
+	}, problems)
+	assert.Len(t, api.Requests("GET /v1/bundleIds"), 1)
+}
+
+func TestCheckPassing(t *testing.T) {
+	t.Parallel()
+
+	routes := testCheckRoutes()
+	routes["GET /v1/bundleIds/b1/bundleIdCapabilities"] = `{"data":[
+		{"id":"cap1","attributes":{"capabilityType":"PUSH_NOTIFICATIONS"}},
+		{"id":"cap2","attributes":{"capabilityType":"ASSOCIATED_DOMAINS"}}
+	]}`
+	routes["GET /v1/bundleIds/b1/profiles"] = `{"data":[{"id":"p1","attributes":{"uuid":"3f0e6e1c-0000-4000-8000-000000000000","profileState":"ACTIVE"}}]}`
+	routes["GET /v1/apps/app1/builds"] = `{"data":[{"id":"build1","attributes":{"version":"41"}}]}`
+
+	client, _ := apitest.NewServer(t, routes)
+
+	archive := testCheckArchive(t)
+	archive.Profile.Payload.Entitlements["com.apple.developer.associated-domains"] = []interface{}{"applinks:*"}
+
+	problems, err := Check(context.Background(), client, archive, CheckOptions{Now: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCheckMissingBundleIDAndApp(t *testing.T) {
+	t.Parallel()
+
+	routes := testCheckRoutes()
+	routes["GET /v1/bundleIds"] = `{"data":[{"id":"b2","attributes":{"identifier":"com.example.app.widget"}}]}`
+
+	client, api := apitest.NewServer(t, routes)
+	archive := testCheckArchive(t)
+	archive.Info.UsesNonExemptEncryption = nil
+
+	problems, err := Check(context.Background(), client, archive, CheckOptions{Now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProblemKind{ProblemMissingEncryptionKey, ProblemExpiredProfile, ProblemUnprovisionedEntitlement, ProblemMissingBundleID}, kinds(problems))
+	assert.Empty(t, api.Requests("GET /v1/bundleIds/b1/bundleIdCapabilities"))
+
+	delete(routes, "GET /v1/bundleIds/b1/app")
+	routes["GET /v1/bundleIds"] = testCheckRoutes()["GET /v1/bundleIds"]
+	client, _ = apitest.NewServer(t, routes)
+
+	problems, err = Check(context.Background(), client, testCheckArchive(t), CheckOptions{Now: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Contains(t, problems, Problem{Kind: ProblemMissingApp, Message: "bundle ID com.example.app has no app in App Store Connect"})
+}
+
+func TestCheckArchiveProfileMismatch(t *testing.T) {
+	t.Parallel()
+
+	archive := testCheckArchive(t)
+	archive.Info.BundleID = "com.example.other"
+	archive.Info.BuildNumber = ""
+	archive.Entitlements = nil
+
+	assert.Equal(t, []Problem{
+		{Kind: ProblemMissingVersion, Message: "Info.plist needs both CFBundleShortVersionString and CFBundleVersion"},
+		{Kind: ProblemProfileMismatch, Message: `profile "Example App Store" is for com.example.app, not com.example.other`},
+	}, checkArchive(archive, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	archive.Profile = nil
+	assert.Equal(t, []ProblemKind{ProblemMissingVersion, ProblemMissingProfile}, kinds(checkArchive(archive, time.Now())))
+}
+
+func TestMatchesBundleID(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchesBundleID("*", "com.example.app"))
+	assert.True(t, matchesBundleID("com.example.*", "com.example.app"))
+	assert.True(t, matchesBundleID("com.example.app", "com.example.app"))
+	assert.False(t, matchesBundleID("com.other.*", "com.example.app"))
+	assert.False(t, matchesBundleID("com.example.app", "com.example.app.widget"))
+}
+
+func kinds(problems []Problem) []ProblemKind {
+	result := make([]ProblemKind, 0, len(problems))
+	for _, problem := range problems {
+		result = append(result, problem.Kind)
+	}
+
+	return result
+}
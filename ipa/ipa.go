@@ -0,0 +1,238 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package ipa inspects an iOS app archive before it is uploaded.
+
+Open reads the app's Info.plist, its embedded provisioning profile and the entitlements in the code
+signature of its executable. Check compares them with the team's App Store Connect account and
+reports the problems that would make the upload fail or the build unusable, such as a capability
+the bundle ID does not have, a profile that was revoked or a build number that was already used:
+
+	archive, err := ipa.Open("Example.ipa")
+	problems, err := ipa.Check(ctx, client, archive, ipa.CheckOptions{})
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+*/
+package ipa
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/plist"
+	"github.com/tutorioapp/asc-go/signing"
+)
+
+const (
+	payloadDir      = "Payload/"
+	infoPlistName   = "Info.plist"
+	profileFileName = "embedded.mobileprovision"
+)
+
+// ErrInvalidArchive happens when a file is not an app archive or its app bundle is incomplete.
+type ErrInvalidArchive struct {
+	Reason string
+}
+
+func (e ErrInvalidArchive) Error() string {
+	return "invalid app archive: " + e.Reason
+}
+
+// Info is what an app's Info.plist says about it.
+type Info struct {
+	// BundleID is CFBundleIdentifier.
+	BundleID string
+	// Version is CFBundleShortVersionString, the marketing version such as "1.2.0".
+	Version string
+	// BuildNumber is CFBundleVersion.
+	BuildNumber string
+	// Executable is CFBundleExecutable, the name of the app's binary.
+	Executable string
+	// UsesNonExemptEncryption is ITSAppUsesNonExemptEncryption, or nil when the key is missing.
+	UsesNonExemptEncryption *bool
+	// Plist holds every key of the Info.plist.
+	Plist map[string]interface{}
+}
+
+// Archive is the content of an .ipa file that matters to App Store Connect.
+type Archive struct {
+	// AppPath is the app bundle's directory inside the archive, such as "Payload/Example.app".
+	AppPath string
+	Info    Info
+	// Profile is the embedded provisioning profile, or nil when the app has none.
+	Profile *signing.Profile
+	// Entitlements are the entitlements the executable was signed with, or nil when it is not signed.
+	Entitlements map[string]interface{}
+}
+
+// Open reads the app archive at path.
+func Open(path string) (*Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return Read(file, stat.Size())
+}
+
+// Read reads an app archive of the given size. Only the app bundle at the top of the Payload
+// directory is read; extensions and frameworks inside it are ignored.
+func Read(r io.ReaderAt, size int64) (*Archive, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(reader.File))
+	archive := &Archive{}
+
+	for _, file := range reader.File {
+		files[file.Name] = file
+
+		dir, name := path.Split(file.Name)
+		if name != infoPlistName || !isAppBundle(strings.TrimSuffix(dir, "/")) {
+			continue
+		}
+
+		if archive.AppPath != "" {
+			return nil, ErrInvalidArchive{Reason: "more than one app in Payload"}
+		}
+
+		archive.AppPath = strings.TrimSuffix(dir, "/")
+	}
+
+	if archive.AppPath == "" {
+		return nil, ErrInvalidArchive{Reason: "no app in Payload"}
+	}
+
+	data, err := readFile(files[path.Join(archive.AppPath, infoPlistName)])
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseInfo(data)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Info = *info
+
+	if file, ok := files[path.Join(archive.AppPath, profileFileName)]; ok {
+		data, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if archive.Profile, err = signing.ParseProfile(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if info.Executable != "" {
+		file, ok := files[path.Join(archive.AppPath, info.Executable)]
+		if !ok {
+			return nil, ErrInvalidArchive{Reason: fmt.Sprintf("executable %q is missing", info.Executable)}
+		}
+
+		data, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if archive.Entitlements, err = ReadEntitlements(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive, nil
+}
+
+// isAppBundle reports whether dir is an app bundle directly inside Payload.
+func isAppBundle(dir string) bool {
+	name := strings.TrimPrefix(dir, payloadDir)
+
+	return name != dir && strings.HasSuffix(name, ".app") && !strings.Contains(name, "/")
+}
+
+func readFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+func parseInfo(data []byte) (*Info, error) {
+	value, err := plist.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidArchive{Reason: "Info.plist is not a dictionary"}
+	}
+
+	info := &Info{Plist: dict}
+
+	for key, field := range map[string]*string{
+		"CFBundleIdentifier":         &info.BundleID,
+		"CFBundleShortVersionString": &info.Version,
+		"CFBundleVersion":            &info.BuildNumber,
+		"CFBundleExecutable":         &info.Executable,
+	} {
+		if value, ok := dict[key]; ok {
+			if *field, ok = value.(string); !ok {
+				return nil, ErrInvalidArchive{Reason: fmt.Sprintf("Info.plist has an invalid %s", key)}
+			}
+		}
+	}
+
+	if info.BundleID == "" {
+		return nil, ErrInvalidArchive{Reason: "Info.plist has no CFBundleIdentifier"}
+	}
+
+	if value, ok := dict["ITSAppUsesNonExemptEncryption"]; ok {
+		uses, ok := value.(bool)
+		if !ok {
+			return nil, ErrInvalidArchive{Reason: "Info.plist has an invalid ITSAppUsesNonExemptEncryption"}
+		}
+
+		info.UsesNonExemptEncryption = &uses
+	}
+
+	return info, nil
+}
@@ -0,0 +1,268 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ipa
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.example.app</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.0</string>
+	<key>CFBundleVersion</key>
+	<string>42</string>
+	<key>CFBundleExecutable</key>
+	<string>Example</string>
+	<key>ITSAppUsesNonExemptEncryption</key>
+	<false/>
+</dict>
+</plist>`
+
+const testEntitlements = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>application-identifier</key>
+	<string>TEAMID.com.example.app</string>
+	<key>aps-environment</key>
+	<string>production</string>
+	<key>com.apple.developer.associated-domains</key>
+	<array><string>applinks:example.com</string></array>
+	<key>get-task-allow</key>
+	<false/>
+</dict>
+</plist>`
+
+const testProfilePlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>Name</key>
+	<string>Example App Store</string>
+	<key>UUID</key>
+	<string>3f0e6e1c-0000-4000-8000-000000000000</string>
+	<key>ExpirationDate</key>
+	<date>2025-03-01T12:00:00Z</date>
+	<key>TeamIdentifier</key>
+	<array><string>TEAMID</string></array>
+	<key>Entitlements</key>
+	<dict>
+		<key>application-identifier</key>
+		<string>TEAMID.com.example.app</string>
+		<key>aps-environment</key>
+		<string>production</string>
+		<key>get-task-allow</key>
+		<false/>
+	</dict>
+</dict>
+</plist>`
+
+type testContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type testSignerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     testIssuerAndSerialNumber
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type testIssuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type testSignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      testContentInfo
+	SignerInfos      []testSignerInfo `asn1:"set"`
+}
+
+// testProfile wraps content in a CMS SignedData message shaped like a provisioning profile. The
+// signature is not valid, which is fine since reading an archive does not verify it.
+func testProfile(t *testing.T, content string) []byte {
+	t.Helper()
+
+	explicit := func(der []byte) asn1.RawValue {
+		return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+	}
+
+	octets, err := asn1.Marshal([]byte(content))
+	assert.NoError(t, err)
+
+	sha256 := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}}
+	signed, err := asn1.Marshal(testSignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256},
+		ContentInfo:      testContentInfo{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}, Content: explicit(octets)},
+		SignerInfos: []testSignerInfo{{
+			Version:                   1,
+			IssuerAndSerialNumber:     testIssuerAndSerialNumber{Issuer: asn1.RawValue{FullBytes: []byte{0x30, 0x00}}, SerialNumber: big.NewInt(1)},
+			DigestAlgorithm:           sha256,
+			DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}},
+			EncryptedDigest:           []byte{0},
+		}},
+	})
+	assert.NoError(t, err)
+
+	message, err := asn1.Marshal(testContentInfo{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}, Content: explicit(signed)})
+	assert.NoError(t, err)
+
+	return message
+}
+
+// testExecutable returns a 64-bit arm64 Mach-O file whose only load command is a code signature
+// holding the entitlements.
+func testExecutable(entitlements string) []byte {
+	var signature bytes.Buffer
+
+	be := func(values ...uint32) {
+		for _, v := range values {
+			_ = binary.Write(&signature, binary.BigEndian, v)
+		}
+	}
+
+	be(magicEmbeddedSignature, uint32(20+8+len(entitlements)), 1, slotEntitlements, 20)
+	be(magicEmbeddedEntitlements, uint32(8+len(entitlements)))
+	signature.WriteString(entitlements)
+
+	var file bytes.Buffer
+
+	_ = binary.Write(&file, binary.LittleEndian, []uint32{
+		0xfeedfacf, 0x0100000c, 0, 2, 1, 16, 0, 0,
+		uint32(loadCmdCodeSignature), 16, 48, uint32(signature.Len()),
+	})
+	file.Write(signature.Bytes())
+
+	return file.Bytes()
+}
+
+func testArchive(t *testing.T, files map[string][]byte) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	writer := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func testArchiveFiles(t *testing.T) map[string][]byte {
+	t.Helper()
+
+	return map[string][]byte{
+		"Payload/Example.app/Info.plist":                          []byte(testInfoPlist),
+		"Payload/Example.app/Example":                             testExecutable(testEntitlements),
+		"Payload/Example.app/embedded.mobileprovision":            testProfile(t, testProfilePlist),
+		"Payload/Example.app/PlugIns/Widget.appex/Info.plist":     []byte(testInfoPlist),
+		"Payload/Example.app/Frameworks/Kit.framework/Info.plist": []byte(testInfoPlist),
+		"Symbols/00000000-0000-0000-0000-000000000000.symbols":    {},
+		"SwiftSupport/iphoneos/libswiftCore.dylib":                {},
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	r := testArchive(t, testArchiveFiles(t))
+
+	archive, err := Read(r, r.Size())
+	assert.NoError(t, err)
+	assert.Equal(t, "Payload/Example.app", archive.AppPath)
+	assert.Equal(t, "com.example.app", archive.Info.BundleID)
+	assert.Equal(t, "1.2.0", archive.Info.Version)
+	assert.Equal(t, "42", archive.Info.BuildNumber)
+	assert.Equal(t, "Example", archive.Info.Executable)
+	assert.Equal(t, false, *archive.Info.UsesNonExemptEncryption)
+	assert.Equal(t, "Example App Store", archive.Profile.Payload.Name)
+	assert.Equal(t, "com.example.app", archive.Profile.Payload.BundleID())
+	assert.Equal(t, []interface{}{"applinks:example.com"}, archive.Entitlements["com.apple.developer.associated-domains"])
+	assert.Len(t, archive.Entitlements, 4)
+}
+
+func TestReadWithoutProfile(t *testing.T) {
+	t.Parallel()
+
+	files := testArchiveFiles(t)
+	delete(files, "Payload/Example.app/embedded.mobileprovision")
+	files["Payload/Example.app/Info.plist"] = []byte(`<plist><dict><key>CFBundleIdentifier</key><string>com.example.app</string></dict></plist>`)
+
+	r := testArchive(t, files)
+
+	archive, err := Read(r, r.Size())
+	assert.NoError(t, err)
+	assert.Nil(t, archive.Profile)
+	assert.Nil(t, archive.Entitlements)
+	assert.Nil(t, archive.Info.UsesNonExemptEncryption)
+}
+
+func TestReadErrors(t *testing.T) {
+	t.Parallel()
+
+	r := testArchive(t, map[string][]byte{"Payload/Example.app/PlugIns/Widget.appex/Info.plist": []byte(testInfoPlist)})
+	_, err := Read(r, r.Size())
+	assert.Equal(t, ErrInvalidArchive{Reason: "no app in Payload"}, err)
+
+	r = testArchive(t, map[string][]byte{
+		"Payload/Example.app/Info.plist": []byte(testInfoPlist),
+		"Payload/Other.app/Info.plist":   []byte(testInfoPlist),
+	})
+	_, err = Read(r, r.Size())
+	assert.Equal(t, ErrInvalidArchive{Reason: "more than one app in Payload"}, err)
+
+	r = testArchive(t, map[string][]byte{"Payload/Example.app/Info.plist": []byte(testInfoPlist)})
+	_, err = Read(r, r.Size())
+	assert.Equal(t, ErrInvalidArchive{Reason: `executable "Example" is missing`}, err)
+
+	r = testArchive(t, map[string][]byte{"Payload/Example.app/Info.plist": []byte(`<plist><dict/></plist>`)})
+	_, err = Read(r, r.Size())
+	assert.Equal(t, ErrInvalidArchive{Reason: "Info.plist has no CFBundleIdentifier"}, err)
+
+	r = testArchive(t, map[string][]byte{"Payload/Example.app/Info.plist": []byte(`<plist><dict>` +
+		`<key>CFBundleIdentifier</key><string>com.example.app</string>` +
+		`<key>ITSAppUsesNonExemptEncryption</key><string>NO</string></dict></plist>`)})
+	_, err = Read(r, r.Size())
+	assert.Equal(t, ErrInvalidArchive{Reason: "Info.plist has an invalid ITSAppUsesNonExemptEncryption"}, err)
+}
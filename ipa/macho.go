@@ -0,0 +1,152 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ipa
+
+import (
+	"bytes"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+
+	"github.com/tutorioapp/asc-go/plist"
+)
+
+const (
+	loadCmdCodeSignature macho.LoadCmd = 0x1d
+
+	// Magic numbers and slot types of the code signature blobs, from the cs_blobs.h header of xnu.
+	magicEmbeddedSignature    = 0xfade0cc0
+	magicEmbeddedEntitlements = 0xfade7171
+	slotEntitlements          = 5
+
+	blobHeaderSize = 8
+)
+
+// ReadEntitlements returns the entitlements an executable was signed with. A universal binary is
+// read from its first architecture, since every slice of an app is signed with the same
+// entitlements. It returns nil when the executable has no code signature or the signature has no
+// entitlements.
+func ReadEntitlements(executable []byte) (map[string]interface{}, error) {
+	file, offset, err := openMachO(executable)
+	if err != nil {
+		return nil, ErrInvalidArchive{Reason: "executable is not a Mach-O file: " + err.Error()}
+	}
+
+	defer file.Close()
+
+	for _, load := range file.Loads {
+		raw := load.Raw()
+		if len(raw) < 16 || macho.LoadCmd(file.ByteOrder.Uint32(raw)) != loadCmdCodeSignature {
+			continue
+		}
+
+		start := offset + uint64(file.ByteOrder.Uint32(raw[8:]))
+		end := start + uint64(file.ByteOrder.Uint32(raw[12:]))
+
+		if end > uint64(len(executable)) || start > end {
+			return nil, ErrInvalidArchive{Reason: "code signature is out of range"}
+		}
+
+		return signatureEntitlements(executable[start:end])
+	}
+
+	return nil, nil
+}
+
+// openMachO opens a thin Mach-O file, or the first architecture of a universal one, and returns the
+// offset of that file within data.
+func openMachO(data []byte) (*macho.File, uint64, error) {
+	fat, err := macho.NewFatFile(bytes.NewReader(data))
+	if err == nil {
+		defer fat.Close()
+
+		arch := fat.Arches[0]
+		if uint64(arch.Offset)+uint64(arch.Size) > uint64(len(data)) {
+			return nil, 0, errors.New("architecture is out of range")
+		}
+
+		file, err := macho.NewFile(bytes.NewReader(data[arch.Offset : arch.Offset+arch.Size]))
+
+		return file, uint64(arch.Offset), err
+	} else if !errors.Is(err, macho.ErrNotFat) {
+		return nil, 0, err
+	}
+
+	file, err := macho.NewFile(bytes.NewReader(data))
+
+	return file, 0, err
+}
+
+// signatureEntitlements finds the entitlements blob in an embedded signature. Code signature blobs
+// are big-endian regardless of the executable's byte order.
+func signatureEntitlements(signature []byte) (map[string]interface{}, error) {
+	if len(signature) < 12 || binary.BigEndian.Uint32(signature) != magicEmbeddedSignature {
+		return nil, ErrInvalidArchive{Reason: "code signature is not an embedded signature"}
+	}
+
+	count := binary.BigEndian.Uint32(signature[8:])
+	if uint64(count) > uint64(len(signature)-12)/8 {
+		return nil, ErrInvalidArchive{Reason: "code signature is truncated"}
+	}
+
+	for i := uint32(0); i < count; i++ {
+		index := signature[12+i*8:]
+		if binary.BigEndian.Uint32(index) != slotEntitlements {
+			continue
+		}
+
+		blob, err := signatureBlob(signature, binary.BigEndian.Uint32(index[4:]))
+		if err != nil {
+			return nil, err
+		}
+
+		if binary.BigEndian.Uint32(blob) != magicEmbeddedEntitlements {
+			return nil, ErrInvalidArchive{Reason: "code signature has an invalid entitlements blob"}
+		}
+
+		value, err := plist.Parse(blob[blobHeaderSize:])
+		if err != nil {
+			return nil, err
+		}
+
+		entitlements, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidArchive{Reason: "entitlements are not a dictionary"}
+		}
+
+		return entitlements, nil
+	}
+
+	return nil, nil
+}
+
+func signatureBlob(signature []byte, offset uint32) ([]byte, error) {
+	if uint64(offset)+blobHeaderSize > uint64(len(signature)) {
+		return nil, ErrInvalidArchive{Reason: "code signature blob is out of range"}
+	}
+
+	length := binary.BigEndian.Uint32(signature[offset+4:])
+	if length < blobHeaderSize || uint64(offset)+uint64(length) > uint64(len(signature)) {
+		return nil, ErrInvalidArchive{Reason: "code signature blob is out of range"}
+	}
+
+	return signature[offset : offset+length], nil
+}
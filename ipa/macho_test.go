@@ -0,0 +1,73 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ipa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEntitlementsFromUniversalBinary(t *testing.T) {
+	t.Parallel()
+
+	thin := testExecutable(testEntitlements)
+
+	var fat bytes.Buffer
+
+	_ = binary.Write(&fat, binary.BigEndian, []uint32{0xcafebabe, 1, 0x0100000c, 0, 4096, uint32(len(thin)), 12})
+	fat.Write(make([]byte, 4096-fat.Len()))
+	fat.Write(thin)
+
+	entitlements, err := ReadEntitlements(fat.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "production", entitlements["aps-environment"])
+}
+
+func TestReadEntitlementsWithoutSignature(t *testing.T) {
+	t.Parallel()
+
+	var file bytes.Buffer
+
+	_ = binary.Write(&file, binary.LittleEndian, []uint32{0xfeedfacf, 0x0100000c, 0, 2, 0, 0, 0, 0})
+
+	entitlements, err := ReadEntitlements(file.Bytes())
+	assert.NoError(t, err)
+	assert.Nil(t, entitlements)
+}
+
+func TestReadEntitlementsErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadEntitlements([]byte("#!/bin/sh\n"))
+	assert.IsType(t, ErrInvalidArchive{}, err)
+
+	executable := testExecutable(testEntitlements)
+
+	_, err = ReadEntitlements(executable[:len(executable)-10])
+	assert.Equal(t, ErrInvalidArchive{Reason: "code signature is out of range"}, err)
+
+	executable[48] = 0
+	_, err = ReadEntitlements(executable)
+	assert.Equal(t, ErrInvalidArchive{Reason: "code signature is not an embedded signature"}, err)
+}
@@ -0,0 +1,345 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package plist
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+// binaryMagic starts every binary property list.
+const binaryMagic = "bplist00"
+
+// binaryTrailerSize is the size of the trailer that ends a binary property list.
+const binaryTrailerSize = 32
+
+// binaryEpoch is the reference date of binary property list dates.
+var binaryEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type binaryPlist struct {
+	data    []byte
+	offsets []uint64
+	refSize int
+	// parsing holds the objects being parsed, to reject references that loop.
+	parsing map[uint64]bool
+}
+
+// parseBinary decodes a binary property list, in the bplist00 format written by macOS and Xcode.
+func parseBinary(data []byte) (interface{}, error) {
+	if len(data) < len(binaryMagic)+binaryTrailerSize {
+		return nil, ErrInvalidPlist{Reason: "binary plist is truncated"}
+	}
+
+	trailer := data[len(data)-binaryTrailerSize:]
+	offsetSize := int(trailer[6])
+	refSize := int(trailer[7])
+	count := binary.BigEndian.Uint64(trailer[8:16])
+	top := binary.BigEndian.Uint64(trailer[16:24])
+	tableOffset := binary.BigEndian.Uint64(trailer[24:32])
+
+	if offsetSize < 1 || offsetSize > 8 || refSize < 1 || refSize > 8 {
+		return nil, ErrInvalidPlist{Reason: "invalid binary plist trailer"}
+	}
+
+	objects := uint64(len(data) - binaryTrailerSize)
+	if count == 0 || top >= count || tableOffset >= objects || count > (objects-tableOffset)/uint64(offsetSize) {
+		return nil, ErrInvalidPlist{Reason: "invalid binary plist offset table"}
+	}
+
+	p := &binaryPlist{
+		data:    data[:tableOffset],
+		offsets: make([]uint64, count),
+		refSize: refSize,
+		parsing: make(map[uint64]bool),
+	}
+
+	table := data[tableOffset:]
+	for i := range p.offsets {
+		p.offsets[i] = readUint(table[i*offsetSize : (i+1)*offsetSize])
+	}
+
+	return p.object(top)
+}
+
+func (p *binaryPlist) object(ref uint64) (interface{}, error) {
+	if ref >= uint64(len(p.offsets)) {
+		return nil, ErrInvalidPlist{Reason: fmt.Sprintf("object reference %d is out of range", ref)}
+	}
+
+	if p.parsing[ref] {
+		return nil, ErrInvalidPlist{Reason: fmt.Sprintf("object %d contains itself", ref)}
+	}
+
+	p.parsing[ref] = true
+	defer delete(p.parsing, ref)
+
+	offset := p.offsets[ref]
+	if offset >= uint64(len(p.data)) {
+		return nil, ErrInvalidPlist{Reason: fmt.Sprintf("object %d is out of range", ref)}
+	}
+
+	marker := p.data[offset]
+	kind, info := marker>>4, marker&0x0f
+	offset++
+
+	switch kind {
+	case 0x0:
+		switch info {
+		case 0x8:
+			return false, nil
+		case 0x9:
+			return true, nil
+		}
+	case 0x1:
+		b, err := p.bytes(offset, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+
+		return integer(b), nil
+	case 0x2:
+		b, err := p.bytes(offset, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(b) {
+		case 4:
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+		case 8:
+			return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+		}
+	case 0x3:
+		if info != 0x3 {
+			break
+		}
+
+		b, err := p.bytes(offset, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		seconds := math.Float64frombits(binary.BigEndian.Uint64(b))
+
+		return binaryEpoch.Add(time.Duration(seconds * float64(time.Second))), nil
+	case 0x4:
+		length, start, err := p.length(info, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := p.bytes(start, length)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(nil), b...), nil
+	case 0x5:
+		length, start, err := p.length(info, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := p.bytes(start, length)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(b), nil
+	case 0x6:
+		length, start, err := p.length(info, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := p.bytes(start, length*2)
+		if err != nil {
+			return nil, err
+		}
+
+		units := make([]uint16, length)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+
+		return string(utf16.Decode(units)), nil
+	case 0x8:
+		b, err := p.bytes(offset, uint64(info)+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return readUint(b), nil
+	case 0xa:
+		return p.array(info, offset)
+	case 0xd:
+		return p.dict(info, offset)
+	}
+
+	return nil, ErrInvalidPlist{Reason: fmt.Sprintf("unknown binary plist marker 0x%02x", marker)}
+}
+
+func (p *binaryPlist) array(info byte, offset uint64) ([]interface{}, error) {
+	count, start, err := p.length(info, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := p.refs(start, count)
+	if err != nil {
+		return nil, err
+	}
+
+	array := make([]interface{}, 0, len(refs))
+
+	for _, ref := range refs {
+		value, err := p.object(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		array = append(array, value)
+	}
+
+	return array, nil
+}
+
+func (p *binaryPlist) dict(info byte, offset uint64) (map[string]interface{}, error) {
+	count, start, err := p.length(info, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := p.refs(start, count*2)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := make(map[string]interface{}, count)
+
+	for i := uint64(0); i < count; i++ {
+		key, err := p.object(refs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := key.(string)
+		if !ok {
+			return nil, ErrInvalidPlist{Reason: "dict key is not a string"}
+		}
+
+		value, err := p.object(refs[count+i])
+		if err != nil {
+			return nil, err
+		}
+
+		dict[name] = value
+	}
+
+	return dict, nil
+}
+
+// length returns the element count of an object and the offset its content starts at. Counts of 15
+// and more are stored in an integer object that follows the marker.
+func (p *binaryPlist) length(info byte, offset uint64) (length, start uint64, err error) {
+	if info != 0xf {
+		return uint64(info), offset, nil
+	}
+
+	marker, err := p.bytes(offset, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if marker[0]>>4 != 0x1 {
+		return 0, 0, ErrInvalidPlist{Reason: "invalid binary plist length"}
+	}
+
+	size := uint64(1) << (marker[0] & 0x0f)
+
+	b, err := p.bytes(offset+1, size)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	length = readUint(b)
+	if length > uint64(len(p.data)) {
+		return 0, 0, ErrInvalidPlist{Reason: "binary plist length is out of range"}
+	}
+
+	return length, offset + 1 + size, nil
+}
+
+func (p *binaryPlist) refs(offset, count uint64) ([]uint64, error) {
+	if count > uint64(len(p.data)) {
+		return nil, ErrInvalidPlist{Reason: "binary plist length is out of range"}
+	}
+
+	b, err := p.bytes(offset, count*uint64(p.refSize))
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]uint64, count)
+	for i := range refs {
+		refs[i] = readUint(b[i*p.refSize : (i+1)*p.refSize])
+	}
+
+	return refs, nil
+}
+
+func (p *binaryPlist) bytes(offset, length uint64) ([]byte, error) {
+	if offset > uint64(len(p.data)) || length > uint64(len(p.data))-offset {
+		return nil, ErrInvalidPlist{Reason: "binary plist object is truncated"}
+	}
+
+	return p.data[offset : offset+length], nil
+}
+
+// integer decodes a big-endian integer object. Integers of 8 bytes are signed; the 16-byte form is
+// only used for unsigned values that do not fit in an int64.
+func integer(b []byte) interface{} {
+	switch len(b) {
+	case 1, 2, 4:
+		return int64(readUint(b))
+	case 8:
+		return int64(binary.BigEndian.Uint64(b))
+	}
+
+	v := readUint(b[len(b)-8:])
+	if v > math.MaxInt64 {
+		return v
+	}
+
+	return int64(v)
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+
+	return v
+}
@@ -0,0 +1,210 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package plist reads Apple property lists, such as Info.plist files, entitlements and the payload of
+provisioning profiles, in either the XML or the binary format.
+
+Parse returns the property list as plain Go values:
+
+	dict     map[string]interface{}
+	array    []interface{}
+	string   string
+	integer  int64, or uint64 when it does not fit an int64
+	real     float64
+	true     bool
+	false    bool
+	date     time.Time
+	data     []byte
+	uid      uint64, in binary property lists only
+*/
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPlist happens when a property list is malformed.
+type ErrInvalidPlist struct {
+	Reason string
+}
+
+func (e ErrInvalidPlist) Error() string {
+	return "invalid property list: " + e.Reason
+}
+
+// Parse decodes an XML or binary property list.
+func Parse(data []byte) (interface{}, error) {
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return parseBinary(data)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrInvalidPlist{Reason: "no plist element"}
+		} else if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local != "plist" {
+			return parseValue(decoder, start)
+		}
+
+		value, err := nextValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			return nil, ErrInvalidPlist{Reason: "empty plist element"}
+		}
+
+		return value, nil
+	}
+}
+
+// nextValue parses the next element, or returns nil at the end of the enclosing element.
+func nextValue(decoder *xml.Decoder) (interface{}, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			return parseValue(decoder, t)
+		case xml.EndElement:
+			return nil, nil
+		}
+	}
+}
+
+func parseValue(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		return parseDict(decoder)
+	case "array":
+		return parseArray(decoder)
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := decoder.DecodeElement(&text, &start); err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "string":
+		return text, nil
+	case "integer":
+		return parseInteger(strings.TrimSpace(text))
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "date":
+		return time.Parse(time.RFC3339, strings.TrimSpace(text))
+	case "data":
+		return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	default:
+		return nil, ErrInvalidPlist{Reason: fmt.Sprintf("unknown element <%s>", start.Name.Local)}
+	}
+}
+
+func parseInteger(text string) (interface{}, error) {
+	if i, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return i, nil
+	}
+
+	return strconv.ParseUint(text, 0, 64)
+}
+
+func parseDict(decoder *xml.Decoder) (map[string]interface{}, error) {
+	dict := make(map[string]interface{})
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.EndElement:
+			return dict, nil
+		case xml.StartElement:
+			if t.Name.Local != "key" {
+				return nil, ErrInvalidPlist{Reason: fmt.Sprintf("expected <key> in dict, found <%s>", t.Name.Local)}
+			}
+
+			var key string
+			if err := decoder.DecodeElement(&key, &t); err != nil {
+				return nil, err
+			}
+
+			value, err := nextValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			if value == nil {
+				return nil, ErrInvalidPlist{Reason: fmt.Sprintf("key %q has no value", key)}
+			}
+
+			dict[key] = value
+		}
+	}
+}
+
+func parseArray(decoder *xml.Decoder) ([]interface{}, error) {
+	array := []interface{}{}
+
+	for {
+		value, err := nextValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			return array, nil
+		}
+
+		array = append(array, value)
+	}
+}
@@ -0,0 +1,188 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package plist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>AppIDName</key>
+	<string>Example &amp; Co</string>
+	<key>CreationDate</key>
+	<date>2024-01-02T03:04:05Z</date>
+	<key>DeveloperCertificates</key>
+	<array>
+		<data>
+		aGVsbG8g
+		d29ybGQ=
+		</data>
+	</array>
+	<key>Entitlements</key>
+	<dict>
+		<key>get-task-allow</key>
+		<false/>
+		<key>keychain-access-groups</key>
+		<array>
+			<string>TEAMID.*</string>
+		</array>
+	</dict>
+	<key>Empty</key>
+	<dict/>
+	<key>ProvisionsAllDevices</key>
+	<true/>
+	<key>TimeToLive</key>
+	<integer>365</integer>
+	<key>Huge</key>
+	<integer>18446744073709551615</integer>
+	<key>Ratio</key>
+	<real>0.5</real>
+	<key>Nothing</key>
+	<array/>
+</dict>
+</plist>`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	value, err := Parse([]byte(testPlist))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"AppIDName":             "Example & Co",
+		"CreationDate":          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"DeveloperCertificates": []interface{}{[]byte("hello world")},
+		"Entitlements": map[string]interface{}{
+			"get-task-allow":         false,
+			"keychain-access-groups": []interface{}{"TEAMID.*"},
+		},
+		"Empty":                map[string]interface{}{},
+		"ProvisionsAllDevices": true,
+		"TimeToLive":           int64(365),
+		"Huge":                 uint64(18446744073709551615),
+		"Ratio":                0.5,
+		"Nothing":              []interface{}{},
+	}, value)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`<plist><dict><string>no key</string></dict></plist>`))
+	assert.Equal(t, ErrInvalidPlist{Reason: "expected <key> in dict, found <string>"}, err)
+
+	_, err = Parse([]byte(`<plist><dict><key>a</key></dict></plist>`))
+	assert.Equal(t, ErrInvalidPlist{Reason: `key "a" has no value`}, err)
+
+	_, err = Parse([]byte(`<plist><object/></plist>`))
+	assert.Equal(t, ErrInvalidPlist{Reason: "unknown element <object>"}, err)
+
+	_, err = Parse([]byte(`not a plist`))
+	assert.Equal(t, ErrInvalidPlist{Reason: "no plist element"}, err)
+}
+
+// binaryFixture assembles a binary property list from encoded objects, with 2-byte offsets and
+// 1-byte object references.
+func binaryFixture(top int, objects ...[]byte) []byte {
+	data := []byte(binaryMagic)
+	offsets := make([]int, len(objects))
+
+	for i, object := range objects {
+		offsets[i] = len(data)
+		data = append(data, object...)
+	}
+
+	tableOffset := len(data)
+	for _, offset := range offsets {
+		data = append(data, byte(offset>>8), byte(offset))
+	}
+
+	trailer := make([]byte, binaryTrailerSize)
+	trailer[6] = 2
+	trailer[7] = 1
+	trailer[15] = byte(len(objects))
+	trailer[23] = byte(top)
+	trailer[30] = byte(tableOffset >> 8)
+	trailer[31] = byte(tableOffset)
+
+	return append(data, trailer...)
+}
+
+func TestParseBinary(t *testing.T) {
+	t.Parallel()
+
+	data := binaryFixture(0,
+		[]byte{0xd6, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+		append([]byte{0x5f, 0x10, 18}, "CFBundleIdentifier"...),
+		append([]byte{0x54}, "Flag"...),
+		append([]byte{0x55}, "Count"...),
+		append([]byte{0x54}, "Date"...),
+		append([]byte{0x54}, "Name"...),
+		append([]byte{0x55}, "Items"...),
+		append([]byte{0x5f, 0x10, 15}, "com.example.app"...),
+		[]byte{0x09},
+		[]byte{0x11, 0x01, 0x2c},
+		[]byte{0x33, 0x41, 0xc5, 0xa1, 0xda, 0x52, 0x80, 0x00, 0x00},
+		[]byte{0x62, 0x00, 0x43, 0x00, 0xe9},
+		[]byte{0xa3, 13, 14, 15},
+		[]byte{0x42, 'h', 'i'},
+		[]byte{0x23, 0x3f, 0xe0, 0, 0, 0, 0, 0, 0},
+		[]byte{0x08},
+	)
+
+	value, err := Parse(data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"CFBundleIdentifier": "com.example.app",
+		"Flag":               true,
+		"Count":              int64(300),
+		"Date":               time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"Name":               "Cé",
+		"Items":              []interface{}{[]byte("hi"), 0.5, false},
+	}, value)
+}
+
+func TestParseBinaryErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(binaryMagic))
+	assert.Equal(t, ErrInvalidPlist{Reason: "binary plist is truncated"}, err)
+
+	_, err = Parse(binaryFixture(0, []byte{0xa1, 0}))
+	assert.Equal(t, ErrInvalidPlist{Reason: "object 0 contains itself"}, err)
+
+	_, err = Parse(binaryFixture(0, []byte{0xa1, 5}))
+	assert.Equal(t, ErrInvalidPlist{Reason: "object reference 5 is out of range"}, err)
+
+	_, err = Parse(binaryFixture(0, []byte{0xd1, 1, 1}, []byte{0x09}))
+	assert.Equal(t, ErrInvalidPlist{Reason: "dict key is not a string"}, err)
+
+	_, err = Parse(binaryFixture(0, []byte{0x5f, 0x10, 5, 'a'}))
+	assert.Equal(t, ErrInvalidPlist{Reason: "binary plist object is truncated"}, err)
+
+	_, err = Parse(binaryFixture(0, []byte{0x70}))
+	assert.Equal(t, ErrInvalidPlist{Reason: "unknown binary plist marker 0x70"}, err)
+}
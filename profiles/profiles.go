@@ -0,0 +1,748 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package profiles keeps development and ad hoc provisioning profiles in sync with the team's devices
+and certificates.
+
+Profiles cannot be edited, so registering a device or rotating a certificate leaves every existing
+profile stale until it is deleted and created again. Fetch reads the profiles along with the
+devices and certificates each one contains, NewPlan compares them with the team's enabled devices
+and valid certificates, and Apply regenerates the stale profiles under the same name:
+
+	snapshot, err := profiles.Fetch(ctx, client, profiles.FetchOptions{})
+	plan := profiles.NewPlan(snapshot)
+	for _, change := range plan.Changes {
+		log.Printf("%s: +%d -%d devices", change.Profile.Name, len(change.AddDevices), len(change.RemoveDevices))
+	}
+	result, err := plan.Apply(ctx, client, "profiles")
+*/
+package profiles
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const pageLimit = 200
+
+// Profile types that list devices, and are therefore reconciled.
+const (
+	TypeIOSDevelopment         = "IOS_APP_DEVELOPMENT"
+	TypeIOSAdHoc               = "IOS_APP_ADHOC"
+	TypeTVOSDevelopment        = "TVOS_APP_DEVELOPMENT"
+	TypeTVOSAdHoc              = "TVOS_APP_ADHOC"
+	TypeMacDevelopment         = "MAC_APP_DEVELOPMENT"
+	TypeMacCatalystDevelopment = "MAC_CATALYST_APP_DEVELOPMENT"
+)
+
+// Extension is the file extension of the profiles written by Apply.
+const Extension = ".mobileprovision"
+
+const (
+	deviceStatusEnabled = "ENABLED"
+	deviceClassAppleTV  = "APPLE_TV"
+	profileStateInvalid = "INVALID"
+)
+
+// kind describes which certificates and devices belong in a profile of a given type.
+type kind struct {
+	certificateTypes []asc.CertificateType
+	device           func(Device) bool
+}
+
+var kinds = map[string]kind{
+	TypeIOSDevelopment: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDevelopment, asc.CertificateTypeiOSDevelopment},
+		device:           iOSDevice,
+	},
+	TypeIOSAdHoc: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDistribution, asc.CertificateTypeiOSDistribution},
+		device:           iOSDevice,
+	},
+	TypeTVOSDevelopment: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDevelopment, asc.CertificateTypeiOSDevelopment},
+		device:           tvOSDevice,
+	},
+	TypeTVOSAdHoc: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDistribution, asc.CertificateTypeiOSDistribution},
+		device:           tvOSDevice,
+	},
+	TypeMacDevelopment: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDevelopment, asc.CertificateTypeMacAppDevelopment},
+		device:           macDevice,
+	},
+	TypeMacCatalystDevelopment: {
+		certificateTypes: []asc.CertificateType{asc.CertificateTypeDevelopment, asc.CertificateTypeMacAppDevelopment},
+		device:           macDevice,
+	},
+}
+
+func iOSDevice(d Device) bool {
+	return d.Platform == asc.BundleIDPlatformiOS && d.Class != deviceClassAppleTV
+}
+
+func tvOSDevice(d Device) bool {
+	return d.Platform == asc.BundleIDPlatformiOS && d.Class == deviceClassAppleTV
+}
+
+func macDevice(d Device) bool {
+	return d.Platform == asc.BundleIDPlatformMacOS
+}
+
+// DefaultTypes are the profile types reconciled when FetchOptions.Types is empty.
+var DefaultTypes = []string{
+	TypeIOSDevelopment,
+	TypeIOSAdHoc,
+	TypeTVOSDevelopment,
+	TypeTVOSAdHoc,
+	TypeMacDevelopment,
+	TypeMacCatalystDevelopment,
+}
+
+// ErrUnsupportedType is returned when asked to reconcile a profile type that does not list devices,
+// such as an App Store profile.
+type ErrUnsupportedType struct {
+	Type string
+}
+
+func (e ErrUnsupportedType) Error() string {
+	return fmt.Sprintf("profile type %s cannot be reconciled", e.Type)
+}
+
+// Device is a registered device.
+type Device struct {
+	ID       string
+	Name     string
+	UDID     string
+	Platform asc.BundleIDPlatform
+	Class    string
+	Enabled  bool
+}
+
+// Certificate is a signing certificate.
+type Certificate struct {
+	ID             string
+	Name           string
+	SerialNumber   string
+	Type           asc.CertificateType
+	Platform       asc.BundleIDPlatform
+	ExpirationDate time.Time
+}
+
+// Profile is a provisioning profile with the devices and certificates it contains.
+type Profile struct {
+	ID    string
+	Name  string
+	Type  string
+	UUID  string
+	State string
+	// BundleID is the ID of the bundle ID resource, and BundleIdentifier its reverse-DNS identifier.
+	BundleID         string
+	BundleIdentifier string
+	ExpirationDate   time.Time
+	Devices          []Device
+	Certificates     []Certificate
+}
+
+// Snapshot is the current state of the team's profiles, devices and certificates.
+type Snapshot struct {
+	// Profiles are the profiles of the fetched types and bundle IDs.
+	Profiles []Profile
+	// Devices are the team's enabled devices.
+	Devices []Device
+	// Certificates are the team's certificates that have not expired.
+	Certificates []Certificate
+}
+
+// FetchOptions limits which profiles are fetched.
+type FetchOptions struct {
+	// Types are the profile types to fetch. Defaults to DefaultTypes.
+	Types []string
+	// BundleIDs are the IDs of the bundle ID resources whose profiles are fetched. If empty, the
+	// profiles of every bundle ID are fetched.
+	BundleIDs []string
+	// Now is the time certificates are checked against. Defaults to the current time.
+	Now time.Time
+}
+
+// Fetch reads the profiles matching the options, the devices and certificates in each of them, and
+// the team's enabled devices and valid certificates.
+func Fetch(ctx context.Context, client *asc.Client, options FetchOptions) (*Snapshot, error) {
+	types := options.Types
+	if len(types) == 0 {
+		types = DefaultTypes
+	}
+
+	for _, t := range types {
+		if _, ok := kinds[t]; !ok {
+			return nil, ErrUnsupportedType{Type: t}
+		}
+	}
+
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	snapshot := &Snapshot{}
+
+	profiles, err := listProfiles(ctx, client, types, options.BundleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		if profile.Devices, err = ListDevicesInProfile(ctx, client, profile.ID); err != nil {
+			return nil, err
+		}
+
+		if profile.Certificates, err = listCertificatesInProfile(ctx, client, profile.ID); err != nil {
+			return nil, err
+		}
+
+		snapshot.Profiles = append(snapshot.Profiles, profile)
+	}
+
+	if snapshot.Devices, err = listEnabledDevices(ctx, client); err != nil {
+		return nil, err
+	}
+
+	certificates, err := ListCertificates(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certificate := range certificates {
+		if certificate.ExpirationDate.IsZero() || certificate.ExpirationDate.After(now) {
+			snapshot.Certificates = append(snapshot.Certificates, certificate)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func listProfiles(ctx context.Context, client *asc.Client, types, bundleIDs []string) ([]Profile, error) {
+	wanted := make(map[string]bool, len(bundleIDs))
+	for _, id := range bundleIDs {
+		wanted[id] = true
+	}
+
+	var profiles []Profile
+
+	params := &asc.ListProfilesQuery{
+		FilterProfileType: types,
+		Include:           []string{"bundleId"},
+		Limit:             pageLimit,
+	}
+
+	for {
+		res, _, err := client.Provisioning.ListProfiles(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		identifiers := make(map[string]string)
+
+		for _, included := range res.Included {
+			if bundleID := included.BundleID(); bundleID != nil && bundleID.Attributes != nil && bundleID.Attributes.IDentifier != nil {
+				identifiers[bundleID.ID] = *bundleID.Attributes.IDentifier
+			}
+		}
+
+		for _, data := range res.Data {
+			profile := NewProfile(data)
+			profile.BundleIdentifier = identifiers[profile.BundleID]
+
+			if len(wanted) == 0 || wanted[profile.BundleID] {
+				profiles = append(profiles, profile)
+			}
+		}
+
+		if res.Links.Next == nil {
+			return profiles, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// NewProfile reads a profile resource. Its Certificates only have an ID, and only when the
+// certificates relationship was included; Devices and BundleIdentifier are left empty.
+func NewProfile(data asc.Profile) Profile {
+	profile := Profile{ID: data.ID}
+
+	if attributes := data.Attributes; attributes != nil {
+		profile.Name = ascutil.StringValue(attributes.Name)
+		profile.Type = ascutil.StringValue(attributes.ProfileType)
+		profile.UUID = ascutil.StringValue(attributes.UUID)
+		profile.State = ascutil.StringValue(attributes.ProfileState)
+
+		if attributes.ExpirationDate != nil {
+			profile.ExpirationDate = attributes.ExpirationDate.Time
+		}
+	}
+
+	if relationships := data.Relationships; relationships != nil {
+		if relationships.BundleID != nil && relationships.BundleID.Data != nil {
+			profile.BundleID = relationships.BundleID.Data.ID
+		}
+
+		if relationships.Certificates != nil {
+			for _, certificate := range relationships.Certificates.Data {
+				profile.Certificates = append(profile.Certificates, Certificate{ID: certificate.ID})
+			}
+		}
+	}
+
+	return profile
+}
+
+// ListDevicesInProfile reads every device in a profile.
+func ListDevicesInProfile(ctx context.Context, client *asc.Client, profileID string) ([]Device, error) {
+	var devices []Device
+
+	params := &asc.ListDevicesInProfileQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListDevicesInProfile(ctx, profileID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range res.Data {
+			devices = append(devices, newDevice(data))
+		}
+
+		if res.Links.Next == nil {
+			return devices, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func listEnabledDevices(ctx context.Context, client *asc.Client) ([]Device, error) {
+	var devices []Device
+
+	params := &asc.ListDevicesQuery{FilterStatus: []string{deviceStatusEnabled}, Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListDevices(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range res.Data {
+			devices = append(devices, newDevice(data))
+		}
+
+		if res.Links.Next == nil {
+			return devices, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func newDevice(data asc.Device) Device {
+	device := Device{ID: data.ID}
+
+	if attributes := data.Attributes; attributes != nil {
+		device.Name = ascutil.StringValue(attributes.Name)
+		device.UDID = ascutil.StringValue(attributes.UDID)
+		device.Class = ascutil.StringValue(attributes.DeviceClass)
+		device.Enabled = ascutil.StringValue(attributes.Status) == deviceStatusEnabled
+
+		if attributes.Platform != nil {
+			device.Platform = *attributes.Platform
+		}
+	}
+
+	return device
+}
+
+func listCertificatesInProfile(ctx context.Context, client *asc.Client, profileID string) ([]Certificate, error) {
+	var certificates []Certificate
+
+	params := &asc.ListCertificatesForProfileQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListCertificatesInProfile(ctx, profileID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range res.Data {
+			certificates = append(certificates, newCertificate(data))
+		}
+
+		if res.Links.Next == nil {
+			return certificates, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+// ListCertificates reads every certificate of the team, expired ones included.
+func ListCertificates(ctx context.Context, client *asc.Client) ([]Certificate, error) {
+	var certificates []Certificate
+
+	params := &asc.ListCertificatesQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := client.Provisioning.ListCertificates(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range res.Data {
+			certificates = append(certificates, newCertificate(data))
+		}
+
+		if res.Links.Next == nil {
+			return certificates, nil
+		}
+
+		params.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func newCertificate(data asc.Certificate) Certificate {
+	certificate := Certificate{ID: data.ID}
+
+	if attributes := data.Attributes; attributes != nil {
+		certificate.Name = ascutil.StringValue(attributes.DisplayName)
+		if certificate.Name == "" {
+			certificate.Name = ascutil.StringValue(attributes.Name)
+		}
+
+		certificate.SerialNumber = ascutil.StringValue(attributes.SerialNumber)
+
+		if attributes.CertificateType != nil {
+			certificate.Type = *attributes.CertificateType
+		}
+
+		if attributes.Platform != nil {
+			certificate.Platform = *attributes.Platform
+		}
+
+		if attributes.ExpirationDate != nil {
+			certificate.ExpirationDate = attributes.ExpirationDate.Time
+		}
+	}
+
+	return certificate
+}
+
+// Change is a profile that is regenerated with a new set of devices and certificates.
+type Change struct {
+	Profile Profile
+	// Devices and Certificates are what the regenerated profile will contain.
+	Devices      []Device
+	Certificates []Certificate
+	// AddDevices, RemoveDevices, AddCertificates and RemoveCertificates describe the difference
+	// with the current profile.
+	AddDevices         []Device
+	RemoveDevices      []Device
+	AddCertificates    []Certificate
+	RemoveCertificates []Certificate
+	// Invalid is set when App Store Connect already marks the profile as invalid, which makes it
+	// stale even when its devices and certificates are up to date.
+	Invalid bool
+}
+
+// Skip is a stale profile that cannot be regenerated.
+type Skip struct {
+	Profile Profile
+	Reason  string
+}
+
+// Plan lists the profiles that need to be regenerated. A plan can be inspected as a dry run before
+// it is applied.
+type Plan struct {
+	Changes []Change
+	Skipped []Skip
+}
+
+// Empty reports whether the plan has nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// NewPlan compares each profile in the snapshot with the devices and certificates it should
+// contain: every enabled device of the profile's platform and every valid certificate of a type
+// that can sign with it. Profiles that already match are left alone.
+func NewPlan(snapshot *Snapshot) *Plan {
+	plan := &Plan{}
+
+	for _, profile := range snapshot.Profiles {
+		k, ok := kinds[profile.Type]
+		if !ok {
+			plan.Skipped = append(plan.Skipped, Skip{Profile: profile, Reason: ErrUnsupportedType{Type: profile.Type}.Error()})
+
+			continue
+		}
+
+		change := Change{Profile: profile, Invalid: profile.State == profileStateInvalid}
+
+		for _, device := range snapshot.Devices {
+			if k.device(device) {
+				change.Devices = append(change.Devices, device)
+			}
+		}
+
+		for _, certificate := range snapshot.Certificates {
+			if hasCertificateType(k.certificateTypes, certificate.Type) {
+				change.Certificates = append(change.Certificates, certificate)
+			}
+		}
+
+		change.AddDevices = deviceDifference(change.Devices, profile.Devices)
+		change.RemoveDevices = deviceDifference(profile.Devices, change.Devices)
+		change.AddCertificates = certificateDifference(change.Certificates, profile.Certificates)
+		change.RemoveCertificates = certificateDifference(profile.Certificates, change.Certificates)
+
+		stale := change.Invalid ||
+			len(change.AddDevices) > 0 || len(change.RemoveDevices) > 0 ||
+			len(change.AddCertificates) > 0 || len(change.RemoveCertificates) > 0
+		if !stale {
+			continue
+		}
+
+		switch {
+		case len(change.Certificates) == 0:
+			plan.Skipped = append(plan.Skipped, Skip{Profile: profile, Reason: "no valid certificate can sign it"})
+		case len(change.Devices) == 0:
+			plan.Skipped = append(plan.Skipped, Skip{Profile: profile, Reason: "no enabled device can run it"})
+		case profile.BundleID == "":
+			plan.Skipped = append(plan.Skipped, Skip{Profile: profile, Reason: "its bundle ID is unknown"})
+		default:
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	return plan
+}
+
+func hasCertificateType(types []asc.CertificateType, t asc.CertificateType) bool {
+	for _, other := range types {
+		if other == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deviceDifference returns the devices in a that are not in b.
+func deviceDifference(a, b []Device) []Device {
+	ids := make(map[string]bool, len(b))
+	for _, device := range b {
+		ids[device.ID] = true
+	}
+
+	var out []Device
+
+	for _, device := range a {
+		if !ids[device.ID] {
+			out = append(out, device)
+		}
+	}
+
+	return out
+}
+
+// certificateDifference returns the certificates in a that are not in b.
+func certificateDifference(a, b []Certificate) []Certificate {
+	ids := make(map[string]bool, len(b))
+	for _, certificate := range b {
+		ids[certificate.ID] = true
+	}
+
+	var out []Certificate
+
+	for _, certificate := range a {
+		if !ids[certificate.ID] {
+			out = append(out, certificate)
+		}
+	}
+
+	return out
+}
+
+// Regenerated is a profile that Apply deleted and created again.
+type Regenerated struct {
+	Old Profile
+	New asc.Profile
+	// Path is the file the new profile was written to, if any.
+	Path string
+}
+
+// Failure records a profile that could not be regenerated, or whose new profile could not be
+// written. In the latter case Err is an ErrWriteProfile and the profile is also listed in
+// Result.Regenerated, without a Path.
+type Failure struct {
+	Profile Profile
+	// Deleted is set when the old profile was deleted but the new one could not be created, in
+	// which case the profile no longer exists.
+	Deleted bool
+	Err     error
+}
+
+// ErrWriteProfile is the error of a profile that was created again but could not be written to
+// the output directory.
+type ErrWriteProfile struct {
+	ID  string
+	Err error
+}
+
+func (e ErrWriteProfile) Error() string {
+	return fmt.Sprintf("profile %s was regenerated but not written: %s", e.ID, e.Err)
+}
+
+func (e ErrWriteProfile) Unwrap() error {
+	return e.Err
+}
+
+// Result lists what Apply did.
+type Result struct {
+	Regenerated []Regenerated
+	Failures    []Failure
+}
+
+// ErrApply is the error of an Apply that could not regenerate or write some profiles. Each of them is
+// in Result.Failures as well.
+type ErrApply struct {
+	Failures []Failure
+}
+
+func (e ErrApply) Error() string {
+	return fmt.Sprintf("%d profiles failed to regenerate, first: %s", len(e.Failures), e.Failures[0].Err)
+}
+
+// Apply deletes each profile in the plan and creates it again with the same name, type and bundle
+// ID. A profile that was already deleted, for instance by an earlier Apply that failed to create it,
+// is only created. If dir is not empty, each new profile is written to it as <name>.mobileprovision.
+// Each profile is attempted whatever happened to the previous ones, and the Result says which were
+// regenerated; when some were not, the error is an ErrApply.
+func (p *Plan) Apply(ctx context.Context, client *asc.Client, dir string) (*Result, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{}
+
+	for _, change := range p.Changes {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		profile := change.Profile
+
+		if _, err := client.Provisioning.DeleteProfile(ctx, profile.ID); err != nil && !ascutil.IsNotFound(err) {
+			result.Failures = append(result.Failures, Failure{Profile: profile, Err: err})
+
+			continue
+		}
+
+		res, _, err := client.Provisioning.CreateProfile(ctx, profile.Name, profile.Type, profile.BundleID, certificateIDs(change.Certificates), deviceIDs(change.Devices))
+		if err != nil {
+			result.Failures = append(result.Failures, Failure{Profile: profile, Deleted: true, Err: err})
+
+			continue
+		}
+
+		regenerated := Regenerated{Old: profile, New: res.Data}
+
+		if dir != "" {
+			path, err := writeProfile(dir, profile.Name, res.Data)
+			if err != nil {
+				result.Failures = append(result.Failures, Failure{Profile: profile, Err: ErrWriteProfile{ID: res.Data.ID, Err: err}})
+			} else {
+				regenerated.Path = path
+			}
+		}
+
+		result.Regenerated = append(result.Regenerated, regenerated)
+	}
+
+	if len(result.Failures) > 0 {
+		return result, ErrApply{Failures: result.Failures}
+	}
+
+	return result, nil
+}
+
+// writeProfile decodes the profile's content into dir and returns the file's path.
+func writeProfile(dir, name string, profile asc.Profile) (string, error) {
+	if profile.Attributes == nil || profile.Attributes.ProfileContent == nil {
+		return "", fmt.Errorf("profile %s was created without content", profile.ID)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(*profile.Attributes.ProfileContent)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fileName(name)+Extension)
+
+	return path, os.WriteFile(path, content, 0o644)
+}
+
+// fileName makes a profile name safe to use as a file name.
+func fileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == os.PathSeparator {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
+
+func certificateIDs(certificates []Certificate) []string {
+	ids := make([]string, 0, len(certificates))
+	for _, certificate := range certificates {
+		ids = append(ids, certificate.ID)
+	}
+
+	return ids
+}
+
+func deviceIDs(devices []Device) []string {
+	ids := make([]string, 0, len(devices))
+	for _, device := range devices {
+		ids = append(ids, device.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,207 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package profiles
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/profiles": `{"data":[
+			{"id":"p1","attributes":{"name":"Example Development","profileType":"IOS_APP_DEVELOPMENT","profileState":"ACTIVE"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}}}},
+			{"id":"p2","attributes":{"name":"Example Ad Hoc","profileType":"IOS_APP_ADHOC","profileState":"ACTIVE"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b1"}}}},
+			{"id":"p3","attributes":{"name":"Other Development","profileType":"IOS_APP_DEVELOPMENT","profileState":"INVALID"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"b2"}}}}
+		],"included":[{"type":"bundleIds","id":"b1","attributes":{"identifier":"com.example.app"}}]}`,
+		"GET /v1/profiles/p1/devices":      `{"data":[{"id":"d1","attributes":{"platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}},{"id":"d3","attributes":{"platform":"IOS","deviceClass":"IPAD","status":"DISABLED"}}]}`,
+		"GET /v1/profiles/p1/certificates": `{"data":[{"id":"c1","attributes":{"certificateType":"DEVELOPMENT"}}]}`,
+		"GET /v1/profiles/p2/devices":      `{"data":[{"id":"d1","attributes":{"platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}},{"id":"d2","attributes":{"platform":"IOS","deviceClass":"IPAD","status":"ENABLED"}}]}`,
+		"GET /v1/profiles/p2/certificates": `{"data":[{"id":"c3","attributes":{"certificateType":"DISTRIBUTION"}}]}`,
+		"GET /v1/devices": `{"data":[
+			{"id":"d1","attributes":{"name":"iPhone","udid":"00008030-000000000000001E","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}},
+			{"id":"d2","attributes":{"name":"iPad","udid":"00008030-000000000000002E","platform":"IOS","deviceClass":"IPAD","status":"ENABLED"}},
+			{"id":"d4","attributes":{"name":"Mac","platform":"MAC_OS","deviceClass":"MAC","status":"ENABLED"}},
+			{"id":"d5","attributes":{"name":"Apple TV","platform":"IOS","deviceClass":"APPLE_TV","status":"ENABLED"}}
+		]}`,
+		"GET /v1/certificates": `{"data":[
+			{"id":"c1","attributes":{"displayName":"Ada","certificateType":"DEVELOPMENT","expirationDate":"2025-01-01T00:00:00.000+0000"}},
+			{"id":"c2","attributes":{"displayName":"Alan","certificateType":"DEVELOPMENT","expirationDate":"2024-01-01T00:00:00.000+0000"}},
+			{"id":"c3","attributes":{"displayName":"CI","certificateType":"DISTRIBUTION","expirationDate":"2025-01-01T00:00:00.000+0000"}},
+			{"id":"c4","attributes":{"displayName":"Grace","certificateType":"IOS_DEVELOPMENT","expirationDate":"2025-01-01T00:00:00.000+0000"}}
+		]}`,
+		"DELETE /v1/profiles/p1": "",
+		"POST /v1/profiles":      `{"data":{"id":"p4","attributes":{"name":"Example Development","profileContent":"cHJvZmlsZQ=="}}}`,
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+
+	snapshot, err := Fetch(context.Background(), client, FetchOptions{BundleIDs: []string{"b1"}, Now: testNow})
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Profiles, 2)
+	assert.Equal(t, "com.example.app", snapshot.Profiles[0].BundleIdentifier)
+	assert.Equal(t, []string{"d1", "d3"}, deviceIDs(snapshot.Profiles[0].Devices))
+	assert.False(t, snapshot.Profiles[0].Devices[1].Enabled)
+	assert.Equal(t, []string{"c1"}, certificateIDs(snapshot.Profiles[0].Certificates))
+	assert.Equal(t, []string{"d1", "d2", "d4", "d5"}, deviceIDs(snapshot.Devices))
+	assert.Equal(t, []string{"c1", "c3", "c4"}, certificateIDs(snapshot.Certificates))
+	assert.Empty(t, api.Requests("GET /v1/profiles/p3/devices"))
+
+	_, err = Fetch(context.Background(), client, FetchOptions{Types: []string{"IOS_APP_STORE"}})
+	assert.Equal(t, ErrUnsupportedType{Type: "IOS_APP_STORE"}, err)
+}
+
+func TestPlanAndApply(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+
+	snapshot, err := Fetch(context.Background(), client, FetchOptions{BundleIDs: []string{"b1"}, Now: testNow})
+	assert.NoError(t, err)
+
+	plan := NewPlan(snapshot)
+	assert.False(t, plan.Empty())
+	assert.Empty(t, plan.Skipped)
+	assert.Len(t, plan.Changes, 1)
+
+	change := plan.Changes[0]
+	assert.Equal(t, "p1", change.Profile.ID)
+	assert.Equal(t, []string{"d1", "d2"}, deviceIDs(change.Devices))
+	assert.Equal(t, []string{"d2"}, deviceIDs(change.AddDevices))
+	assert.Equal(t, []string{"d3"}, deviceIDs(change.RemoveDevices))
+	assert.Equal(t, []string{"c1", "c4"}, certificateIDs(change.Certificates))
+	assert.Equal(t, []string{"c4"}, certificateIDs(change.AddCertificates))
+	assert.Empty(t, change.RemoveCertificates)
+
+	dir := t.TempDir()
+
+	result, err := plan.Apply(context.Background(), client, dir)
+	assert.NoError(t, err)
+	assert.Len(t, result.Regenerated, 1)
+	assert.Equal(t, "p4", result.Regenerated[0].New.ID)
+	assert.Equal(t, filepath.Join(dir, "Example Development.mobileprovision"), result.Regenerated[0].Path)
+
+	content, err := os.ReadFile(result.Regenerated[0].Path)
+	assert.NoError(t, err)
+	assert.Equal(t, "profile", string(content))
+
+	assert.Len(t, api.Requests("DELETE /v1/profiles/p1"), 1)
+
+	var body struct {
+		Data struct {
+			Attributes    map[string]string
+			Relationships map[string]json.RawMessage
+		}
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(api.Requests("POST /v1/profiles")[0]), &body))
+	assert.Equal(t, map[string]string{"name": "Example Development", "profileType": "IOS_APP_DEVELOPMENT"}, body.Data.Attributes)
+	assert.JSONEq(t, `{"data":{"type":"bundleIds","id":"b1"}}`, string(body.Data.Relationships["bundleId"]))
+	assert.JSONEq(t, `{"data":[{"type":"devices","id":"d1"},{"type":"devices","id":"d2"}]}`, string(body.Data.Relationships["devices"]))
+	assert.JSONEq(t, `{"data":[{"type":"certificates","id":"c1"},{"type":"certificates","id":"c4"}]}`, string(body.Data.Relationships["certificates"]))
+}
+
+func TestNewPlanSkipsProfilesThatCannotBeRegenerated(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &Snapshot{
+		Profiles: []Profile{
+			{ID: "p1", Type: TypeIOSAdHoc, BundleID: "b1", State: profileStateInvalid},
+			{ID: "p2", Type: TypeTVOSDevelopment, BundleID: "b1", State: profileStateInvalid, Certificates: []Certificate{{ID: "c1"}}},
+			{ID: "p3", Type: "IOS_APP_STORE"},
+		},
+		Devices:      []Device{{ID: "d1", Platform: asc.BundleIDPlatformiOS, Class: "IPHONE"}},
+		Certificates: []Certificate{{ID: "c1", Type: asc.CertificateTypeDevelopment}},
+	}
+
+	plan := NewPlan(snapshot)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, []Skip{
+		{Profile: snapshot.Profiles[0], Reason: "no valid certificate can sign it"},
+		{Profile: snapshot.Profiles[1], Reason: "no enabled device can run it"},
+		{Profile: snapshot.Profiles[2], Reason: "profile type IOS_APP_STORE cannot be reconciled"},
+	}, plan.Skipped)
+}
+
+func TestApplyReportsDeletedProfiles(t *testing.T) {
+	t.Parallel()
+
+	routes := testRoutes()
+	delete(routes, "POST /v1/profiles")
+	client, _ := apitest.NewServer(t, routes)
+
+	plan := &Plan{Changes: []Change{{Profile: Profile{ID: "p1", Name: "Example Development", Type: TypeIOSDevelopment, BundleID: "b1"}}}}
+
+	result, err := plan.Apply(context.Background(), client, "")
+	assert.IsType(t, ErrApply{}, err)
+	assert.Len(t, result.Failures, 1)
+	assert.True(t, result.Failures[0].Deleted)
+}
+
+func TestApplyKeepsProfilesThatCouldNotBeWritten(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "Example Development"+Extension), 0o755))
+
+	plan := &Plan{Changes: []Change{{Profile: Profile{ID: "p1", Name: "Example Development", Type: TypeIOSDevelopment, BundleID: "b1"}}}}
+
+	result, err := plan.Apply(context.Background(), client, dir)
+	assert.IsType(t, ErrApply{}, err)
+	assert.Len(t, result.Regenerated, 1)
+	assert.Equal(t, "p4", result.Regenerated[0].New.ID)
+	assert.Empty(t, result.Regenerated[0].Path)
+	assert.Len(t, result.Failures, 1)
+	assert.False(t, result.Failures[0].Deleted)
+	assert.IsType(t, ErrWriteProfile{}, result.Failures[0].Err)
+}
+
+func TestApplyCreatesProfilesThatWereAlreadyDeleted(t *testing.T) {
+	t.Parallel()
+
+	routes := testRoutes()
+	delete(routes, "DELETE /v1/profiles/p1")
+	client, api := apitest.NewServer(t, routes)
+
+	plan := &Plan{Changes: []Change{{Profile: Profile{ID: "p1", Name: "Example Development", Type: TypeIOSDevelopment, BundleID: "b1"}}}}
+
+	result, err := plan.Apply(context.Background(), client, "")
+	assert.NoError(t, err)
+	assert.Len(t, result.Regenerated, 1)
+	assert.Len(t, api.Requests("DELETE /v1/profiles/p1"), 1)
+	assert.Len(t, api.Requests("POST /v1/profiles"), 1)
+}
@@ -0,0 +1,98 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package release
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+// AuditAction describes what a PhasedReleaseController did during a check.
+type AuditAction string
+
+const (
+	// AuditActionObserved means the release was checked and no threshold was crossed.
+	AuditActionObserved AuditAction = "observed"
+	// AuditActionSkipped means the release was not evaluated, because it is not active or
+	// has not reached the configured minimum day.
+	AuditActionSkipped AuditAction = "skipped"
+	// AuditActionPaused means a threshold was crossed and the phased release was paused.
+	AuditActionPaused AuditAction = "paused"
+	// AuditActionWouldPause means a threshold was crossed, but the controller is in dry-run mode.
+	AuditActionWouldPause AuditAction = "wouldPause"
+)
+
+// AuditEntry records one check made by a PhasedReleaseController.
+type AuditEntry struct {
+	Time              time.Time              `json:"time"`
+	AppStoreVersionID string                 `json:"appStoreVersionId"`
+	PhasedReleaseID   string                 `json:"phasedReleaseId,omitempty"`
+	BuildID           string                 `json:"buildId,omitempty"`
+	PreviousBuildID   string                 `json:"previousBuildId,omitempty"`
+	Day               int                    `json:"day"`
+	State             asc.PhasedReleaseState `json:"state,omitempty"`
+	Action            AuditAction            `json:"action"`
+	DryRun            bool                   `json:"dryRun,omitempty"`
+	Violations        []Violation            `json:"violations,omitempty"`
+	Notes             []string               `json:"notes,omitempty"`
+}
+
+// AuditLog receives an entry for every check a PhasedReleaseController makes.
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// FileAuditLog is an AuditLog that appends each entry as a line of JSON to a file.
+type FileAuditLog struct {
+	Path string
+}
+
+// Record appends the entry to the log file, creating it if needed.
+func (l FileAuditLog) Record(ctx context.Context, entry AuditEntry) error {
+	return ascutil.AppendJSONLine(l.Path, entry)
+}
+
+// MemoryAuditLog is an AuditLog that keeps entries in memory.
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// Record appends the entry.
+func (l *MemoryAuditLog) Record(ctx context.Context, entry AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+
+	return nil
+}
+
+// Entries returns a copy of the recorded entries, oldest first.
+func (l *MemoryAuditLog) Entries() []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]AuditEntry(nil), l.entries...)
+}
@@ -0,0 +1,144 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint records the progress of a release so that an interrupted run can resume
+// without repeating work that has already been confirmed by App Store Connect.
+type Checkpoint struct {
+	// AppStoreVersionID is the ID of the App Store version being released, once known.
+	AppStoreVersionID string `json:"appStoreVersionId,omitempty"`
+	// BuildID is the ID of the build attached to the version, once known.
+	BuildID string `json:"buildId,omitempty"`
+	// Completed lists the steps that have finished successfully, in the order they finished.
+	Completed []Step `json:"completed,omitempty"`
+}
+
+// IsComplete reports whether the given step has already finished.
+func (c *Checkpoint) IsComplete(step Step) bool {
+	for _, s := range c.Completed {
+		if s == step {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Checkpoint) markComplete(step Step) {
+	if !c.IsComplete(step) {
+		c.Completed = append(c.Completed, step)
+	}
+}
+
+// CheckpointStore persists a Checkpoint between runs.
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint, or an empty checkpoint if none has been saved.
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Save persists the checkpoint, replacing any previous one.
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// FileCheckpointStore is a CheckpointStore backed by a JSON file on disk, which suits CI jobs
+// that cache a workspace directory between attempts.
+type FileCheckpointStore struct {
+	Path string
+}
+
+// Load reads the checkpoint file. A missing file is treated as an empty checkpoint.
+func (s FileCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Checkpoint{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	checkpoint := new(Checkpoint)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint file atomically by writing to a temporary file and renaming it.
+func (s FileCheckpointStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps the checkpoint in memory. It is useful
+// when the caller does not need to resume across processes.
+type MemoryCheckpointStore struct {
+	checkpoint *Checkpoint
+}
+
+// Load returns the checkpoint held in memory.
+func (s *MemoryCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	if s.checkpoint == nil {
+		return &Checkpoint{}, nil
+	}
+
+	copied := *s.checkpoint
+	copied.Completed = append([]Step(nil), s.checkpoint.Completed...)
+
+	return &copied, nil
+}
+
+// Save replaces the checkpoint held in memory.
+func (s *MemoryCheckpointStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+	copied := *checkpoint
+	copied.Completed = append([]Step(nil), checkpoint.Completed...)
+	s.checkpoint = &copied
+
+	return nil
+}
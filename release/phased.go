@@ -0,0 +1,430 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const (
+	defaultPhasedReleasePollInterval = time.Hour
+	diagnosticSignaturesPageLimit    = 200
+)
+
+// MetricThreshold bounds a power or performance metric of the new build relative to the previous one.
+type MetricThreshold struct {
+	// MetricType is the metric category used to filter the request, such as "HANG" or "LAUNCH".
+	MetricType string
+	// Metric is the metric identifier within the category, such as "hangRate" or "launchTime".
+	Metric string
+	// Percentile and Device select a dataset, such as "percentile.ninety" and "all_iPhones".
+	// When empty, the first dataset reported for the metric is used.
+	Percentile string
+	Device     string
+	// MaxRegression is the largest allowed relative increase over the previous build, where 0.1
+	// means 10%. Zero disables the comparison.
+	MaxRegression float64
+	// MaxValue, when set, is an absolute ceiling for the new build regardless of the previous one.
+	MaxValue *float64
+}
+
+func (t MetricThreshold) name() string {
+	return fmt.Sprintf("%s/%s", t.MetricType, t.Metric)
+}
+
+// DiagnosticThreshold bounds the diagnostic signatures that are new in the new build.
+type DiagnosticThreshold struct {
+	// DiagnosticType is the diagnostic type used to filter the request, such as "HANGS" or "DISK_WRITES".
+	DiagnosticType string
+	// MaxNewWeight is the largest allowed combined weight of signatures that appear in the new
+	// build but not in the previous one.
+	MaxNewWeight float64
+}
+
+func (t DiagnosticThreshold) name() string {
+	return fmt.Sprintf("diagnostics/%s", t.DiagnosticType)
+}
+
+// Violation describes a threshold crossed by the new build.
+type Violation struct {
+	Threshold string  `json:"threshold"`
+	Current   float64 `json:"current"`
+	Previous  float64 `json:"previous,omitempty"`
+	Limit     float64 `json:"limit"`
+	Reason    string  `json:"reason"`
+}
+
+// PhasedReleaseConfig describes the phased release to watch and when to pause it.
+type PhasedReleaseConfig struct {
+	// AppStoreVersionID is the App Store version being rolled out.
+	AppStoreVersionID string
+	// BuildID is the build being rolled out. When empty, the build attached to the version is used.
+	BuildID string
+	// PreviousBuildID is the build of the last release, used as the baseline for comparisons.
+	PreviousBuildID string
+	// Platform filters the metrics request, such as "IOS". When empty, every platform is considered.
+	Platform string
+	// MinDay is the first day of the phased release on which thresholds are evaluated, since
+	// metrics from the first days come from very few devices.
+	MinDay int
+
+	Metrics     []MetricThreshold
+	Diagnostics []DiagnosticThreshold
+
+	// DryRun records when the release would have been paused instead of pausing it.
+	DryRun bool
+	// PollInterval is how often Watch checks the release. Defaults to one hour.
+	PollInterval time.Duration
+}
+
+func (c PhasedReleaseConfig) validate() error {
+	switch {
+	case c.AppStoreVersionID == "":
+		return ErrInvalidConfig{Field: "AppStoreVersionID"}
+	case c.PreviousBuildID == "":
+		return ErrInvalidConfig{Field: "PreviousBuildID"}
+	case len(c.Metrics) == 0 && len(c.Diagnostics) == 0:
+		return ErrInvalidConfig{Field: "Metrics or Diagnostics"}
+	}
+
+	return nil
+}
+
+// PhasedReleaseController watches a phased release and pauses it when the new build regresses
+// against the previous build. Every check is written to the audit log.
+type PhasedReleaseController struct {
+	client *asc.Client
+	config PhasedReleaseConfig
+	audit  AuditLog
+
+	// Logf, if set, receives a line for each check.
+	Logf func(format string, args ...interface{})
+
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// NewPhasedReleaseController creates a controller for the given phased release. If audit is nil,
+// entries are only kept in memory.
+func NewPhasedReleaseController(client *asc.Client, config PhasedReleaseConfig, audit AuditLog) *PhasedReleaseController {
+	if audit == nil {
+		audit = &MemoryAuditLog{}
+	}
+
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPhasedReleasePollInterval
+	}
+
+	return &PhasedReleaseController{
+		client: client,
+		config: config,
+		audit:  audit,
+		sleep:  ascutil.Sleep,
+		now:    time.Now,
+	}
+}
+
+// Watch checks the phased release every PollInterval until it completes, the controller pauses it,
+// or the context is cancelled. It returns the last audit entry.
+func (c *PhasedReleaseController) Watch(ctx context.Context) (*AuditEntry, error) {
+	for {
+		entry, err := c.Check(ctx)
+		if err != nil {
+			return entry, err
+		}
+
+		if entry.Action == AuditActionPaused || entry.State == asc.PhasedReleaseStateComplete {
+			return entry, nil
+		}
+
+		if err := c.sleep(ctx, c.config.PollInterval); err != nil {
+			return entry, err
+		}
+	}
+}
+
+// Check evaluates the thresholds once, pauses the phased release if any are crossed and the
+// controller is not in dry-run mode, and records the outcome in the audit log.
+func (c *PhasedReleaseController) Check(ctx context.Context) (*AuditEntry, error) {
+	if err := c.config.validate(); err != nil {
+		return nil, err
+	}
+
+	phased, _, err := c.client.Publishing.GetAppStoreVersionPhasedReleaseForAppStoreVersion(ctx, c.config.AppStoreVersionID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &AuditEntry{
+		Time:              c.now(),
+		AppStoreVersionID: c.config.AppStoreVersionID,
+		PhasedReleaseID:   phased.Data.ID,
+		PreviousBuildID:   c.config.PreviousBuildID,
+		DryRun:            c.config.DryRun,
+	}
+
+	if attributes := phased.Data.Attributes; attributes != nil {
+		if attributes.CurrentDayNumber != nil {
+			entry.Day = *attributes.CurrentDayNumber
+		}
+
+		if attributes.PhasedReleaseState != nil {
+			entry.State = *attributes.PhasedReleaseState
+		}
+	}
+
+	switch {
+	case entry.State != asc.PhasedReleaseStateActive:
+		entry.Action = AuditActionSkipped
+		entry.Notes = append(entry.Notes, fmt.Sprintf("phased release is %s", entry.State))
+	case entry.Day < c.config.MinDay:
+		entry.Action = AuditActionSkipped
+		entry.Notes = append(entry.Notes, fmt.Sprintf("day %d is before day %d", entry.Day, c.config.MinDay))
+	default:
+		if err := c.evaluate(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if entry.Action == AuditActionPaused {
+		paused := asc.PhasedReleaseStatePaused
+		if _, _, err := c.client.Publishing.UpdatePhasedRelease(ctx, entry.PhasedReleaseID, &paused); err != nil {
+			return nil, err
+		}
+
+		entry.State = paused
+	}
+
+	c.logf("day %d: %s (%d violations)", entry.Day, entry.Action, len(entry.Violations))
+
+	if err := c.audit.Record(ctx, *entry); err != nil {
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+func (c *PhasedReleaseController) evaluate(ctx context.Context, entry *AuditEntry) error {
+	buildID, err := c.buildID(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry.BuildID = buildID
+
+	if len(c.config.Metrics) > 0 {
+		if err := c.evaluateMetrics(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	for _, threshold := range c.config.Diagnostics {
+		if err := c.evaluateDiagnostics(ctx, entry, threshold); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case len(entry.Violations) == 0:
+		entry.Action = AuditActionObserved
+	case c.config.DryRun:
+		entry.Action = AuditActionWouldPause
+	default:
+		entry.Action = AuditActionPaused
+	}
+
+	return nil
+}
+
+func (c *PhasedReleaseController) buildID(ctx context.Context) (string, error) {
+	if c.config.BuildID != "" {
+		return c.config.BuildID, nil
+	}
+
+	res, _, err := c.client.Apps.GetBuildIDForAppStoreVersion(ctx, c.config.AppStoreVersionID)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Data.ID, nil
+}
+
+func (c *PhasedReleaseController) evaluateMetrics(ctx context.Context, entry *AuditEntry) error {
+	query := &asc.GetPerfPowerMetricsQuery{}
+	seen := make(map[string]bool)
+
+	for _, threshold := range c.config.Metrics {
+		if threshold.MetricType != "" && !seen[threshold.MetricType] {
+			seen[threshold.MetricType] = true
+			query.FilterMetricType = append(query.FilterMetricType, threshold.MetricType)
+		}
+	}
+
+	if c.config.Platform != "" {
+		query.FilterPlatform = []string{c.config.Platform}
+	}
+
+	current, _, err := c.client.Reporting.GetPerfPowerMetricsForBuild(ctx, entry.BuildID, query)
+	if err != nil {
+		return err
+	}
+
+	previous, _, err := c.client.Reporting.GetPerfPowerMetricsForBuild(ctx, c.config.PreviousBuildID, query)
+	if err != nil {
+		return err
+	}
+
+	for _, threshold := range c.config.Metrics {
+		value, ok := metricValue(current, threshold)
+		if !ok {
+			entry.Notes = append(entry.Notes, fmt.Sprintf("%s: no data for build %s", threshold.name(), entry.BuildID))
+
+			continue
+		}
+
+		if threshold.MaxValue != nil && value > *threshold.MaxValue {
+			entry.Violations = append(entry.Violations, Violation{
+				Threshold: threshold.name(),
+				Current:   value,
+				Limit:     *threshold.MaxValue,
+				Reason:    "above maximum value",
+			})
+		}
+
+		if threshold.MaxRegression == 0 {
+			continue
+		}
+
+		baseline, ok := metricValue(previous, threshold)
+		if !ok || baseline <= 0 {
+			entry.Notes = append(entry.Notes, fmt.Sprintf("%s: no baseline from build %s", threshold.name(), c.config.PreviousBuildID))
+
+			continue
+		}
+
+		if regression := (value - baseline) / baseline; regression > threshold.MaxRegression {
+			entry.Violations = append(entry.Violations, Violation{
+				Threshold: threshold.name(),
+				Current:   value,
+				Previous:  baseline,
+				Limit:     threshold.MaxRegression,
+				Reason:    fmt.Sprintf("regressed by %.1f%%", regression*100),
+			})
+		}
+	}
+
+	return nil
+}
+
+func (c *PhasedReleaseController) evaluateDiagnostics(ctx context.Context, entry *AuditEntry, threshold DiagnosticThreshold) error {
+	query := &asc.ListDiagnosticsSignaturesQuery{
+		FilterDiagnosticType: []string{threshold.DiagnosticType},
+		Limit:                diagnosticSignaturesPageLimit,
+	}
+
+	current, _, err := c.client.Reporting.ListDiagnosticSignaturesForBuild(ctx, entry.BuildID, query)
+	if err != nil {
+		return err
+	}
+
+	previous, _, err := c.client.Reporting.ListDiagnosticSignaturesForBuild(ctx, c.config.PreviousBuildID, query)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(previous.Data))
+
+	for _, signature := range previous.Data {
+		if signature.Attributes != nil && signature.Attributes.Signature != nil {
+			known[*signature.Attributes.Signature] = true
+		}
+	}
+
+	var newWeight float64
+
+	for _, signature := range current.Data {
+		if signature.Attributes == nil || signature.Attributes.Signature == nil || known[*signature.Attributes.Signature] {
+			continue
+		}
+
+		if signature.Attributes.Weight != nil {
+			newWeight += float64(*signature.Attributes.Weight)
+		}
+	}
+
+	if newWeight > threshold.MaxNewWeight {
+		entry.Violations = append(entry.Violations, Violation{
+			Threshold: threshold.name(),
+			Current:   newWeight,
+			Limit:     threshold.MaxNewWeight,
+			Reason:    "new signatures above maximum weight",
+		})
+	}
+
+	return nil
+}
+
+func (c *PhasedReleaseController) logf(format string, args ...interface{}) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+// metricValue finds the most recent point of the metric in the dataset selected by the threshold.
+func metricValue(res *asc.PerfPowerMetricsResponse, threshold MetricThreshold) (float64, bool) {
+	for _, product := range res.ProductData {
+		for _, category := range product.MetricCategories {
+			for _, metric := range category.Metrics {
+				if metric.Identifier != threshold.Metric {
+					continue
+				}
+
+				for _, dataset := range metric.Datasets {
+					if len(dataset.Points) == 0 || !matchesDataset(dataset, threshold) {
+						continue
+					}
+
+					return dataset.Points[len(dataset.Points)-1].Value, true
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func matchesDataset(dataset asc.XcodeMetricDataset, threshold MetricThreshold) bool {
+	if threshold.Percentile == "" && threshold.Device == "" {
+		return true
+	}
+
+	if dataset.FilterCriteria == nil {
+		return false
+	}
+
+	return (threshold.Percentile == "" || dataset.FilterCriteria.Percentile == threshold.Percentile) &&
+		(threshold.Device == "" || dataset.FilterCriteria.Device == threshold.Device)
+}
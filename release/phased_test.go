@@ -0,0 +1,178 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func hangRateMetrics(value float64) string {
+	return fmt.Sprintf(`{"productData":[{"platform":"IOS","metricCategories":[{"identifier":"HANG","metrics":[{"identifier":"hangRate","datasets":[{"filterCriteria":{"percentile":"percentile.fifty","device":"all_iPhones"},"points":[{"version":"2.1.0","value":%v}]}]}]}]}]}`, value)
+}
+
+func phasedReleaseBody(state string, day int) string {
+	return fmt.Sprintf(`{"data":{"id":"phased","attributes":{"phasedReleaseState":"%s","currentDayNumber":%d}}}`, state, day)
+}
+
+func testPhasedReleaseConfig() PhasedReleaseConfig {
+	return PhasedReleaseConfig{
+		AppStoreVersionID: "version",
+		BuildID:           "new",
+		PreviousBuildID:   "old",
+		MinDay:            2,
+		Metrics: []MetricThreshold{
+			{MetricType: "HANG", Metric: "hangRate", Percentile: "percentile.fifty", MaxRegression: 0.2},
+		},
+		Diagnostics: []DiagnosticThreshold{
+			{DiagnosticType: "HANGS", MaxNewWeight: 5},
+		},
+	}
+}
+
+func newPhasedReleaseAPI(state string, day int, current, previous float64) *apitest.Queue {
+	api := apitest.NewQueue()
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease", 200, phasedReleaseBody(state, day))
+	api.On("GET /v1/builds/new/perfPowerMetrics", 200, hangRateMetrics(current))
+	api.On("GET /v1/builds/old/perfPowerMetrics", 200, hangRateMetrics(previous))
+	api.On("GET /v1/builds/new/diagnosticSignatures", 200, `{"data":[{"id":"1","attributes":{"signature":"main","weight":30}},{"id":"2","attributes":{"signature":"fresh","weight":2}}]}`)
+	api.On("GET /v1/builds/old/diagnosticSignatures", 200, `{"data":[{"id":"1","attributes":{"signature":"main","weight":40}}]}`)
+	api.On("PATCH /v1/appStoreVersionPhasedReleases/phased", 200, phasedReleaseBody("PAUSED", day))
+
+	return api
+}
+
+func TestPhasedReleaseControllerObserves(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 3, 1.1, 1.0)
+	audit := &MemoryAuditLog{}
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), testPhasedReleaseConfig(), audit)
+
+	entry, err := controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionObserved, entry.Action)
+	assert.Empty(t, entry.Violations)
+	assert.Equal(t, 3, entry.Day)
+	assert.Equal(t, 0, api.Count("PATCH /v1/appStoreVersionPhasedReleases/phased"))
+	assert.Len(t, audit.Entries(), 1)
+}
+
+func TestPhasedReleaseControllerPausesOnRegression(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 3, 1.5, 1.0)
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), testPhasedReleaseConfig(), nil)
+
+	entry, err := controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionPaused, entry.Action)
+	assert.Equal(t, asc.PhasedReleaseStatePaused, entry.State)
+	assert.Len(t, entry.Violations, 1)
+	assert.Equal(t, "HANG/hangRate", entry.Violations[0].Threshold)
+	assert.Equal(t, 1, api.Count("PATCH /v1/appStoreVersionPhasedReleases/phased"))
+}
+
+func TestPhasedReleaseControllerPausesOnNewSignatures(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 3, 1.0, 1.0)
+	config := testPhasedReleaseConfig()
+	config.Diagnostics[0].MaxNewWeight = 1
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), config, nil)
+
+	entry, err := controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionPaused, entry.Action)
+	assert.Equal(t, []Violation{{Threshold: "diagnostics/HANGS", Current: 2, Limit: 1, Reason: "new signatures above maximum weight"}}, entry.Violations)
+}
+
+func TestPhasedReleaseControllerDryRun(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 3, 1.0, 1.0)
+	config := testPhasedReleaseConfig()
+	config.DryRun = true
+	config.Metrics[0].MaxValue = asc.Float(0.5)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), config, FileAuditLog{Path: path})
+
+	entry, err := controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionWouldPause, entry.Action)
+	assert.True(t, entry.DryRun)
+	assert.Equal(t, asc.PhasedReleaseStateActive, entry.State)
+	assert.Equal(t, 0, api.Count("PATCH /v1/appStoreVersionPhasedReleases/phased"))
+	assert.FileExists(t, path)
+}
+
+func TestPhasedReleaseControllerSkips(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 1, 9, 1)
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), testPhasedReleaseConfig(), nil)
+
+	entry, err := controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionSkipped, entry.Action)
+	assert.Equal(t, 0, api.Count("GET /v1/builds/new/perfPowerMetrics"))
+
+	api = newPhasedReleaseAPI("PAUSED", 4, 9, 1)
+	controller = NewPhasedReleaseController(apitest.NewClient(t, api), testPhasedReleaseConfig(), nil)
+
+	entry, err = controller.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionSkipped, entry.Action)
+}
+
+func TestPhasedReleaseControllerWatchStopsWhenComplete(t *testing.T) {
+	t.Parallel()
+
+	api := newPhasedReleaseAPI("ACTIVE", 5, 1.0, 1.0)
+	api.Clear("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease")
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease", 200, phasedReleaseBody("ACTIVE", 5))
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease", 200, phasedReleaseBody("COMPLETE", 7))
+
+	audit := &MemoryAuditLog{}
+	controller := NewPhasedReleaseController(apitest.NewClient(t, api), testPhasedReleaseConfig(), audit)
+	controller.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	entry, err := controller.Watch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, asc.PhasedReleaseStateComplete, entry.State)
+	assert.Len(t, audit.Entries(), 2)
+}
+
+func TestPhasedReleaseControllerRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	controller := NewPhasedReleaseController(apitest.NewClient(t, apitest.NewQueue()), PhasedReleaseConfig{AppStoreVersionID: "version"}, nil)
+
+	_, err := controller.Check(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "PreviousBuildID"}, err)
+}
@@ -0,0 +1,592 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package release orchestrates an App Store release end-to-end on top of the services in package asc.
+
+A release is a fixed sequence of steps: find or create the App Store version, wait for the build
+to finish processing, attach the build, apply localized metadata and App Review details, configure
+phased release, and submit for review. Every step first reads the current state from App Store
+Connect and only writes what is missing, so running the same release twice is safe. Progress is
+recorded in a Checkpoint after each step so a crashed job can resume where it stopped:
+
+	client := asc.NewClient(auth.Client())
+	orchestrator := release.New(client, release.Config{
+		AppID:         "1234567890",
+		Platform:      asc.PlatformIOS,
+		VersionString: "2.1.0",
+		BuildNumber:   "417",
+		PhasedRelease: true,
+		Submit:        true,
+	}, release.FileCheckpointStore{Path: "release-2.1.0.json"})
+
+	checkpoint, err := orchestrator.Run(ctx)
+
+Once the version is live, a PhasedReleaseController can watch its phased release and pause it
+automatically when the new build's metrics or diagnostics regress against the previous build.
+*/
+package release
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+const (
+	defaultBuildPollInterval = 30 * time.Second
+	defaultBuildTimeout      = time.Hour
+	localizationsPageLimit   = 200
+)
+
+// Step identifies one stage of a release.
+type Step string
+
+const (
+	// StepVersion finds or creates the App Store version.
+	StepVersion Step = "version"
+	// StepBuild waits for the build to finish processing.
+	StepBuild Step = "build"
+	// StepAttachBuild attaches the build to the App Store version.
+	StepAttachBuild Step = "attachBuild"
+	// StepLocalizations creates or updates the version's localized metadata.
+	StepLocalizations Step = "localizations"
+	// StepReviewDetail creates or updates the App Review details.
+	StepReviewDetail Step = "reviewDetail"
+	// StepPhasedRelease enables phased release for the version.
+	StepPhasedRelease Step = "phasedRelease"
+	// StepSubmit adds the version to a review submission and submits it to App Review.
+	StepSubmit Step = "submit"
+)
+
+// Steps is the order in which a release runs its steps.
+var Steps = []Step{
+	StepVersion,
+	StepBuild,
+	StepAttachBuild,
+	StepLocalizations,
+	StepReviewDetail,
+	StepPhasedRelease,
+	StepSubmit,
+}
+
+// StepError wraps an error that occurred while running a particular step.
+type StepError struct {
+	Step Step
+	Err  error
+}
+
+func (e StepError) Error() string {
+	return fmt.Sprintf("release step %s failed: %s", e.Step, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e StepError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidConfig is returned when a Config is missing required values.
+type ErrInvalidConfig struct {
+	Field string
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("release config: %s is required", e.Field)
+}
+
+// ErrBuildProcessing is returned when a build does not become valid before the configured timeout,
+// or when App Store Connect reports that processing has failed.
+type ErrBuildProcessing struct {
+	BuildNumber string
+	State       string
+}
+
+func (e ErrBuildProcessing) Error() string {
+	if e.State == "" {
+		return fmt.Sprintf("build %s was not found before the timeout elapsed", e.BuildNumber)
+	}
+
+	return fmt.Sprintf("build %s is in processing state %s", e.BuildNumber, e.State)
+}
+
+// Config describes the release to perform.
+type Config struct {
+	// AppID is the App Store Connect ID of the app being released.
+	AppID string
+	// Platform is the platform of the App Store version.
+	Platform asc.Platform
+	// VersionString is the user-facing version, such as "2.1.0".
+	VersionString string
+	// BuildNumber is the build's CFBundleVersion.
+	BuildNumber string
+
+	// Copyright, ReleaseType and EarliestReleaseDate are applied to the App Store version when set.
+	Copyright           *string
+	ReleaseType         *string
+	EarliestReleaseDate *asc.DateTime
+
+	// Localizations maps locales, such as "en-US", to the metadata they should have. Locales that
+	// do not yet exist on the version are created. Locales not listed are left untouched.
+	Localizations map[string]asc.AppStoreVersionLocalizationUpdateRequestAttributes
+	// ReviewDetail is the contact and demo account information for App Review, when set.
+	ReviewDetail *asc.AppStoreReviewDetailUpdateRequestAttributes
+	// PhasedRelease enables a seven-day phased release for automatic updates.
+	PhasedRelease bool
+	// Submit submits the version to App Review as the final step.
+	Submit bool
+
+	// BuildPollInterval is how often to check the build's processing state. Defaults to 30 seconds.
+	BuildPollInterval time.Duration
+	// BuildTimeout is how long to wait for the build to become valid. Defaults to one hour.
+	BuildTimeout time.Duration
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.AppID == "":
+		return ErrInvalidConfig{Field: "AppID"}
+	case c.Platform == "":
+		return ErrInvalidConfig{Field: "Platform"}
+	case c.VersionString == "":
+		return ErrInvalidConfig{Field: "VersionString"}
+	case c.BuildNumber == "":
+		return ErrInvalidConfig{Field: "BuildNumber"}
+	}
+
+	for _, attributes := range c.Localizations {
+		if err := attributes.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Orchestrator runs the steps of a release.
+type Orchestrator struct {
+	client *asc.Client
+	config Config
+	store  CheckpointStore
+
+	// Logf, if set, receives a line of progress for each step.
+	Logf func(format string, args ...interface{})
+
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// New creates an Orchestrator for the given release. If store is nil, progress is only
+// kept in memory for the lifetime of the Orchestrator.
+func New(client *asc.Client, config Config, store CheckpointStore) *Orchestrator {
+	if store == nil {
+		store = &MemoryCheckpointStore{}
+	}
+
+	if config.BuildPollInterval == 0 {
+		config.BuildPollInterval = defaultBuildPollInterval
+	}
+
+	if config.BuildTimeout == 0 {
+		config.BuildTimeout = defaultBuildTimeout
+	}
+
+	return &Orchestrator{
+		client: client,
+		config: config,
+		store:  store,
+		sleep:  ascutil.Sleep,
+		now:    time.Now,
+	}
+}
+
+// Run performs every step of the release that has not already been recorded as complete in the
+// checkpoint store, saving the checkpoint after each step. It returns the final checkpoint. If a step
+// fails, the returned error is a StepError and the checkpoint reflects the steps that completed.
+func (o *Orchestrator) Run(ctx context.Context) (*Checkpoint, error) {
+	if err := o.config.validate(); err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := o.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range Steps {
+		if checkpoint.IsComplete(step) {
+			o.logf("%s: already complete", step)
+
+			continue
+		}
+
+		if err := o.runStep(ctx, step, checkpoint); err != nil {
+			return checkpoint, StepError{Step: step, Err: err}
+		}
+
+		checkpoint.markComplete(step)
+
+		if err := o.store.Save(ctx, checkpoint); err != nil {
+			return checkpoint, StepError{Step: step, Err: err}
+		}
+
+		o.logf("%s: complete", step)
+	}
+
+	return checkpoint, nil
+}
+
+func (o *Orchestrator) runStep(ctx context.Context, step Step, checkpoint *Checkpoint) error {
+	switch step {
+	case StepVersion:
+		return o.ensureVersion(ctx, checkpoint)
+	case StepBuild:
+		return o.waitForBuild(ctx, checkpoint)
+	case StepAttachBuild:
+		return o.attachBuild(ctx, checkpoint)
+	case StepLocalizations:
+		return o.applyLocalizations(ctx, checkpoint)
+	case StepReviewDetail:
+		return o.applyReviewDetail(ctx, checkpoint)
+	case StepPhasedRelease:
+		return o.ensurePhasedRelease(ctx, checkpoint)
+	case StepSubmit:
+		return o.submit(ctx, checkpoint)
+	}
+
+	return fmt.Errorf("unknown step %s", step)
+}
+
+func (o *Orchestrator) ensureVersion(ctx context.Context, checkpoint *Checkpoint) error {
+	version, err := o.findVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version == nil {
+		res, _, err := o.client.Apps.CreateAppStoreVersion(ctx, asc.AppStoreVersionCreateRequestAttributes{
+			Copyright:           o.config.Copyright,
+			EarliestReleaseDate: o.config.EarliestReleaseDate,
+			Platform:            o.config.Platform,
+			ReleaseType:         o.config.ReleaseType,
+			VersionString:       o.config.VersionString,
+		}, o.config.AppID, nil)
+		if err != nil {
+			return err
+		}
+
+		o.logf("%s: created App Store version %s", StepVersion, res.Data.ID)
+		checkpoint.AppStoreVersionID = res.Data.ID
+
+		return nil
+	}
+
+	checkpoint.AppStoreVersionID = version.ID
+
+	if !isEditable(version) || !o.hasVersionAttributes() {
+		return nil
+	}
+
+	_, _, err = o.client.Apps.UpdateAppStoreVersion(ctx, version.ID, &asc.AppStoreVersionUpdateRequestAttributes{
+		Copyright:           o.config.Copyright,
+		EarliestReleaseDate: o.config.EarliestReleaseDate,
+		ReleaseType:         o.config.ReleaseType,
+	}, nil)
+
+	return err
+}
+
+func (o *Orchestrator) hasVersionAttributes() bool {
+	return o.config.Copyright != nil || o.config.ReleaseType != nil || o.config.EarliestReleaseDate != nil
+}
+
+func (o *Orchestrator) findVersion(ctx context.Context) (*asc.AppStoreVersion, error) {
+	res, _, err := o.client.Apps.ListAppStoreVersionsForApp(ctx, o.config.AppID, &asc.ListAppStoreVersionsQuery{
+		FilterVersionString: []string{o.config.VersionString},
+		FilterPlatform:      []string{string(o.config.Platform)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Data) == 0 {
+		return nil, nil
+	}
+
+	return &res.Data[0], nil
+}
+
+func (o *Orchestrator) waitForBuild(ctx context.Context, checkpoint *Checkpoint) error {
+	deadline := o.now().Add(o.config.BuildTimeout)
+
+	for {
+		build, err := o.findBuild(ctx)
+		if err != nil {
+			return err
+		}
+
+		var state string
+
+		if build != nil && build.Attributes != nil && build.Attributes.ProcessingState != nil {
+			state = *build.Attributes.ProcessingState
+		}
+
+		switch state {
+		case asc.BuildProcessingStateValid:
+			checkpoint.BuildID = build.ID
+
+			return nil
+		case asc.BuildProcessingStateFailed, asc.BuildProcessingStateInvalid:
+			return ErrBuildProcessing{BuildNumber: o.config.BuildNumber, State: state}
+		}
+
+		if !o.now().Before(deadline) {
+			return ErrBuildProcessing{BuildNumber: o.config.BuildNumber, State: state}
+		}
+
+		o.logf("%s: waiting for build %s (state %q)", StepBuild, o.config.BuildNumber, state)
+
+		if err := o.sleep(ctx, o.config.BuildPollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func (o *Orchestrator) findBuild(ctx context.Context) (*asc.Build, error) {
+	res, _, err := o.client.Builds.ListBuilds(ctx, &asc.ListBuildsQuery{
+		FilterApp:                       []string{o.config.AppID},
+		FilterVersion:                   []string{o.config.BuildNumber},
+		FilterPreReleaseVersionVersion:  []string{o.config.VersionString},
+		FilterPreReleaseVersionPlatform: []string{string(o.config.Platform)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Data) == 0 {
+		return nil, nil
+	}
+
+	return &res.Data[0], nil
+}
+
+func (o *Orchestrator) attachBuild(ctx context.Context, checkpoint *Checkpoint) error {
+	linkage, _, err := o.client.Apps.GetBuildIDForAppStoreVersion(ctx, checkpoint.AppStoreVersionID)
+	if err != nil && !ascutil.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && linkage.Data.ID == checkpoint.BuildID {
+		return nil
+	}
+
+	_, _, err = o.client.Apps.UpdateBuildForAppStoreVersion(ctx, checkpoint.AppStoreVersionID, &checkpoint.BuildID)
+
+	return err
+}
+
+func (o *Orchestrator) applyLocalizations(ctx context.Context, checkpoint *Checkpoint) error {
+	if len(o.config.Localizations) == 0 {
+		return nil
+	}
+
+	res, _, err := o.client.Apps.ListLocalizationsForAppStoreVersion(ctx, checkpoint.AppStoreVersionID, &asc.ListLocalizationsForAppStoreVersionQuery{
+		Limit: localizationsPageLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]string, len(res.Data))
+
+	for _, localization := range res.Data {
+		if localization.Attributes != nil && localization.Attributes.Locale != nil {
+			existing[*localization.Attributes.Locale] = localization.ID
+		}
+	}
+
+	for locale, attributes := range o.config.Localizations {
+		attributes := attributes
+
+		if id, ok := existing[locale]; ok {
+			if _, _, err := o.client.Apps.UpdateAppStoreVersionLocalization(ctx, id, &attributes); err != nil {
+				return fmt.Errorf("locale %s: %w", locale, err)
+			}
+
+			continue
+		}
+
+		_, _, err := o.client.Apps.CreateAppStoreVersionLocalization(ctx, asc.AppStoreVersionLocalizationCreateRequestAttributes{
+			Description:     attributes.Description,
+			Keywords:        attributes.Keywords,
+			Locale:          locale,
+			MarketingURL:    attributes.MarketingURL,
+			PromotionalText: attributes.PromotionalText,
+			SupportURL:      attributes.SupportURL,
+			WhatsNew:        attributes.WhatsNew,
+		}, checkpoint.AppStoreVersionID)
+		if err != nil {
+			return fmt.Errorf("locale %s: %w", locale, err)
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) applyReviewDetail(ctx context.Context, checkpoint *Checkpoint) error {
+	if o.config.ReviewDetail == nil {
+		return nil
+	}
+
+	res, _, err := o.client.Submission.GetReviewDetailsForAppStoreVersion(ctx, checkpoint.AppStoreVersionID, nil)
+	if ascutil.IsNotFound(err) || (err == nil && res.Data.ID == "") {
+		attributes := asc.AppStoreReviewDetailCreateRequestAttributes(*o.config.ReviewDetail)
+		_, _, err = o.client.Submission.CreateReviewDetail(ctx, &attributes, checkpoint.AppStoreVersionID)
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	_, _, err = o.client.Submission.UpdateReviewDetail(ctx, res.Data.ID, o.config.ReviewDetail)
+
+	return err
+}
+
+func (o *Orchestrator) ensurePhasedRelease(ctx context.Context, checkpoint *Checkpoint) error {
+	if !o.config.PhasedRelease {
+		return nil
+	}
+
+	res, _, err := o.client.Publishing.GetAppStoreVersionPhasedReleaseForAppStoreVersion(ctx, checkpoint.AppStoreVersionID, nil)
+	if err == nil && res.Data.ID != "" {
+		return nil
+	} else if err != nil && !ascutil.IsNotFound(err) {
+		return err
+	}
+
+	state := asc.PhasedReleaseStateInactive
+	_, _, err = o.client.Publishing.CreatePhasedRelease(ctx, &state, checkpoint.AppStoreVersionID)
+
+	return err
+}
+
+func (o *Orchestrator) submit(ctx context.Context, checkpoint *Checkpoint) error {
+	if !o.config.Submit {
+		return nil
+	}
+
+	version, _, err := o.client.Apps.GetAppStoreVersion(ctx, checkpoint.AppStoreVersionID, nil)
+	if err != nil {
+		return err
+	}
+
+	if !isEditable(&version.Data) {
+		o.logf("%s: version is already past submission", StepSubmit)
+
+		return nil
+	}
+
+	submissionID, err := o.draftReviewSubmission(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, _, err := o.client.Submission.ListItemsForReviewSubmission(ctx, submissionID, nil)
+	if err != nil {
+		return err
+	}
+
+	if !containsVersion(items.Data, checkpoint.AppStoreVersionID) {
+		_, _, err = o.client.Submission.CreateReviewSubmissionItem(ctx, submissionID, asc.ReviewSubmissionItemTypeAppStoreVersion, checkpoint.AppStoreVersionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err = o.client.Submission.SubmitReviewSubmission(ctx, submissionID)
+
+	return err
+}
+
+// draftReviewSubmission returns the ID of the app's unsubmitted review submission for the platform,
+// creating one if none exists.
+func (o *Orchestrator) draftReviewSubmission(ctx context.Context) (string, error) {
+	res, _, err := o.client.Submission.ListReviewSubmissions(ctx, &asc.ListReviewSubmissionsQuery{
+		FilterApp:      []string{o.config.AppID},
+		FilterPlatform: []string{string(o.config.Platform)},
+		FilterState:    []string{string(asc.ReviewSubmissionStateReadyForReview)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Data) > 0 {
+		return res.Data[0].ID, nil
+	}
+
+	created, _, err := o.client.Submission.CreateReviewSubmission(ctx, o.config.Platform, o.config.AppID)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Data.ID, nil
+}
+
+func containsVersion(items []asc.ReviewSubmissionItem, appStoreVersionID string) bool {
+	for _, item := range items {
+		if item.Relationships == nil || item.Relationships.AppStoreVersion == nil || item.Relationships.AppStoreVersion.Data == nil {
+			continue
+		}
+
+		if item.Relationships.AppStoreVersion.Data.ID == appStoreVersionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *Orchestrator) logf(format string, args ...interface{}) {
+	if o.Logf != nil {
+		o.Logf(format, args...)
+	}
+}
+
+// isEditable reports whether a version is in a state where its metadata can change and it can be
+// submitted, as opposed to being in review or already released.
+func isEditable(version *asc.AppStoreVersion) bool {
+	if version.Attributes == nil || version.Attributes.AppStoreState == nil {
+		return true
+	}
+
+	switch *version.Attributes.AppStoreState {
+	case asc.AppStoreVersionStatePrepareForSubmission,
+		asc.AppStoreVersionStateDeveloperRejected,
+		asc.AppStoreVersionStateRejected,
+		asc.AppStoreVersionStateMetadataRejected,
+		asc.AppStoreVersionStateInvalidBinary:
+		return true
+	}
+
+	return false
+}
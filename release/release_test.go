@@ -0,0 +1,186 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package release
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func newTestOrchestrator(client *asc.Client, config Config, store CheckpointStore) *Orchestrator {
+	o := New(client, config, store)
+	o.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	return o
+}
+
+func testConfig() Config {
+	return Config{
+		AppID:         "app",
+		Platform:      asc.PlatformIOS,
+		VersionString: "2.1.0",
+		BuildNumber:   "417",
+		Localizations: map[string]asc.AppStoreVersionLocalizationUpdateRequestAttributes{
+			"en-US": {WhatsNew: asc.String("Bug fixes")},
+			"fr-FR": {WhatsNew: asc.String("Corrections")},
+		},
+		ReviewDetail:  &asc.AppStoreReviewDetailUpdateRequestAttributes{ContactEmail: asc.String("a@example.com")},
+		PhasedRelease: true,
+		Submit:        true,
+	}
+}
+
+func TestRunCreatesEverything(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/apps/app/appStoreVersions", http.StatusOK, `{"data":[]}`)
+	api.On("POST /v1/appStoreVersions", http.StatusCreated, `{"data":{"id":"version","type":"appStoreVersions"}}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[]}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","type":"builds","attributes":{"processingState":"PROCESSING"}}]}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","type":"builds","attributes":{"processingState":"VALID"}}]}`)
+	api.On("PATCH /v1/appStoreVersions/version/relationships/build", http.StatusOK, `{"data":{"id":"build","type":"builds"}}`)
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionLocalizations", http.StatusOK, `{"data":[{"id":"loc-en","type":"appStoreVersionLocalizations","attributes":{"locale":"en-US"}}]}`)
+	api.On("PATCH /v1/appStoreVersionLocalizations/loc-en", http.StatusOK, `{"data":{"id":"loc-en"}}`)
+	api.On("POST /v1/appStoreVersionLocalizations", http.StatusCreated, `{"data":{"id":"loc-fr"}}`)
+	api.On("POST /v1/appStoreReviewDetails", http.StatusCreated, `{"data":{"id":"review"}}`)
+	api.On("POST /v1/appStoreVersionPhasedReleases", http.StatusCreated, `{"data":{"id":"phased"}}`)
+	api.On("GET /v1/appStoreVersions/version", http.StatusOK, `{"data":{"id":"version","attributes":{"appStoreState":"PREPARE_FOR_SUBMISSION"}}}`)
+	api.On("GET /v1/reviewSubmissions", http.StatusOK, `{"data":[]}`)
+	api.On("POST /v1/reviewSubmissions", http.StatusCreated, `{"data":{"id":"submission"}}`)
+	api.On("GET /v1/reviewSubmissions/submission/items", http.StatusOK, `{"data":[]}`)
+	api.On("POST /v1/reviewSubmissionItems", http.StatusCreated, `{"data":{"id":"item"}}`)
+	api.On("PATCH /v1/reviewSubmissions/submission", http.StatusOK, `{"data":{"id":"submission"}}`)
+
+	store := &MemoryCheckpointStore{}
+	checkpoint, err := newTestOrchestrator(apitest.NewClient(t, api), testConfig(), store).Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "version", checkpoint.AppStoreVersionID)
+	assert.Equal(t, "build", checkpoint.BuildID)
+	assert.Equal(t, Steps, checkpoint.Completed)
+	assert.Equal(t, 3, api.Count("GET /v1/builds"))
+	assert.Equal(t, 1, api.Count("PATCH /v1/appStoreVersionLocalizations/loc-en"))
+	assert.Equal(t, 1, api.Count("POST /v1/appStoreVersionLocalizations"))
+	assert.Equal(t, 1, api.Count("POST /v1/reviewSubmissionItems"))
+	assert.Equal(t, 1, api.Count("PATCH /v1/reviewSubmissions/submission"))
+}
+
+func TestRunIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/apps/app/appStoreVersions", http.StatusOK, `{"data":[{"id":"version","attributes":{"appStoreState":"WAITING_FOR_REVIEW"}}]}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","attributes":{"processingState":"VALID"}}]}`)
+	api.On("GET /v1/appStoreVersions/version/relationships/build", http.StatusOK, `{"data":{"id":"build","type":"builds"}}`)
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionLocalizations", http.StatusOK, `{"data":[{"id":"loc-en","attributes":{"locale":"en-US"}},{"id":"loc-fr","attributes":{"locale":"fr-FR"}}]}`)
+	api.On("PATCH /v1/appStoreVersionLocalizations/loc-en", http.StatusOK, `{"data":{"id":"loc-en"}}`)
+	api.On("PATCH /v1/appStoreVersionLocalizations/loc-fr", http.StatusOK, `{"data":{"id":"loc-fr"}}`)
+	api.On("GET /v1/appStoreVersions/version/appStoreReviewDetail", http.StatusOK, `{"data":{"id":"review"}}`)
+	api.On("PATCH /v1/appStoreReviewDetails/review", http.StatusOK, `{"data":{"id":"review"}}`)
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease", http.StatusOK, `{"data":{"id":"phased"}}`)
+	api.On("GET /v1/appStoreVersions/version", http.StatusOK, `{"data":{"id":"version","attributes":{"appStoreState":"WAITING_FOR_REVIEW"}}}`)
+
+	checkpoint, err := newTestOrchestrator(apitest.NewClient(t, api), testConfig(), nil).Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, Steps, checkpoint.Completed)
+	assert.Equal(t, 0, api.Count("POST /v1/appStoreVersions"))
+	assert.Equal(t, 0, api.Count("PATCH /v1/appStoreVersions/version"))
+	assert.Equal(t, 0, api.Count("PATCH /v1/appStoreVersions/version/relationships/build"))
+	assert.Equal(t, 0, api.Count("POST /v1/appStoreVersionPhasedReleases"))
+	assert.Equal(t, 0, api.Count("POST /v1/reviewSubmissions"))
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/appStoreVersions/version/appStoreVersionPhasedRelease", http.StatusOK, `{"data":{"id":"phased"}}`)
+	api.On("GET /v1/appStoreVersions/version", http.StatusOK, `{"data":{"id":"version","attributes":{"appStoreState":"PREPARE_FOR_SUBMISSION"}}}`)
+	api.On("GET /v1/reviewSubmissions", http.StatusOK, `{"data":[{"id":"submission"}]}`)
+	api.On("GET /v1/reviewSubmissions/submission/items", http.StatusOK, `{"data":[{"id":"item","relationships":{"appStoreVersion":{"data":{"id":"version","type":"appStoreVersions"}}}}]}`)
+	api.On("PATCH /v1/reviewSubmissions/submission", http.StatusConflict, `{"errors":[{"status":"409"}]}`)
+
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+	err := store.Save(context.Background(), &Checkpoint{
+		AppStoreVersionID: "version",
+		BuildID:           "build",
+		Completed:         []Step{StepVersion, StepBuild, StepAttachBuild, StepLocalizations, StepReviewDetail},
+	})
+	assert.NoError(t, err)
+
+	_, err = newTestOrchestrator(apitest.NewClient(t, api), testConfig(), store).Run(context.Background())
+
+	var stepErr StepError
+
+	assert.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, StepSubmit, stepErr.Step)
+	assert.Equal(t, 0, api.Count("GET /v1/apps/app/appStoreVersions"))
+	assert.Equal(t, 0, api.Count("POST /v1/reviewSubmissionItems"))
+
+	saved, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, saved.IsComplete(StepPhasedRelease))
+	assert.False(t, saved.IsComplete(StepSubmit))
+}
+
+func TestRunFailsOnInvalidBuild(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.NewQueue()
+	api.On("GET /v1/apps/app/appStoreVersions", http.StatusOK, `{"data":[{"id":"version"}]}`)
+	api.On("GET /v1/builds", http.StatusOK, `{"data":[{"id":"build","attributes":{"processingState":"INVALID"}}]}`)
+
+	_, err := newTestOrchestrator(apitest.NewClient(t, api), testConfig(), nil).Run(context.Background())
+
+	var processingErr ErrBuildProcessing
+
+	assert.True(t, errors.As(err, &processingErr))
+	assert.Equal(t, asc.BuildProcessingStateInvalid, processingErr.State)
+}
+
+func TestRunRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	config.BuildNumber = ""
+
+	_, err := New(asc.NewClient(nil), config, nil).Run(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "BuildNumber"}, err)
+
+	config = testConfig()
+	config.Localizations["en-US"] = asc.AppStoreVersionLocalizationUpdateRequestAttributes{Keywords: asc.String("a,a")}
+
+	_, err = New(asc.NewClient(nil), config, nil).Run(context.Background())
+
+	var validationErr asc.ErrInvalidLocalization
+
+	assert.True(t, errors.As(err, &validationErr))
+}
@@ -0,0 +1,97 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package retention
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+// AuditAction describes what an Engine did with a build.
+type AuditAction string
+
+const (
+	// AuditActionKept means the build was left alone.
+	AuditActionKept AuditAction = "kept"
+	// AuditActionExpired means the build was expired.
+	AuditActionExpired AuditAction = "expired"
+	// AuditActionWouldExpire means the build would have been expired, but the engine is in dry-run mode.
+	AuditActionWouldExpire AuditAction = "wouldExpire"
+	// AuditActionFailed means expiring the build was attempted and failed.
+	AuditActionFailed AuditAction = "failed"
+)
+
+// AuditEntry records the decision made for one build.
+type AuditEntry struct {
+	Time              time.Time   `json:"time"`
+	AppID             string      `json:"appId"`
+	BuildID           string      `json:"buildId"`
+	BuildNumber       string      `json:"buildNumber,omitempty"`
+	PrereleaseVersion string      `json:"prereleaseVersion,omitempty"`
+	Platform          string      `json:"platform,omitempty"`
+	UploadedDate      *time.Time  `json:"uploadedDate,omitempty"`
+	Action            AuditAction `json:"action"`
+	Reason            string      `json:"reason"`
+	DryRun            bool        `json:"dryRun,omitempty"`
+	Error             string      `json:"error,omitempty"`
+}
+
+// AuditLog receives an entry for every build an Engine considers.
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// FileAuditLog writes the decisions to a file, one JSON object per line, so that a scheduled run
+// leaves a history of what it expired.
+type FileAuditLog struct {
+	Path string
+}
+
+// Record adds the entry to the end of the file at Path.
+func (l FileAuditLog) Record(ctx context.Context, entry AuditEntry) error {
+	return ascutil.AppendJSONLine(l.Path, entry)
+}
+
+// MemoryAuditLog holds the decisions in memory, for dry runs that print them afterwards.
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// Record keeps the entry.
+func (l *MemoryAuditLog) Record(ctx context.Context, entry AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+
+	return nil
+}
+
+// Entries returns the decisions recorded so far, in the order they were made.
+func (l *MemoryAuditLog) Entries() []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]AuditEntry(nil), l.entries...)
+}
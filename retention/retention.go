@@ -0,0 +1,406 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package retention expires old TestFlight builds according to a policy, so that apps with hundreds of
+uploads keep only the builds that testers still need.
+
+An Engine lists the app's unexpired builds newest first, groups them by prerelease version and
+decides for each build whether to keep or expire it. A build is expired when it is not among the
+newest KeepPerVersion builds of its prerelease version, or when it is older than MaxAge; either rule
+is enough. Builds attached to an App Store version, or available to an external beta group that has
+testers, are never expired. Every decision is written to an AuditLog, and DryRun previews the
+decisions without changing anything:
+
+	engine := retention.NewEngine(client, retention.Config{
+		AppID:          "1234567890",
+		KeepPerVersion: 5,
+		MaxAge:         30 * 24 * time.Hour,
+		DryRun:         true,
+	}, retention.FileAuditLog{Path: "retention.jsonl"})
+
+	entries, err := engine.Run(ctx)
+*/
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const pageLimit = 200
+
+// ErrInvalidConfig is returned when a Config is missing a required field.
+type ErrInvalidConfig struct {
+	Field string
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("retention config: %s is required", e.Field)
+}
+
+// ErrExpire is returned by Run when some builds could not be expired. The failed builds are also
+// recorded in the audit log with AuditActionFailed.
+type ErrExpire struct {
+	Failures map[string]error
+}
+
+func (e ErrExpire) Error() string {
+	return fmt.Sprintf("%d builds could not be expired", len(e.Failures))
+}
+
+// Config is the retention policy for an app.
+type Config struct {
+	// AppID is the app whose builds are considered.
+	AppID string
+	// KeepPerVersion expires every build of a prerelease version but the newest KeepPerVersion.
+	// Zero disables the rule.
+	KeepPerVersion int
+	// MaxAge expires builds uploaded longer ago than MaxAge, even among the newest KeepPerVersion.
+	// Zero disables the rule.
+	MaxAge time.Duration
+	// DryRun records the builds that would be expired instead of expiring them.
+	DryRun bool
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.AppID == "":
+		return ErrInvalidConfig{Field: "AppID"}
+	case c.KeepPerVersion <= 0 && c.MaxAge <= 0:
+		return ErrInvalidConfig{Field: "KeepPerVersion or MaxAge"}
+	}
+
+	return nil
+}
+
+// Decision is what the policy decided for a single build.
+type Decision struct {
+	Build             asc.Build
+	PrereleaseVersion string
+	Platform          string
+	Expire            bool
+	Reason            string
+}
+
+// Engine applies a retention policy to the builds of an app.
+type Engine struct {
+	client *asc.Client
+	config Config
+	audit  AuditLog
+
+	// Logf, if set, receives a line for each build that is expired.
+	Logf func(format string, args ...interface{})
+
+	now func() time.Time
+}
+
+// NewEngine creates an engine for the given policy. If audit is nil, entries are only kept in memory.
+func NewEngine(client *asc.Client, config Config, audit AuditLog) *Engine {
+	if audit == nil {
+		audit = &MemoryAuditLog{}
+	}
+
+	return &Engine{
+		client: client,
+		config: config,
+		audit:  audit,
+		now:    time.Now,
+	}
+}
+
+// Plan decides which builds to keep and which to expire without changing anything or writing to
+// the audit log. Decisions are ordered newest build first.
+func (e *Engine) Plan(ctx context.Context) ([]Decision, error) {
+	if err := e.config.validate(); err != nil {
+		return nil, err
+	}
+
+	builds, versions, err := e.listBuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := e.protectedBuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := e.now()
+	ranks := make(map[string]int)
+	decisions := make([]Decision, 0, len(builds))
+
+	for _, build := range builds {
+		decision := Decision{Build: build}
+
+		versionID := ""
+		if build.Relationships != nil && build.Relationships.PreReleaseVersion != nil && build.Relationships.PreReleaseVersion.Data != nil {
+			versionID = build.Relationships.PreReleaseVersion.Data.ID
+		}
+
+		if version, ok := versions[versionID]; ok && version.Attributes != nil {
+			if version.Attributes.Version != nil {
+				decision.PrereleaseVersion = *version.Attributes.Version
+			}
+
+			if version.Attributes.Platform != nil {
+				decision.Platform = string(*version.Attributes.Platform)
+			}
+		}
+
+		rank := ranks[versionID]
+		ranks[versionID]++
+
+		decision.Expire, decision.Reason = e.decide(build, rank, protected, now)
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+// Run plans the policy, records every decision in the audit log and expires the builds the policy
+// selects, unless DryRun is set. Every selected build is attempted even after one fails to expire;
+// the builds that could not be expired are gathered into an ErrExpire.
+func (e *Engine) Run(ctx context.Context) ([]AuditEntry, error) {
+	decisions, err := e.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]AuditEntry, 0, len(decisions))
+	failures := make(map[string]error)
+
+	for _, decision := range decisions {
+		if err := ctx.Err(); err != nil {
+			return entries, err
+		}
+
+		entry := AuditEntry{
+			Time:              e.now(),
+			AppID:             e.config.AppID,
+			BuildID:           decision.Build.ID,
+			PrereleaseVersion: decision.PrereleaseVersion,
+			Platform:          decision.Platform,
+			Action:            AuditActionKept,
+			Reason:            decision.Reason,
+			DryRun:            e.config.DryRun,
+		}
+
+		if attributes := decision.Build.Attributes; attributes != nil {
+			if attributes.Version != nil {
+				entry.BuildNumber = *attributes.Version
+			}
+
+			if attributes.UploadedDate != nil {
+				uploaded := attributes.UploadedDate.Time
+				entry.UploadedDate = &uploaded
+			}
+		}
+
+		switch {
+		case !decision.Expire:
+		case e.config.DryRun:
+			entry.Action = AuditActionWouldExpire
+		default:
+			if _, _, err := e.client.Builds.UpdateBuild(ctx, decision.Build.ID, asc.Bool(true), nil, nil); err != nil {
+				entry.Action = AuditActionFailed
+				entry.Error = err.Error()
+				failures[decision.Build.ID] = err
+			} else {
+				entry.Action = AuditActionExpired
+			}
+		}
+
+		if entry.Action != AuditActionKept {
+			e.logf("build %s (%s %s): %s, %s", entry.BuildID, entry.PrereleaseVersion, entry.BuildNumber, entry.Action, entry.Reason)
+		}
+
+		if err := e.audit.Record(ctx, entry); err != nil {
+			return entries, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(failures) > 0 {
+		return entries, ErrExpire{Failures: failures}
+	}
+
+	return entries, nil
+}
+
+// decide applies the rules to a build. rank is the number of newer builds in the same prerelease version.
+func (e *Engine) decide(build asc.Build, rank int, protected map[string]string, now time.Time) (bool, string) {
+	if build.Relationships != nil && build.Relationships.AppStoreVersion != nil && build.Relationships.AppStoreVersion.Data != nil {
+		return false, fmt.Sprintf("attached to App Store version %s", build.Relationships.AppStoreVersion.Data.ID)
+	}
+
+	if group, ok := protected[build.ID]; ok {
+		return false, fmt.Sprintf("available to external beta group %s", group)
+	}
+
+	if e.config.KeepPerVersion > 0 && rank >= e.config.KeepPerVersion {
+		return true, fmt.Sprintf("not among the newest %d builds of its prerelease version", e.config.KeepPerVersion)
+	}
+
+	if e.config.MaxAge > 0 && build.Attributes != nil && build.Attributes.UploadedDate != nil {
+		if age := now.Sub(build.Attributes.UploadedDate.Time); age > e.config.MaxAge {
+			return true, fmt.Sprintf("uploaded more than %s ago", e.config.MaxAge)
+		}
+	}
+
+	if e.config.KeepPerVersion > 0 {
+		return false, fmt.Sprintf("among the newest %d builds of its prerelease version", e.config.KeepPerVersion)
+	}
+
+	if build.Attributes == nil || build.Attributes.UploadedDate == nil {
+		return false, "upload date is unknown"
+	}
+
+	return false, fmt.Sprintf("uploaded less than %s ago", e.config.MaxAge)
+}
+
+// listBuilds pages through the app's unexpired builds, newest first, along with their prerelease versions
+// keyed by ID. ListBuildsForApp cannot sort or include related resources, so the top-level builds
+// endpoint is filtered by app instead.
+func (e *Engine) listBuilds(ctx context.Context) ([]asc.Build, map[string]*asc.PrereleaseVersion, error) {
+	var builds []asc.Build
+
+	versions := make(map[string]*asc.PrereleaseVersion)
+	query := &asc.ListBuildsQuery{
+		FilterApp:     []string{e.config.AppID},
+		FilterExpired: []string{"false"},
+		Include:       []string{"preReleaseVersion", "appStoreVersion"},
+		Sort:          []string{"-uploadedDate"},
+		Limit:         pageLimit,
+	}
+
+	for {
+		res, _, err := e.client.Builds.ListBuilds(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		builds = append(builds, res.Data...)
+
+		for i := range res.Included {
+			if version := res.Included[i].PrereleaseVersion(); version != nil {
+				versions[version.ID] = version
+			}
+		}
+
+		if res.Links.Next == nil {
+			break
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool {
+		return uploadedDate(builds[i]).After(uploadedDate(builds[j]))
+	})
+
+	return builds, versions, nil
+}
+
+// protectedBuilds returns the IDs of builds available to an external beta group that has at least one
+// tester, mapped to the name of one such group.
+func (e *Engine) protectedBuilds(ctx context.Context) (map[string]string, error) {
+	protected := make(map[string]string)
+	query := &asc.ListBetaGroupsForAppQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := e.client.TestFlight.ListBetaGroupsForApp(ctx, e.config.AppID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range res.Data {
+			if group.Attributes == nil || group.Attributes.IsInternalGroup == nil || *group.Attributes.IsInternalGroup {
+				continue
+			}
+
+			name := group.ID
+			if group.Attributes.Name != nil {
+				name = *group.Attributes.Name
+			}
+
+			testers, _, err := e.client.TestFlight.ListBetaTesterIDsForBetaGroup(ctx, group.ID, &asc.ListBetaTesterIDsForBetaGroupQuery{Limit: 1})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(testers.Data) == 0 {
+				continue
+			}
+
+			if err := e.addGroupBuilds(ctx, group.ID, name, protected); err != nil {
+				return nil, err
+			}
+		}
+
+		if res.Links.Next == nil {
+			return protected, nil
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func (e *Engine) addGroupBuilds(ctx context.Context, groupID, name string, protected map[string]string) error {
+	query := &asc.ListBuildIDsForBetaGroupQuery{Limit: pageLimit}
+
+	for {
+		res, _, err := e.client.TestFlight.ListBuildIDsForBetaGroup(ctx, groupID, query)
+		if err != nil {
+			return err
+		}
+
+		for _, build := range res.Data {
+			if _, ok := protected[build.ID]; !ok {
+				protected[build.ID] = name
+			}
+		}
+
+		if res.Links.Next == nil {
+			return nil
+		}
+
+		query.Cursor = res.Links.Next.Cursor()
+	}
+}
+
+func (e *Engine) logf(format string, args ...interface{}) {
+	if e.Logf != nil {
+		e.Logf(format, args...)
+	}
+}
+
+func uploadedDate(build asc.Build) time.Time {
+	if build.Attributes == nil || build.Attributes.UploadedDate == nil {
+		return time.Time{}
+	}
+
+	return build.Attributes.UploadedDate.Time
+}
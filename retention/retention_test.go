@@ -0,0 +1,237 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+var testNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func testBuild(id, number, versionID string, age int, appStoreVersionID string) string {
+	appStoreVersion := "null"
+	if appStoreVersionID != "" {
+		appStoreVersion = fmt.Sprintf(`{"type":"appStoreVersions","id":%q}`, appStoreVersionID)
+	}
+
+	return fmt.Sprintf(`{"type":"builds","id":%q,"attributes":{"version":%q,"uploadedDate":%q},"relationships":{"preReleaseVersion":{"data":{"type":"preReleaseVersions","id":%q}},"appStoreVersion":{"data":%s}}}`,
+		id, number, testNow.AddDate(0, 0, -age).Format(time.RFC3339), versionID, appStoreVersion)
+}
+
+func testRoutes() map[string]string {
+	builds := []string{
+		testBuild("b4", "4", "pv1", 50, ""),
+		testBuild("b1", "1", "pv1", 1, ""),
+		testBuild("b6", "6", "pv2", 70, ""),
+		testBuild("b2", "2", "pv1", 10, ""),
+		testBuild("b3", "3", "pv1", 40, "asv"),
+		testBuild("b5", "5", "pv2", 60, ""),
+	}
+
+	return map[string]string{
+		"GET /v1/builds": fmt.Sprintf(`{"data":[%s],"included":[
+			{"type":"preReleaseVersions","id":"pv1","attributes":{"version":"2.0","platform":"IOS"}},
+			{"type":"preReleaseVersions","id":"pv2","attributes":{"version":"1.9","platform":"IOS"}}
+		],"links":{"self":""}}`, strings.Join(builds, ",")),
+		"GET /v1/apps/app/betaGroups": `{"data":[
+			{"type":"betaGroups","id":"internal","attributes":{"name":"Team","isInternalGroup":true}},
+			{"type":"betaGroups","id":"external","attributes":{"name":"Public","isInternalGroup":false}},
+			{"type":"betaGroups","id":"empty","attributes":{"name":"Empty","isInternalGroup":false}}
+		],"links":{"self":""}}`,
+		"GET /v1/betaGroups/external/relationships/betaTesters": `{"data":[{"type":"betaTesters","id":"t1"}],"links":{"self":""}}`,
+		"GET /v1/betaGroups/external/relationships/builds":      `{"data":[{"type":"builds","id":"b5"}],"links":{"self":""}}`,
+		"GET /v1/betaGroups/empty/relationships/betaTesters":    `{"data":[],"links":{"self":""}}`,
+		"GET /v1/betaGroups/empty/relationships/builds":         `{"data":[{"type":"builds","id":"b6"}],"links":{"self":""}}`,
+		"PATCH /v1/builds/b2":                                   `{"data":{"type":"builds","id":"b2"}}`,
+		"PATCH /v1/builds/b4":                                   `{"data":{"type":"builds","id":"b4"}}`,
+		"PATCH /v1/builds/b6":                                   `{"data":{"type":"builds","id":"b6"}}`,
+	}
+}
+
+func testConfig() Config {
+	return Config{
+		AppID:          "app",
+		KeepPerVersion: 1,
+		MaxAge:         30 * 24 * time.Hour,
+	}
+}
+
+func newTestEngine(client *asc.Client, config Config, audit AuditLog) *Engine {
+	engine := NewEngine(client, config, audit)
+	engine.now = func() time.Time { return testNow }
+
+	return engine
+}
+
+func expiredIDs(decisions []Decision) []string {
+	var ids []string
+
+	for _, decision := range decisions {
+		if decision.Expire {
+			ids = append(ids, decision.Build.ID)
+		}
+	}
+
+	return ids
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+	decisions, err := newTestEngine(client, testConfig(), nil).Plan(context.Background())
+	assert.NoError(t, err)
+
+	var order []string
+	for _, decision := range decisions {
+		order = append(order, decision.Build.ID)
+	}
+
+	assert.Equal(t, []string{"b1", "b2", "b3", "b4", "b5", "b6"}, order)
+	assert.Equal(t, []string{"b2", "b4", "b6"}, expiredIDs(decisions))
+	assert.Equal(t, "2.0", decisions[0].PrereleaseVersion)
+	assert.Equal(t, "IOS", decisions[0].Platform)
+	assert.Contains(t, decisions[0].Reason, "newest 1")
+	assert.Contains(t, decisions[1].Reason, "not among the newest 1")
+	assert.Contains(t, decisions[3].Reason, "not among the newest 1")
+	assert.Contains(t, decisions[2].Reason, "App Store version asv")
+	assert.Contains(t, decisions[4].Reason, "Public")
+	assert.Empty(t, api.Requests("PATCH /v1/builds/b4"))
+	assert.Empty(t, api.Requests("GET /v1/betaGroups/internal/relationships/betaTesters"))
+}
+
+func TestPlanKeepPerVersionOnly(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+	decisions, err := newTestEngine(client, Config{AppID: "app", KeepPerVersion: 2}, nil).Plan(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b4"}, expiredIDs(decisions))
+}
+
+func TestPlanMaxAgeOnly(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+	decisions, err := newTestEngine(client, Config{AppID: "app", MaxAge: 5 * 24 * time.Hour}, nil).Plan(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b2", "b4", "b6"}, expiredIDs(decisions))
+}
+
+func TestPlanMaxAgeAppliesToNewestBuilds(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+	decisions, err := newTestEngine(client, Config{AppID: "app", KeepPerVersion: 2, MaxAge: 5 * 24 * time.Hour}, nil).Plan(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b2", "b4", "b6"}, expiredIDs(decisions))
+	assert.Contains(t, decisions[1].Reason, "uploaded more than")
+}
+
+func TestPlanInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEngine(asc.NewClient(nil), Config{AppID: "app"}, nil).Plan(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "KeepPerVersion or MaxAge"}, err)
+
+	_, err = NewEngine(asc.NewClient(nil), Config{MaxAge: time.Hour}, nil).Plan(context.Background())
+	assert.Equal(t, ErrInvalidConfig{Field: "AppID"}, err)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+	audit := &MemoryAuditLog{}
+	entries, err := newTestEngine(client, testConfig(), audit).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, entries, 6)
+	assert.Equal(t, entries, audit.Entries())
+	assert.Equal(t, AuditActionKept, entries[0].Action)
+	assert.Equal(t, AuditActionExpired, entries[3].Action)
+	assert.Equal(t, "4", entries[3].BuildNumber)
+	assert.Equal(t, testNow.AddDate(0, 0, -50), entries[3].UploadedDate.UTC())
+	assert.Equal(t, AuditActionExpired, entries[5].Action)
+	assert.Len(t, api.Requests("PATCH /v1/builds/b4"), 1)
+	assert.Contains(t, api.Requests("PATCH /v1/builds/b4")[0], `"expired":true`)
+	assert.Len(t, api.Requests("PATCH /v1/builds/b6"), 1)
+}
+
+func TestRunDryRun(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+	config := testConfig()
+	config.DryRun = true
+
+	entries, err := newTestEngine(client, config, nil).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionWouldExpire, entries[3].Action)
+	assert.True(t, entries[3].DryRun)
+	assert.Empty(t, api.Requests("PATCH /v1/builds/b4"))
+	assert.Empty(t, api.Requests("PATCH /v1/builds/b6"))
+}
+
+func TestRunFailure(t *testing.T) {
+	t.Parallel()
+
+	routes := testRoutes()
+	delete(routes, "PATCH /v1/builds/b4")
+	client, api := apitest.NewServer(t, routes)
+
+	entries, err := newTestEngine(client, testConfig(), nil).Run(context.Background())
+
+	var expireErr ErrExpire
+	assert.True(t, errors.As(err, &expireErr))
+	assert.Contains(t, expireErr.Failures, "b4")
+	assert.Equal(t, AuditActionFailed, entries[3].Action)
+	assert.NotEmpty(t, entries[3].Error)
+	assert.Equal(t, AuditActionExpired, entries[5].Action)
+	assert.Len(t, api.Requests("PATCH /v1/builds/b6"), 1)
+}
+
+func TestFileAuditLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "retention.jsonl")
+	log := FileAuditLog{Path: path}
+
+	assert.NoError(t, log.Record(context.Background(), AuditEntry{BuildID: "b1", Action: AuditActionKept}))
+	assert.NoError(t, log.Record(context.Background(), AuditEntry{BuildID: "b2", Action: AuditActionExpired}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"action":"expired"`)
+}
@@ -0,0 +1,160 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package roster
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/internal/ascutil"
+)
+
+// groupSeparator separates group names in the groups column.
+const groupSeparator = ";"
+
+var header = []string{"First Name", "Last Name", "Email", "Groups"}
+
+// ErrInvalidRow is returned when a line of a roster CSV cannot be used.
+type ErrInvalidRow struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrInvalidRow) Error() string {
+	return fmt.Sprintf("roster line %d: %s", e.Line, e.Reason)
+}
+
+// Tester is one row of a roster: a person and the names of the beta groups they belong to.
+type Tester struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Groups    []string
+}
+
+// ReadCSV reads a roster in TestFlight's import format: first name, last name and email, with an
+// optional fourth column listing group names separated by semicolons. A header row is skipped when
+// present. Emails are compared case-insensitively and must be unique.
+func ReadCSV(r io.Reader) ([]Tester, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var testers []Tester
+
+	seen := make(map[string]int)
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		// A quoted field can span several lines, so the line is the one the record starts on.
+		line, _ := reader.FieldPos(0)
+
+		if ascutil.BlankRecord(record) || (first && isHeader(record)) {
+			continue
+		}
+
+		if len(record) < 3 {
+			return nil, ErrInvalidRow{Line: line, Reason: "expected first name, last name and email"}
+		}
+
+		tester := Tester{
+			FirstName: strings.TrimSpace(record[0]),
+			LastName:  strings.TrimSpace(record[1]),
+			Email:     strings.TrimSpace(record[2]),
+		}
+
+		if !strings.Contains(tester.Email, "@") {
+			return nil, ErrInvalidRow{Line: line, Reason: fmt.Sprintf("%q is not an email address", tester.Email)}
+		}
+
+		if previous, ok := seen[normalizeEmail(tester.Email)]; ok {
+			return nil, ErrInvalidRow{Line: line, Reason: fmt.Sprintf("%s is already listed on line %d", tester.Email, previous)}
+		}
+
+		seen[normalizeEmail(tester.Email)] = line
+
+		if len(record) > 3 {
+			tester.Groups = splitGroups(record[3])
+		}
+
+		testers = append(testers, tester)
+	}
+
+	return testers, nil
+}
+
+// WriteCSV writes a roster with a header row in the format read by ReadCSV. Testers are sorted by
+// email so that exports diff cleanly.
+func WriteCSV(w io.Writer, testers []Tester) error {
+	sorted := append([]Tester(nil), testers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return normalizeEmail(sorted[i].Email) < normalizeEmail(sorted[j].Email)
+	})
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, tester := range sorted {
+		groups := append([]string(nil), tester.Groups...)
+		sort.Strings(groups)
+
+		if err := writer.Write([]string{tester.FirstName, tester.LastName, tester.Email, strings.Join(groups, groupSeparator)}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func isHeader(record []string) bool {
+	return len(record) >= 3 && strings.EqualFold(strings.TrimSpace(record[2]), "email")
+}
+
+func splitGroups(field string) []string {
+	var groups []string
+
+	for _, name := range strings.Split(field, groupSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			groups = append(groups, name)
+		}
+	}
+
+	return groups
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
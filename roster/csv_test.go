@@ -0,0 +1,89 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package roster
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+
+	input := "First Name,Last Name,Email,Groups\n" +
+		"Ada,Lovelace,ada@example.com,Public; Friends\n" +
+		"\n" +
+		"Alan,Turing,alan@example.com\n"
+
+	testers, err := ReadCSV(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, []Tester{
+		{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Groups: []string{"Public", "Friends"}},
+		{FirstName: "Alan", LastName: "Turing", Email: "alan@example.com"},
+	}, testers)
+}
+
+func TestReadCSVWithoutHeader(t *testing.T) {
+	t.Parallel()
+
+	testers, err := ReadCSV(strings.NewReader("Ada,Lovelace,ada@example.com\n"))
+	assert.NoError(t, err)
+	assert.Len(t, testers, 1)
+}
+
+func TestReadCSVRejectsInvalidRows(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadCSV(strings.NewReader("Ada,Lovelace\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: "expected first name, last name and email"}, err)
+
+	_, err = ReadCSV(strings.NewReader("Ada,Lovelace,not-an-email\n"))
+	assert.IsType(t, ErrInvalidRow{}, err)
+
+	_, err = ReadCSV(strings.NewReader("Ada,Lovelace,ada@example.com\nAda,L,ADA@example.com\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 2, Reason: "ADA@example.com is already listed on line 1"}, err)
+
+	_, err = ReadCSV(strings.NewReader("Ada,\"Love\nlace\",ada@example.com\n\nAda,L,ADA@example.com\n"))
+	assert.Equal(t, ErrInvalidRow{Line: 4, Reason: "ADA@example.com is already listed on line 1"}, err)
+}
+
+func TestWriteCSVRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	testers := []Tester{
+		{FirstName: "Zed", LastName: "Shaw", Email: "zed@example.com", Groups: []string{"Public"}},
+		{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Groups: []string{"Public", "Friends"}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, testers))
+	assert.Equal(t, "First Name,Last Name,Email,Groups\n"+
+		"Ada,Lovelace,ada@example.com,Friends;Public\n"+
+		"Zed,Shaw,zed@example.com,Public\n", buf.String())
+
+	read, err := ReadCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Friends", "Public"}, read[0].Groups)
+	assert.Equal(t, "zed@example.com", read[1].Email)
+}
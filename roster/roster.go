@@ -0,0 +1,585 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package roster keeps the beta testers of an app's TestFlight groups in sync with a spreadsheet.
+
+A roster is read from CSV in TestFlight's import format, compared against a Snapshot of the app's
+groups fetched from App Store Connect, and turned into a Plan of invites, group moves and removals
+that can be reviewed before it is applied:
+
+	desired, err := roster.ReadCSV(file)
+	snapshot, err := roster.Fetch(ctx, client, "1234567890")
+	plan, err := roster.NewPlan(snapshot, desired, roster.PlanOptions{})
+	result, err := plan.Apply(ctx, client, roster.DefaultBatchSize)
+
+The current roster can be exported back to the same format with WriteCSV(w, snapshot.Testers()).
+*/
+package roster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+const (
+	// DefaultBatchSize is the number of testers added to or removed from a group per request.
+	DefaultBatchSize = 50
+
+	pageLimit = 200
+)
+
+// ErrUnknownGroup is returned when a roster names a beta group that the app does not have.
+type ErrUnknownGroup struct {
+	Name string
+}
+
+func (e ErrUnknownGroup) Error() string {
+	return fmt.Sprintf("beta group %q does not exist", e.Name)
+}
+
+// ErrInternalGroup is returned when a roster names an internal beta group. Internal testers are
+// members of the team and are managed in Users and Access, not by a roster.
+type ErrInternalGroup struct {
+	Name string
+}
+
+func (e ErrInternalGroup) Error() string {
+	return fmt.Sprintf("beta group %q is an internal group", e.Name)
+}
+
+// ErrNoGroups is returned when a roster lists a tester without any group and no default groups are set.
+type ErrNoGroups struct {
+	Email string
+}
+
+func (e ErrNoGroups) Error() string {
+	return fmt.Sprintf("tester %s is not in any group", e.Email)
+}
+
+// Group is a beta group of the app.
+type Group struct {
+	ID   string
+	Name string
+	// Internal is set for groups of the team's own users. Their testers are not part of the
+	// snapshot, so a roster never invites or removes them.
+	Internal bool
+}
+
+// Member is a tester that already exists in App Store Connect.
+type Member struct {
+	ID string
+	Tester
+}
+
+// Snapshot is the current state of an app's beta groups and their testers.
+type Snapshot struct {
+	AppID   string
+	Groups  []Group
+	Members []Member
+}
+
+// Fetch reads every beta group of the app and every tester in its external groups. Testers of
+// internal groups are left out, so that a roster of external testers does not remove them.
+func Fetch(ctx context.Context, client *asc.Client, appID string) (*Snapshot, error) {
+	snapshot := &Snapshot{AppID: appID}
+	cursor := ""
+
+	for {
+		groups, _, err := client.TestFlight.ListBetaGroupsForApp(ctx, appID, &asc.ListBetaGroupsForAppQuery{Limit: pageLimit, Cursor: cursor})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups.Data {
+			name := ""
+			if group.Attributes != nil && group.Attributes.Name != nil {
+				name = *group.Attributes.Name
+			}
+
+			internal := group.Attributes != nil && group.Attributes.IsInternalGroup != nil && *group.Attributes.IsInternalGroup
+
+			snapshot.Groups = append(snapshot.Groups, Group{ID: group.ID, Name: name, Internal: internal})
+		}
+
+		if groups.Links.Next == nil {
+			break
+		}
+
+		cursor = groups.Links.Next.Cursor()
+	}
+
+	members := make(map[string]*Member)
+
+	var order []string
+
+	for _, group := range snapshot.Groups {
+		if group.Internal {
+			continue
+		}
+
+		cursor = ""
+
+		for {
+			testers, _, err := client.TestFlight.ListBetaTestersForBetaGroup(ctx, group.ID, &asc.ListBetaTestersForBetaGroupQuery{Limit: pageLimit, Cursor: cursor})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, tester := range testers.Data {
+				member, ok := members[tester.ID]
+				if !ok {
+					member = newMember(tester)
+					members[tester.ID] = member
+					order = append(order, tester.ID)
+				}
+
+				member.Groups = append(member.Groups, group.Name)
+			}
+
+			if testers.Links.Next == nil {
+				break
+			}
+
+			cursor = testers.Links.Next.Cursor()
+		}
+	}
+
+	for _, id := range order {
+		snapshot.Members = append(snapshot.Members, *members[id])
+	}
+
+	return snapshot, nil
+}
+
+func newMember(tester asc.BetaTester) *Member {
+	member := &Member{ID: tester.ID}
+
+	if attributes := tester.Attributes; attributes != nil {
+		if attributes.FirstName != nil {
+			member.FirstName = *attributes.FirstName
+		}
+
+		if attributes.LastName != nil {
+			member.LastName = *attributes.LastName
+		}
+
+		if attributes.Email != nil {
+			member.Email = string(*attributes.Email)
+		}
+	}
+
+	return member
+}
+
+// Testers returns the snapshot's members as roster rows, suitable for WriteCSV.
+func (s *Snapshot) Testers() []Tester {
+	testers := make([]Tester, 0, len(s.Members))
+	for _, member := range s.Members {
+		testers = append(testers, member.Tester)
+	}
+
+	return testers
+}
+
+func (s *Snapshot) group(name string) (Group, bool) {
+	for _, group := range s.Groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+
+	return Group{}, false
+}
+
+// PlanOptions changes how a Plan is computed.
+type PlanOptions struct {
+	// DefaultGroups are the group names used for testers listed without any group, as in a CSV
+	// exported from TestFlight, which has no groups column.
+	DefaultGroups []string
+	// DeleteMissing deletes testers that are not in the roster, instead of only removing them
+	// from the app's groups. Deleting a tester removes them from every app of the team.
+	DeleteMissing bool
+}
+
+// Invite is a tester that does not exist yet and is invited to the given groups.
+type Invite struct {
+	Tester Tester
+	Groups []Group
+}
+
+// Move is an existing tester whose groups change.
+type Move struct {
+	TesterID string
+	Email    string
+	Add      []Group
+	Remove   []Group
+}
+
+// Removal is an existing tester that is not in the roster. The tester is removed from Groups, or
+// deleted when Delete is set.
+type Removal struct {
+	TesterID string
+	Email    string
+	Groups   []Group
+	Delete   bool
+}
+
+// Plan lists the changes needed to make App Store Connect match a roster.
+type Plan struct {
+	Invites  []Invite
+	Moves    []Move
+	Removals []Removal
+}
+
+// Empty reports whether the plan has nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Invites) == 0 && len(p.Moves) == 0 && len(p.Removals) == 0
+}
+
+// NewPlan compares the desired roster with a snapshot. Testers are matched by email, ignoring case.
+// Names of existing testers are not changed, since the API does not allow it.
+func NewPlan(snapshot *Snapshot, desired []Tester, options PlanOptions) (*Plan, error) {
+	plan := &Plan{}
+	existing := make(map[string]Member, len(snapshot.Members))
+
+	for _, member := range snapshot.Members {
+		existing[normalizeEmail(member.Email)] = member
+	}
+
+	listed := make(map[string]bool, len(desired))
+
+	for _, tester := range desired {
+		email := normalizeEmail(tester.Email)
+		listed[email] = true
+
+		names := tester.Groups
+		if len(names) == 0 {
+			names = options.DefaultGroups
+		}
+
+		if len(names) == 0 {
+			return nil, ErrNoGroups{Email: tester.Email}
+		}
+
+		groups, err := snapshot.resolve(names)
+		if err != nil {
+			return nil, err
+		}
+
+		member, ok := existing[email]
+		if !ok {
+			plan.Invites = append(plan.Invites, Invite{Tester: tester, Groups: groups})
+
+			continue
+		}
+
+		current, err := snapshot.resolve(member.Groups)
+		if err != nil {
+			return nil, err
+		}
+
+		move := Move{
+			TesterID: member.ID,
+			Email:    member.Email,
+			Add:      difference(groups, current),
+			Remove:   difference(current, groups),
+		}
+
+		if len(move.Add) > 0 || len(move.Remove) > 0 {
+			plan.Moves = append(plan.Moves, move)
+		}
+	}
+
+	for _, member := range snapshot.Members {
+		if listed[normalizeEmail(member.Email)] {
+			continue
+		}
+
+		groups, err := snapshot.resolve(member.Groups)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Removals = append(plan.Removals, Removal{
+			TesterID: member.ID,
+			Email:    member.Email,
+			Groups:   groups,
+			Delete:   options.DeleteMissing,
+		})
+	}
+
+	return plan, nil
+}
+
+func (s *Snapshot) resolve(names []string) ([]Group, error) {
+	groups := make([]Group, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		group, ok := s.group(strings.TrimSpace(name))
+		if !ok {
+			return nil, ErrUnknownGroup{Name: name}
+		}
+
+		if group.Internal {
+			return nil, ErrInternalGroup{Name: group.Name}
+		}
+
+		if !seen[group.ID] {
+			seen[group.ID] = true
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups, nil
+}
+
+// difference returns the groups in a that are not in b.
+func difference(a, b []Group) []Group {
+	var out []Group
+
+	for _, group := range a {
+		found := false
+
+		for _, other := range b {
+			if other.ID == group.ID {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			out = append(out, group)
+		}
+	}
+
+	return out
+}
+
+// Failure records a change that could not be applied.
+type Failure struct {
+	// Emails are the testers affected by the failed request.
+	Emails []string
+	// Group is set when the failed request added to or removed from a group.
+	Group *Group
+	Err   error
+}
+
+// Result counts the changes made by Apply.
+type Result struct {
+	Invited  int
+	Added    int
+	Removed  int
+	Deleted  int
+	Failures []Failure
+}
+
+// ErrApply reports the requests of a Plan that App Store Connect refused. Result.Failures lists the
+// same requests.
+type ErrApply struct {
+	Failures []Failure
+}
+
+func (e ErrApply) Error() string {
+	return fmt.Sprintf("%d roster changes failed, first: %s", len(e.Failures), e.Failures[0].Err)
+}
+
+type membership struct {
+	testerID string
+	email    string
+}
+
+// Apply makes the changes in the plan. Testers are added to and removed from each group in
+// requests of at most batchSize testers; a batchSize of zero or less uses DefaultBatchSize.
+// Invites and deletions are made one tester at a time. A refused request does not end the run: the
+// rest of the plan is still applied, and the refusals are reported together as an ErrApply.
+func (p *Plan) Apply(ctx context.Context, client *asc.Client, batchSize int) (*Result, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	result := &Result{}
+
+	for _, invite := range p.Invites {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		attributes := asc.BetaTesterCreateRequestAttributes{Email: asc.Email(invite.Tester.Email)}
+		if invite.Tester.FirstName != "" {
+			attributes.FirstName = asc.String(invite.Tester.FirstName)
+		}
+
+		if invite.Tester.LastName != "" {
+			attributes.LastName = asc.String(invite.Tester.LastName)
+		}
+
+		if _, _, err := client.TestFlight.CreateBetaTester(ctx, attributes, groupIDs(invite.Groups), nil); err != nil {
+			result.Failures = append(result.Failures, Failure{Emails: []string{invite.Tester.Email}, Err: err})
+
+			continue
+		}
+
+		result.Invited++
+	}
+
+	additions, removals, groups := p.memberships()
+
+	for _, id := range sortedGroupIDs(additions) {
+		group := groups[id]
+
+		for _, batch := range batches(additions[id], batchSize) {
+			if _, err := client.TestFlight.AddBetaTestersToBetaGroup(ctx, id, testerIDs(batch)); err != nil {
+				result.Failures = append(result.Failures, Failure{Emails: emails(batch), Group: &group, Err: err})
+
+				continue
+			}
+
+			result.Added += len(batch)
+		}
+	}
+
+	for _, id := range sortedGroupIDs(removals) {
+		group := groups[id]
+
+		for _, batch := range batches(removals[id], batchSize) {
+			if _, err := client.TestFlight.RemoveBetaTestersFromBetaGroup(ctx, id, testerIDs(batch)); err != nil {
+				result.Failures = append(result.Failures, Failure{Emails: emails(batch), Group: &group, Err: err})
+
+				continue
+			}
+
+			result.Removed += len(batch)
+		}
+	}
+
+	for _, removal := range p.Removals {
+		if !removal.Delete {
+			continue
+		}
+
+		if _, err := client.TestFlight.DeleteBetaTester(ctx, removal.TesterID); err != nil {
+			result.Failures = append(result.Failures, Failure{Emails: []string{removal.Email}, Err: err})
+
+			continue
+		}
+
+		result.Deleted++
+	}
+
+	if len(result.Failures) > 0 {
+		return result, ErrApply{Failures: result.Failures}
+	}
+
+	return result, nil
+}
+
+// memberships groups the plan's additions and removals by beta group ID.
+func (p *Plan) memberships() (additions, removals map[string][]membership, groups map[string]Group) {
+	additions = make(map[string][]membership)
+	removals = make(map[string][]membership)
+	groups = make(map[string]Group)
+
+	for _, move := range p.Moves {
+		for _, group := range move.Add {
+			groups[group.ID] = group
+			additions[group.ID] = append(additions[group.ID], membership{testerID: move.TesterID, email: move.Email})
+		}
+
+		for _, group := range move.Remove {
+			groups[group.ID] = group
+			removals[group.ID] = append(removals[group.ID], membership{testerID: move.TesterID, email: move.Email})
+		}
+	}
+
+	for _, removal := range p.Removals {
+		if removal.Delete {
+			continue
+		}
+
+		for _, group := range removal.Groups {
+			groups[group.ID] = group
+			removals[group.ID] = append(removals[group.ID], membership{testerID: removal.TesterID, email: removal.Email})
+		}
+	}
+
+	return additions, removals, groups
+}
+
+func batches(memberships []membership, size int) [][]membership {
+	var out [][]membership
+
+	for len(memberships) > size {
+		out = append(out, memberships[:size])
+		memberships = memberships[size:]
+	}
+
+	if len(memberships) > 0 {
+		out = append(out, memberships)
+	}
+
+	return out
+}
+
+func sortedGroupIDs(m map[string][]membership) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+func groupIDs(groups []Group) []string {
+	ids := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.ID)
+	}
+
+	return ids
+}
+
+func testerIDs(batch []membership) []string {
+	ids := make([]string, 0, len(batch))
+	for _, m := range batch {
+		ids = append(ids, m.testerID)
+	}
+
+	return ids
+}
+
+func emails(batch []membership) []string {
+	out := make([]string, 0, len(batch))
+	for _, m := range batch {
+		out = append(out, m.email)
+	}
+
+	return out
+}
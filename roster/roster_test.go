@@ -0,0 +1,162 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package roster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func testRoutes() map[string]string {
+	return map[string]string{
+		"GET /v1/apps/app/betaGroups":                        `{"data":[{"id":"g1","attributes":{"name":"Public"}},{"id":"g2","attributes":{"name":"Friends"}},{"id":"g3","attributes":{"name":"Team","isInternalGroup":true}}]}`,
+		"GET /v1/betaGroups/g1/betaTesters":                  `{"data":[{"id":"t1","attributes":{"firstName":"Ada","lastName":"Lovelace","email":"ada@example.com"}},{"id":"t2","attributes":{"firstName":"Alan","lastName":"Turing","email":"alan@example.com"}}]}`,
+		"GET /v1/betaGroups/g2/betaTesters":                  `{"data":[{"id":"t1","attributes":{"firstName":"Ada","lastName":"Lovelace","email":"ada@example.com"}}]}`,
+		"POST /v1/betaTesters":                               `{"data":{"id":"t3"}}`,
+		"POST /v1/betaGroups/g2/relationships/betaTesters":   "",
+		"DELETE /v1/betaGroups/g1/relationships/betaTesters": "",
+		"DELETE /v1/betaGroups/g2/relationships/betaTesters": "",
+		"DELETE /v1/betaTesters/t1":                          "",
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	snapshot, err := Fetch(context.Background(), client, "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []Group{{ID: "g1", Name: "Public"}, {ID: "g2", Name: "Friends"}, {ID: "g3", Name: "Team", Internal: true}}, snapshot.Groups)
+	assert.Equal(t, []Tester{
+		{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Groups: []string{"Public", "Friends"}},
+		{FirstName: "Alan", LastName: "Turing", Email: "alan@example.com", Groups: []string{"Public"}},
+	}, snapshot.Testers())
+}
+
+func TestNewPlan(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+	snapshot, err := Fetch(context.Background(), client, "app")
+	assert.NoError(t, err)
+
+	plan, err := NewPlan(snapshot, []Tester{
+		{FirstName: "Alan", LastName: "Turing", Email: "ALAN@example.com", Groups: []string{"Friends"}},
+		{FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com"},
+	}, PlanOptions{DefaultGroups: []string{"Public"}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Invite{{
+		Tester: Tester{FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com"},
+		Groups: []Group{{ID: "g1", Name: "Public"}},
+	}}, plan.Invites)
+	assert.Equal(t, []Move{{
+		TesterID: "t2",
+		Email:    "alan@example.com",
+		Add:      []Group{{ID: "g2", Name: "Friends"}},
+		Remove:   []Group{{ID: "g1", Name: "Public"}},
+	}}, plan.Moves)
+	assert.Equal(t, []Removal{{
+		TesterID: "t1",
+		Email:    "ada@example.com",
+		Groups:   []Group{{ID: "g2", Name: "Friends"}, {ID: "g1", Name: "Public"}},
+	}}, plan.Removals)
+}
+
+func TestNewPlanIsEmptyWhenInSync(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+	snapshot, err := Fetch(context.Background(), client, "app")
+	assert.NoError(t, err)
+
+	plan, err := NewPlan(snapshot, snapshot.Testers(), PlanOptions{})
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+}
+
+func TestNewPlanRejectsUnknownGroups(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &Snapshot{Groups: []Group{{ID: "g1", Name: "Public"}, {ID: "g3", Name: "Team", Internal: true}}}
+
+	_, err := NewPlan(snapshot, []Tester{{Email: "a@example.com", Groups: []string{"Nope"}}}, PlanOptions{})
+	assert.Equal(t, ErrUnknownGroup{Name: "Nope"}, err)
+
+	_, err = NewPlan(snapshot, []Tester{{Email: "a@example.com", Groups: []string{"Team"}}}, PlanOptions{})
+	assert.Equal(t, ErrInternalGroup{Name: "Team"}, err)
+
+	_, err = NewPlan(snapshot, []Tester{{Email: "a@example.com"}}, PlanOptions{})
+	assert.Equal(t, ErrNoGroups{Email: "a@example.com"}, err)
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	client, api := apitest.NewServer(t, testRoutes())
+
+	plan := &Plan{
+		Invites: []Invite{{Tester: Tester{Email: "grace@example.com"}, Groups: []Group{{ID: "g1", Name: "Public"}}}},
+		Moves: []Move{
+			{TesterID: "t2", Email: "alan@example.com", Add: []Group{{ID: "g2"}}, Remove: []Group{{ID: "g1"}}},
+			{TesterID: "t4", Email: "b@example.com", Add: []Group{{ID: "g2"}}},
+			{TesterID: "t5", Email: "c@example.com", Add: []Group{{ID: "g2"}}},
+		},
+		Removals: []Removal{{TesterID: "t1", Email: "ada@example.com", Groups: []Group{{ID: "g2"}}, Delete: true}},
+	}
+
+	result, err := plan.Apply(context.Background(), client, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{Invited: 1, Added: 3, Removed: 1, Deleted: 1}, result)
+
+	additions := api.Requests("POST /v1/betaGroups/g2/relationships/betaTesters")
+	assert.Len(t, additions, 2)
+
+	var batch struct {
+		Data []asc.RelationshipData `json:"data"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(additions[0]), &batch))
+	assert.Len(t, batch.Data, 2)
+	assert.Empty(t, api.Requests("DELETE /v1/betaGroups/g2/relationships/betaTesters"))
+}
+
+func TestApplyReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	client, _ := apitest.NewServer(t, testRoutes())
+
+	plan := &Plan{
+		Moves: []Move{{TesterID: "t2", Email: "alan@example.com", Add: []Group{{ID: "missing", Name: "Missing"}}}},
+	}
+
+	result, err := plan.Apply(context.Background(), client, 0)
+	assert.IsType(t, ErrApply{}, err)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, []string{"alan@example.com"}, result.Failures[0].Emails)
+	assert.Equal(t, "Missing", result.Failures[0].Group.Name)
+}
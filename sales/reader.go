@@ -0,0 +1,297 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ErrInvalidRow is returned when a line of a report cannot be parsed.
+type ErrInvalidRow struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrInvalidRow) Error() string {
+	return fmt.Sprintf("report line %d: %s", e.Line, e.Reason)
+}
+
+// Reader streams the rows of a Sales and Trends report. Only the current line is held in memory, so
+// reports of any size can be read, as long as they are streamed from a file or a network response
+// rather than buffered first.
+//
+// Columns are matched by name, so every version of a report type is read by the same method;
+// columns a version does not have are left as zero values, and columns the row type does not know
+// are kept in its Extra map.
+type Reader struct {
+	reader  *bufio.Reader
+	header  []string
+	columns map[string]int
+	line    int
+	close   func() error
+}
+
+// NewReader reads the header of a report. The report may be gzip-compressed, as App Store Connect
+// serves it, or already uncompressed.
+func NewReader(r io.Reader) (*Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	magic, err := buffered.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if bytes.Equal(magic, gzipMagic) {
+		unzipped, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+
+		buffered = bufio.NewReader(unzipped)
+	}
+
+	reader := &Reader{reader: buffered}
+
+	fields, err := reader.readLine()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrInvalidRow{Line: 1, Reason: "report has no header"}
+	} else if err != nil {
+		return nil, err
+	}
+
+	fields[0] = strings.TrimPrefix(fields[0], "\ufeff")
+
+	reader.header = fields
+	reader.columns = make(map[string]int, len(fields))
+
+	for i, name := range fields {
+		name = strings.TrimSpace(name)
+		fields[i] = name
+
+		if _, ok := reader.columns[name]; ok {
+			return nil, ErrInvalidRow{Line: 1, Reason: fmt.Sprintf("column %q is repeated", name)}
+		}
+
+		reader.columns[name] = i
+	}
+
+	return reader, nil
+}
+
+// Close stops the download of a Reader returned by Download. It does nothing for a Reader created
+// with NewReader, whose io.Reader is closed by the caller.
+func (r *Reader) Close() error {
+	if r.close == nil {
+		return nil
+	}
+
+	return r.close()
+}
+
+// Header returns the column names of the report, in order.
+func (r *Reader) Header() []string {
+	return append([]string(nil), r.header...)
+}
+
+// Read returns the next row as a Record, or io.EOF after the last row. Blank lines are skipped.
+func (r *Reader) Read() (*Record, error) {
+	for {
+		fields, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) == 1 && strings.TrimSpace(fields[0]) == "" {
+			continue
+		}
+
+		if len(fields) > len(r.header) {
+			return nil, ErrInvalidRow{Line: r.line, Reason: fmt.Sprintf("expected %d columns, found %d", len(r.header), len(fields))}
+		}
+
+		return &Record{Line: r.line, reader: r, fields: fields, used: make([]bool, len(r.header))}, nil
+	}
+}
+
+func (r *Reader) readLine() ([]string, error) {
+	line, err := r.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return nil, io.EOF
+	} else if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	r.line++
+
+	return strings.Split(strings.TrimRight(line, "\r\n"), "\t"), nil
+}
+
+// Record is one row of a report, read by column name. The typed getters remember the first value
+// that could not be parsed; Err returns it.
+type Record struct {
+	Line int
+
+	reader *Reader
+	fields []string
+	used   []bool
+	err    error
+}
+
+// Get returns the value of a column, and whether the report has the column. Rows that end early,
+// as some reports do when their last columns are empty, read as empty strings.
+func (r *Record) Get(column string) (string, bool) {
+	i, ok := r.reader.columns[column]
+	if !ok {
+		return "", false
+	}
+
+	r.used[i] = true
+
+	if i >= len(r.fields) {
+		return "", true
+	}
+
+	return strings.TrimSpace(r.fields[i]), true
+}
+
+// Fields returns every column of the row by name.
+func (r *Record) Fields() map[string]string {
+	fields := make(map[string]string, len(r.reader.header))
+
+	for name, i := range r.reader.columns {
+		if i < len(r.fields) {
+			fields[name] = strings.TrimSpace(r.fields[i])
+		} else {
+			fields[name] = ""
+		}
+	}
+
+	return fields
+}
+
+// Err returns the first value a typed getter could not parse.
+func (r *Record) Err() error {
+	return r.err
+}
+
+// extra returns the columns no getter has read.
+func (r *Record) extra() map[string]string {
+	var extra map[string]string
+
+	for i, name := range r.reader.header {
+		if r.used[i] {
+			continue
+		}
+
+		if extra == nil {
+			extra = make(map[string]string)
+		}
+
+		if i < len(r.fields) {
+			extra[name] = strings.TrimSpace(r.fields[i])
+		} else {
+			extra[name] = ""
+		}
+	}
+
+	return extra
+}
+
+func (r *Record) fail(column, value, kind string) {
+	if r.err == nil {
+		r.err = ErrInvalidRow{Line: r.Line, Reason: fmt.Sprintf("%s %q is not %s", column, value, kind)}
+	}
+}
+
+// lookup returns the value of the first of columns the report has. Columns are renamed between
+// versions of some reports, so getters accept every name a column has had.
+func (r *Record) lookup(columns []string) (column, value string) {
+	for _, column := range columns {
+		if value, ok := r.Get(column); ok {
+			return column, value
+		}
+	}
+
+	return "", ""
+}
+
+func (r *Record) string(columns ...string) string {
+	_, value := r.lookup(columns)
+
+	return value
+}
+
+func (r *Record) int(columns ...string) int64 {
+	column, value := r.lookup(columns)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		r.fail(column, value, "an integer")
+	}
+
+	return n
+}
+
+func (r *Record) decimal(columns ...string) Decimal {
+	column, value := r.lookup(columns)
+
+	d, err := ParseDecimal(value)
+	if err != nil {
+		r.fail(column, value, "a decimal number")
+	}
+
+	return d
+}
+
+func (r *Record) currency(columns ...string) Currency {
+	column, value := r.lookup(columns)
+
+	currency, ok := parseCurrency(value)
+	if !ok {
+		r.fail(column, value, "a currency code")
+	}
+
+	return currency
+}
+
+func (r *Record) date(columns ...string) time.Time {
+	column, value := r.lookup(columns)
+
+	t, ok := parseDate(value)
+	if !ok {
+		r.fail(column, value, "a date")
+	}
+
+	return t
+}
@@ -0,0 +1,235 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tsv(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+var salesReport = tsv(
+	"Provider\tProvider Country\tSKU\tDeveloper\tTitle\tVersion\tProduct Type Identifier\tUnits\tDeveloper Proceeds\tBegin Date\tEnd Date\tCustomer Currency\tCountry Code\tCurrency of Proceeds\tApple Identifier\tCustomer Price\tPromo Code\tParent Identifier\tSubscription\tPeriod\tCategory\tCMB\tDevice\tSupported Platforms\tProceeds Reason\tPreserved Pricing\tClient\tOrder Type\tNew Column",
+	"APPLE\tUS\tcom.example.app\tExample\tExample App\t2.1\t1F\t3\t0.70\t03/09/2020\t03/09/2020\tUSD\tUS\tUSD\t1234567890\t0.99\t\t\t\t\tProductivity\t\tiPhone\tiOS\t\t\t\t\tsurprise",
+	"",
+	"APPLE\tUS\tcom.example.app\tExample\tExample App\t2.1\t1F\t-1\t-0.70\t03/09/2020\t03/09/2020\tEUR\tDE\tEUR\t1234567890\t1.09",
+)
+
+func TestReadSales(t *testing.T) {
+	t.Parallel()
+
+	for name, body := range map[string][]byte{"plain": []byte(salesReport), "gzip": gzipped(t, salesReport)} {
+		reader, err := NewReader(bytes.NewReader(body))
+		assert.NoError(t, err, name)
+		assert.Len(t, reader.Header(), 29, name)
+
+		row, err := reader.ReadSales()
+		assert.NoError(t, err, name)
+		assert.Equal(t, "com.example.app", row.SKU, name)
+		assert.Equal(t, int64(3), row.Units, name)
+		assert.Equal(t, "0.70", row.DeveloperProceeds.String(), name)
+		assert.Equal(t, time.Date(2020, time.March, 9, 0, 0, 0, 0, time.UTC), row.BeginDate, name)
+		assert.Equal(t, Currency("USD"), row.CustomerCurrency, name)
+		assert.Equal(t, "Productivity", row.Category, name)
+		assert.Equal(t, map[string]string{"New Column": "surprise"}, row.Extra, name)
+
+		row, err = reader.ReadSales()
+		assert.NoError(t, err, name)
+		assert.Equal(t, int64(-1), row.Units, name)
+		assert.Equal(t, Currency("EUR"), row.CurrencyOfProceeds, name)
+		assert.Equal(t, "", row.OrderType, name)
+		assert.Equal(t, map[string]string{"New Column": ""}, row.Extra, name)
+
+		_, err = reader.ReadSales()
+		assert.Equal(t, io.EOF, err, name)
+	}
+}
+
+func TestReadSubscription(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv(
+		"\ufeffApp Name\tApp Apple ID\tSubscription Name\tSubscription Apple ID\tSubscription Group ID\tStandard Subscription Duration\tCustomer Price\tCustomer Currency\tDeveloper Proceeds\tProceeds Currency\tCountry\tActive Standard Price Subscriptions\tActive Free Trial Introductory Offer Subscriptions\tSubscribers\r",
+		"Example\t1234567890\tPro\t1400000000\t20000000\t1 Month\t4.99\tUSD\t3.49\tUSD\tUS\t120\t15\t135\r",
+	)))
+	assert.NoError(t, err)
+
+	row, err := reader.ReadSubscription()
+	assert.NoError(t, err)
+	assert.Equal(t, "Example", row.AppName)
+	assert.Equal(t, "1 Month", row.StandardSubscriptionDuration)
+	assert.Equal(t, "3.49", row.DeveloperProceeds.String())
+	assert.Equal(t, int64(120), row.ActiveStandardPriceSubscriptions)
+	assert.Equal(t, int64(15), row.ActiveFreeTrialIntroductoryOfferSubscriptions)
+	assert.Equal(t, int64(135), row.Subscribers)
+	assert.Nil(t, row.Extra)
+}
+
+func TestReadSubscriptionEvent(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv(
+		"Event Date\tEvent\tApp Name\tSubscription Duration\tIntroductory Price Type\tConsecutive Paid Periods\tOriginal Start Date\tDays Before Canceling\tCancellation Reason\tQuantity",
+		"2020-03-09\tCancel\tExample\t1 Month\tFree Trial\t0\t2020-03-02\t7\tPrice Increase\t2",
+	)))
+	assert.NoError(t, err)
+
+	row, err := reader.ReadSubscriptionEvent()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, time.March, 9, 0, 0, 0, 0, time.UTC), row.EventDate)
+	assert.Equal(t, "Cancel", row.Event)
+	assert.Equal(t, "1 Month", row.StandardSubscriptionDuration)
+	assert.Equal(t, "Free Trial", row.SubscriptionOfferType)
+	assert.Equal(t, time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC), row.OriginalStartDate)
+	assert.Equal(t, int64(7), row.DaysBeforeCanceling)
+	assert.Equal(t, int64(2), row.Quantity)
+}
+
+func TestReadSubscriber(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv(
+		"Event Date\tApp Name\tSubscription Name\tCustomer Price\tCustomer Currency\tDeveloper Proceeds\tProceeds Currency\tCountry\tSubscriber ID\tSubscriber ID Reset\tRefund\tPurchase Date\tUnits",
+		"2020-03-09\tExample\tPro\t4.99\tUSD\t3.49\tUSD\tUS\t8263748293\t\tYes\t2020-02-09\t-1",
+	)))
+	assert.NoError(t, err)
+
+	row, err := reader.ReadSubscriber()
+	assert.NoError(t, err)
+	assert.Equal(t, "8263748293", row.SubscriberID)
+	assert.Equal(t, "Yes", row.Refund)
+	assert.Equal(t, time.Date(2020, time.February, 9, 0, 0, 0, 0, time.UTC), row.PurchaseDate)
+	assert.Equal(t, int64(-1), row.Units)
+}
+
+func TestReadNewsstand(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv(
+		"Provider\tSKU\tUnits\tDeveloper Proceeds\tCustomer Currency\tCustomer Price\tDownload Date (PST)\tCustomer Identifier\tReport Date (Local)\tSales/Return",
+		"APPLE\tissue-12\t1\t2.10\tGBP\t2.99\t03/09/2020 10:15:00\t99\t03/09/2020\tS",
+	)))
+	assert.NoError(t, err)
+
+	row, err := reader.ReadNewsstand()
+	assert.NoError(t, err)
+	assert.Equal(t, "issue-12", row.SKU)
+	assert.Equal(t, Currency("GBP"), row.CustomerCurrency)
+	assert.Equal(t, time.Date(2020, time.March, 9, 10, 15, 0, 0, time.UTC), row.DownloadDate)
+	assert.Equal(t, time.Date(2020, time.March, 9, 0, 0, 0, 0, time.UTC), row.ReportDate)
+	assert.Equal(t, "S", row.SalesOrReturn)
+}
+
+func TestReadPreOrder(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv(
+		"Provider\tTitle\tSKU\tPre-Order Start Date\tPre-Order End Date\tOrdered\tCanceled\tCumulative Ordered\tCumulative Canceled\tStart Date\tEnd Date\tCountry Code",
+		"APPLE\tExample\tcom.example.app\t03/01/2020\t04/01/2020\t40\t2\t300\t11\t03/02/2020\t03/08/2020\tFR",
+	)))
+	assert.NoError(t, err)
+
+	row, err := reader.ReadPreOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC), row.PreOrderEndDate)
+	assert.Equal(t, int64(40), row.Ordered)
+	assert.Equal(t, int64(11), row.CumulativeCanceled)
+	assert.Equal(t, "FR", row.CountryCode)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	reader, err := NewReader(strings.NewReader(tsv("SKU\tUnits", "a\t1", "b")))
+	assert.NoError(t, err)
+
+	record, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, record.Line)
+	assert.Equal(t, map[string]string{"SKU": "a", "Units": "1"}, record.Fields())
+
+	value, ok := record.Get("SKU")
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+
+	_, ok = record.Get("Title")
+	assert.False(t, ok)
+
+	record, err = reader.Read()
+	assert.NoError(t, err)
+
+	value, ok = record.Get("Units")
+	assert.True(t, ok)
+	assert.Equal(t, "", value)
+}
+
+func TestReaderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReader(strings.NewReader(""))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: "report has no header"}, err)
+
+	_, err = NewReader(strings.NewReader(tsv("SKU\tSKU")))
+	assert.Equal(t, ErrInvalidRow{Line: 1, Reason: `column "SKU" is repeated`}, err)
+
+	reader, err := NewReader(strings.NewReader(tsv("SKU\tUnits", "a\t1\textra")))
+	assert.NoError(t, err)
+
+	_, err = reader.ReadSales()
+	assert.Equal(t, ErrInvalidRow{Line: 2, Reason: "expected 2 columns, found 3"}, err)
+
+	reader, err = NewReader(strings.NewReader(tsv(
+		"Units\tDeveloper Proceeds\tBegin Date\tCustomer Currency",
+		"many\tfree\tyesterday\tdollars",
+	)))
+	assert.NoError(t, err)
+
+	_, err = reader.ReadSales()
+	assert.Equal(t, ErrInvalidRow{Line: 2, Reason: `Units "many" is not an integer`}, err)
+
+	reader, err = NewReader(strings.NewReader(tsv("Begin Date", "yesterday")))
+	assert.NoError(t, err)
+
+	_, err = reader.ReadSales()
+	assert.Equal(t, ErrInvalidRow{Line: 2, Reason: `Begin Date "yesterday" is not a date`}, err)
+}
@@ -0,0 +1,490 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import "time"
+
+// SalesRow is a row of a SALES SUMMARY report: the units and proceeds of one product in one
+// country, price and currency for the report's period.
+type SalesRow struct {
+	Provider              string
+	ProviderCountry       string
+	SKU                   string
+	Developer             string
+	Title                 string
+	Version               string
+	ProductTypeIdentifier string
+	// Units is negative for refunds.
+	Units              int64
+	DeveloperProceeds  Decimal
+	BeginDate          time.Time
+	EndDate            time.Time
+	CustomerCurrency   Currency
+	CountryCode        string
+	CurrencyOfProceeds Currency
+	AppleIdentifier    string
+	CustomerPrice      Decimal
+	PromoCode          string
+	ParentIdentifier   string
+	Subscription       string
+	Period             string
+	Category           string
+	CMB                string
+	Device             string
+	SupportedPlatforms string
+	ProceedsReason     string
+	PreservedPricing   string
+	Client             string
+	OrderType          string
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadSales returns the next row of a SALES report, or io.EOF after the last row.
+func (r *Reader) ReadSales() (*SalesRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &SalesRow{
+		Provider:              record.string("Provider"),
+		ProviderCountry:       record.string("Provider Country"),
+		SKU:                   record.string("SKU"),
+		Developer:             record.string("Developer"),
+		Title:                 record.string("Title"),
+		Version:               record.string("Version"),
+		ProductTypeIdentifier: record.string("Product Type Identifier"),
+		Units:                 record.int("Units"),
+		DeveloperProceeds:     record.decimal("Developer Proceeds"),
+		BeginDate:             record.date("Begin Date"),
+		EndDate:               record.date("End Date"),
+		CustomerCurrency:      record.currency("Customer Currency"),
+		CountryCode:           record.string("Country Code"),
+		CurrencyOfProceeds:    record.currency("Currency of Proceeds"),
+		AppleIdentifier:       record.string("Apple Identifier"),
+		CustomerPrice:         record.decimal("Customer Price"),
+		PromoCode:             record.string("Promo Code"),
+		ParentIdentifier:      record.string("Parent Identifier"),
+		Subscription:          record.string("Subscription"),
+		Period:                record.string("Period"),
+		Category:              record.string("Category"),
+		CMB:                   record.string("CMB"),
+		Device:                record.string("Device"),
+		SupportedPlatforms:    record.string("Supported Platforms"),
+		ProceedsReason:        record.string("Proceeds Reason"),
+		PreservedPricing:      record.string("Preserved Pricing"),
+		Client:                record.string("Client"),
+		OrderType:             record.string("Order Type"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
+
+// SubscriptionRow is a row of a SUBSCRIPTION report: the active subscriptions of one subscription
+// at one price, in one country, on the report's day.
+type SubscriptionRow struct {
+	AppName                      string
+	AppAppleID                   string
+	SubscriptionName             string
+	SubscriptionAppleID          string
+	SubscriptionGroupID          string
+	StandardSubscriptionDuration string
+	SubscriptionOfferName        string
+	PromotionalOfferID           string
+	CustomerPrice                Decimal
+	CustomerCurrency             Currency
+	DeveloperProceeds            Decimal
+	ProceedsCurrency             Currency
+	PreservedPricing             string
+	ProceedsReason               string
+	Client                       string
+	Device                       string
+	State                        string
+	Country                      string
+
+	ActiveStandardPriceSubscriptions               int64
+	ActiveFreeTrialIntroductoryOfferSubscriptions  int64
+	ActivePayUpFrontIntroductoryOfferSubscriptions int64
+	ActivePayAsYouGoIntroductoryOfferSubscriptions int64
+	FreeTrialPromotionalOfferSubscriptions         int64
+	PayUpFrontPromotionalOfferSubscriptions        int64
+	PayAsYouGoPromotionalOfferSubscriptions        int64
+	FreeTrialOfferCodeSubscriptions                int64
+	PayUpFrontOfferCodeSubscriptions               int64
+	PayAsYouGoOfferCodeSubscriptions               int64
+	MarketingOptIns                                int64
+	BillingRetry                                   int64
+	GracePeriod                                    int64
+	Subscribers                                    int64
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadSubscription returns the next row of a SUBSCRIPTION report, or io.EOF after the last row.
+func (r *Reader) ReadSubscription() (*SubscriptionRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &SubscriptionRow{
+		AppName:                      record.string("App Name"),
+		AppAppleID:                   record.string("App Apple ID"),
+		SubscriptionName:             record.string("Subscription Name"),
+		SubscriptionAppleID:          record.string("Subscription Apple ID"),
+		SubscriptionGroupID:          record.string("Subscription Group ID"),
+		StandardSubscriptionDuration: record.string("Standard Subscription Duration", "Subscription Duration"),
+		SubscriptionOfferName:        record.string("Subscription Offer Name", "Promotional Offer Name"),
+		PromotionalOfferID:           record.string("Promotional Offer ID"),
+		CustomerPrice:                record.decimal("Customer Price"),
+		CustomerCurrency:             record.currency("Customer Currency"),
+		DeveloperProceeds:            record.decimal("Developer Proceeds"),
+		ProceedsCurrency:             record.currency("Proceeds Currency"),
+		PreservedPricing:             record.string("Preserved Pricing"),
+		ProceedsReason:               record.string("Proceeds Reason"),
+		Client:                       record.string("Client"),
+		Device:                       record.string("Device"),
+		State:                        record.string("State"),
+		Country:                      record.string("Country"),
+
+		ActiveStandardPriceSubscriptions:               record.int("Active Standard Price Subscriptions", "Active Subscriptions"),
+		ActiveFreeTrialIntroductoryOfferSubscriptions:  record.int("Active Free Trial Introductory Offer Subscriptions", "Active Free Trials"),
+		ActivePayUpFrontIntroductoryOfferSubscriptions: record.int("Active Pay Up Front Introductory Offer Subscriptions", "Active Pay Up Front Introductory Price"),
+		ActivePayAsYouGoIntroductoryOfferSubscriptions: record.int("Active Pay As You Go Introductory Offer Subscriptions", "Active Pay As You Go Introductory Price"),
+		FreeTrialPromotionalOfferSubscriptions:         record.int("Free Trial Promotional Offer Subscriptions"),
+		PayUpFrontPromotionalOfferSubscriptions:        record.int("Pay Up Front Promotional Offer Subscriptions"),
+		PayAsYouGoPromotionalOfferSubscriptions:        record.int("Pay As You Go Promotional Offer Subscriptions"),
+		FreeTrialOfferCodeSubscriptions:                record.int("Free Trial Offer Code Subscriptions"),
+		PayUpFrontOfferCodeSubscriptions:               record.int("Pay Up Front Offer Code Subscriptions"),
+		PayAsYouGoOfferCodeSubscriptions:               record.int("Pay As You Go Offer Code Subscriptions"),
+		MarketingOptIns:                                record.int("Marketing Opt-Ins"),
+		BillingRetry:                                   record.int("Billing Retry"),
+		GracePeriod:                                    record.int("Grace Period"),
+		Subscribers:                                    record.int("Subscribers"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
+
+// SubscriptionEventRow is a row of a SUBSCRIPTION_EVENT report: how many times an event, such as a
+// renewal or a cancellation, happened to one subscription on one day.
+type SubscriptionEventRow struct {
+	EventDate                    time.Time
+	Event                        string
+	AppName                      string
+	AppAppleID                   string
+	SubscriptionName             string
+	SubscriptionAppleID          string
+	SubscriptionGroupID          string
+	StandardSubscriptionDuration string
+	SubscriptionOfferType        string
+	SubscriptionOfferDuration    string
+	MarketingOptIn               string
+	MarketingOptInDuration       string
+	PreservedPricing             string
+	ProceedsReason               string
+	SubscriptionOfferName        string
+	PromotionalOfferID           string
+	ConsecutivePaidPeriods       int64
+	OriginalStartDate            time.Time
+	Device                       string
+	Client                       string
+	State                        string
+	Country                      string
+	PreviousSubscriptionName     string
+	PreviousSubscriptionAppleID  string
+	DaysBeforeCanceling          int64
+	CancellationReason           string
+	DaysCanceled                 int64
+	Quantity                     int64
+	PaidServiceDaysRecovered     int64
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadSubscriptionEvent returns the next row of a SUBSCRIPTION_EVENT report, or io.EOF after the
+// last row.
+func (r *Reader) ReadSubscriptionEvent() (*SubscriptionEventRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &SubscriptionEventRow{
+		EventDate:                    record.date("Event Date"),
+		Event:                        record.string("Event"),
+		AppName:                      record.string("App Name"),
+		AppAppleID:                   record.string("App Apple ID"),
+		SubscriptionName:             record.string("Subscription Name"),
+		SubscriptionAppleID:          record.string("Subscription Apple ID"),
+		SubscriptionGroupID:          record.string("Subscription Group ID"),
+		StandardSubscriptionDuration: record.string("Standard Subscription Duration", "Subscription Duration"),
+		SubscriptionOfferType:        record.string("Subscription Offer Type", "Introductory Price Type"),
+		SubscriptionOfferDuration:    record.string("Subscription Offer Duration", "Introductory Price Duration"),
+		MarketingOptIn:               record.string("Marketing Opt-In"),
+		MarketingOptInDuration:       record.string("Marketing Opt-In Duration"),
+		PreservedPricing:             record.string("Preserved Pricing"),
+		ProceedsReason:               record.string("Proceeds Reason"),
+		SubscriptionOfferName:        record.string("Subscription Offer Name", "Promotional Offer Name"),
+		PromotionalOfferID:           record.string("Promotional Offer ID"),
+		ConsecutivePaidPeriods:       record.int("Consecutive Paid Periods"),
+		OriginalStartDate:            record.date("Original Start Date"),
+		Device:                       record.string("Device"),
+		Client:                       record.string("Client"),
+		State:                        record.string("State"),
+		Country:                      record.string("Country"),
+		PreviousSubscriptionName:     record.string("Previous Subscription Name"),
+		PreviousSubscriptionAppleID:  record.string("Previous Subscription Apple ID"),
+		DaysBeforeCanceling:          record.int("Days Before Canceling"),
+		CancellationReason:           record.string("Cancellation Reason"),
+		DaysCanceled:                 record.int("Days Canceled"),
+		Quantity:                     record.int("Quantity"),
+		PaidServiceDaysRecovered:     record.int("Paid Service Days Recovered"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
+
+// SubscriberRow is a row of a SUBSCRIBER report: one transaction of one subscriber, identified by
+// an anonymous ID.
+type SubscriberRow struct {
+	EventDate                    time.Time
+	AppName                      string
+	AppAppleID                   string
+	SubscriptionName             string
+	SubscriptionAppleID          string
+	SubscriptionGroupID          string
+	StandardSubscriptionDuration string
+	SubscriptionOfferName        string
+	PromotionalOfferID           string
+	SubscriptionOfferType        string
+	SubscriptionOfferDuration    string
+	MarketingOptInDuration       string
+	CustomerPrice                Decimal
+	CustomerCurrency             Currency
+	DeveloperProceeds            Decimal
+	ProceedsCurrency             Currency
+	PreservedPricing             string
+	ProceedsReason               string
+	Client                       string
+	Device                       string
+	Country                      string
+	SubscriberID                 string
+	SubscriberIDReset            string
+	Refund                       string
+	PurchaseDate                 time.Time
+	Units                        int64
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadSubscriber returns the next row of a SUBSCRIBER report, or io.EOF after the last row.
+func (r *Reader) ReadSubscriber() (*SubscriberRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &SubscriberRow{
+		EventDate:                    record.date("Event Date"),
+		AppName:                      record.string("App Name"),
+		AppAppleID:                   record.string("App Apple ID"),
+		SubscriptionName:             record.string("Subscription Name"),
+		SubscriptionAppleID:          record.string("Subscription Apple ID"),
+		SubscriptionGroupID:          record.string("Subscription Group ID"),
+		StandardSubscriptionDuration: record.string("Standard Subscription Duration", "Subscription Duration"),
+		SubscriptionOfferName:        record.string("Subscription Offer Name", "Promotional Offer Name"),
+		PromotionalOfferID:           record.string("Promotional Offer ID"),
+		SubscriptionOfferType:        record.string("Subscription Offer Type", "Introductory Price Type"),
+		SubscriptionOfferDuration:    record.string("Subscription Offer Duration", "Introductory Price Duration"),
+		MarketingOptInDuration:       record.string("Marketing Opt-In Duration"),
+		CustomerPrice:                record.decimal("Customer Price"),
+		CustomerCurrency:             record.currency("Customer Currency"),
+		DeveloperProceeds:            record.decimal("Developer Proceeds"),
+		ProceedsCurrency:             record.currency("Proceeds Currency"),
+		PreservedPricing:             record.string("Preserved Pricing"),
+		ProceedsReason:               record.string("Proceeds Reason"),
+		Client:                       record.string("Client"),
+		Device:                       record.string("Device"),
+		Country:                      record.string("Country"),
+		SubscriberID:                 record.string("Subscriber ID"),
+		SubscriberIDReset:            record.string("Subscriber ID Reset"),
+		Refund:                       record.string("Refund"),
+		PurchaseDate:                 record.date("Purchase Date"),
+		Units:                        record.int("Units"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
+
+// NewsstandRow is a row of a NEWSSTAND DETAILED report: one download of a Newsstand issue or
+// subscription.
+type NewsstandRow struct {
+	Provider              string
+	ProviderCountry       string
+	SKU                   string
+	Developer             string
+	Title                 string
+	Version               string
+	ProductTypeIdentifier string
+	Units                 int64
+	DeveloperProceeds     Decimal
+	CustomerCurrency      Currency
+	CountryCode           string
+	CurrencyOfProceeds    Currency
+	AppleIdentifier       string
+	CustomerPrice         Decimal
+	PromoCode             string
+	ParentIdentifier      string
+	Subscription          string
+	Period                string
+	DownloadDate          time.Time
+	CustomerIdentifier    string
+	ReportDate            time.Time
+	SalesOrReturn         string
+	Category              string
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadNewsstand returns the next row of a NEWSSTAND report, or io.EOF after the last row.
+func (r *Reader) ReadNewsstand() (*NewsstandRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &NewsstandRow{
+		Provider:              record.string("Provider"),
+		ProviderCountry:       record.string("Provider Country"),
+		SKU:                   record.string("SKU"),
+		Developer:             record.string("Developer"),
+		Title:                 record.string("Title"),
+		Version:               record.string("Version"),
+		ProductTypeIdentifier: record.string("Product Type Identifier"),
+		Units:                 record.int("Units"),
+		DeveloperProceeds:     record.decimal("Developer Proceeds"),
+		CustomerCurrency:      record.currency("Customer Currency"),
+		CountryCode:           record.string("Country Code"),
+		CurrencyOfProceeds:    record.currency("Currency of Proceeds"),
+		AppleIdentifier:       record.string("Apple Identifier"),
+		CustomerPrice:         record.decimal("Customer Price"),
+		PromoCode:             record.string("Promo Code"),
+		ParentIdentifier:      record.string("Parent Identifier"),
+		Subscription:          record.string("Subscription"),
+		Period:                record.string("Period"),
+		DownloadDate:          record.date("Download Date (PST)", "Download Date"),
+		CustomerIdentifier:    record.string("Customer Identifier"),
+		ReportDate:            record.date("Report Date (Local)", "Report Date"),
+		SalesOrReturn:         record.string("Sales/Return"),
+		Category:              record.string("Category"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
+
+// PreOrderRow is a row of a PRE_ORDER report: the pre-orders of one app in one country.
+type PreOrderRow struct {
+	Provider           string
+	ProviderCountry    string
+	Title              string
+	SKU                string
+	Developer          string
+	PreOrderStartDate  time.Time
+	PreOrderEndDate    time.Time
+	Ordered            int64
+	Canceled           int64
+	CumulativeOrdered  int64
+	CumulativeCanceled int64
+	StartDate          time.Time
+	EndDate            time.Time
+	CountryCode        string
+	AppleIdentifier    string
+	Device             string
+	SupportedPlatforms string
+	Category           string
+	Client             string
+	// Extra holds the columns this type does not know, by name.
+	Extra map[string]string
+}
+
+// ReadPreOrder returns the next row of a PRE_ORDER report, or io.EOF after the last row.
+func (r *Reader) ReadPreOrder() (*PreOrderRow, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &PreOrderRow{
+		Provider:           record.string("Provider"),
+		ProviderCountry:    record.string("Provider Country"),
+		Title:              record.string("Title"),
+		SKU:                record.string("SKU"),
+		Developer:          record.string("Developer"),
+		PreOrderStartDate:  record.date("Pre-Order Start Date"),
+		PreOrderEndDate:    record.date("Pre-Order End Date"),
+		Ordered:            record.int("Ordered"),
+		Canceled:           record.int("Canceled"),
+		CumulativeOrdered:  record.int("Cumulative Ordered"),
+		CumulativeCanceled: record.int("Cumulative Canceled"),
+		StartDate:          record.date("Start Date"),
+		EndDate:            record.date("End Date"),
+		CountryCode:        record.string("Country Code"),
+		AppleIdentifier:    record.string("Apple Identifier"),
+		Device:             record.string("Device"),
+		SupportedPlatforms: record.string("Supported Platforms"),
+		Category:           record.string("Category"),
+		Client:             record.string("Client"),
+	}
+	if err := record.Err(); err != nil {
+		return nil, err
+	}
+
+	row.Extra = record.extra()
+
+	return row, nil
+}
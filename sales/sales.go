@@ -0,0 +1,235 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package sales parses the Sales and Trends reports downloaded with
+asc.ReportingService.DownloadSalesAndTrendsReports into typed rows, with dates, decimal amounts and
+currencies already parsed.
+
+A Reader streams a report one row at a time, so that even large subscriber reports are read in
+constant memory. Each report type has its own read method:
+
+	reader, err := sales.Download(ctx, client, sales.SalesSummary, "85012345", sales.Daily, day)
+	for {
+		row, err := reader.ReadSales()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		fmt.Println(row.SKU, row.Units, row.DeveloperProceeds, row.CurrencyOfProceeds)
+	}
+
+Download streams the report from the response as the rows are read, so the Reader must be closed
+when it is not read to the end. A report saved to a file can be read by passing the file to
+NewReader.
+*/
+package sales
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+// ReportType is the filter[reportType] of a Sales and Trends report.
+type ReportType string
+
+const (
+	// ReportTypeSales is read with Reader.ReadSales.
+	ReportTypeSales ReportType = "SALES"
+	// ReportTypeSubscription is read with Reader.ReadSubscription.
+	ReportTypeSubscription ReportType = "SUBSCRIPTION"
+	// ReportTypeSubscriptionEvent is read with Reader.ReadSubscriptionEvent.
+	ReportTypeSubscriptionEvent ReportType = "SUBSCRIPTION_EVENT"
+	// ReportTypeSubscriber is read with Reader.ReadSubscriber.
+	ReportTypeSubscriber ReportType = "SUBSCRIBER"
+	// ReportTypeNewsstand is read with Reader.ReadNewsstand.
+	ReportTypeNewsstand ReportType = "NEWSSTAND"
+	// ReportTypePreOrder is read with Reader.ReadPreOrder.
+	ReportTypePreOrder ReportType = "PRE_ORDER"
+)
+
+// SubType is the filter[reportSubType] of a Sales and Trends report.
+type SubType string
+
+const (
+	// SubTypeSummary aggregates rows by product, country and price.
+	SubTypeSummary SubType = "SUMMARY"
+	// SubTypeDetailed lists individual transactions.
+	SubTypeDetailed SubType = "DETAILED"
+)
+
+// Frequency is the period a Sales and Trends report covers.
+type Frequency string
+
+const (
+	// Daily reports cover a day, in Pacific Time.
+	Daily Frequency = "DAILY"
+	// Weekly reports cover a week from Monday to Sunday.
+	Weekly Frequency = "WEEKLY"
+	// Monthly reports cover a calendar month.
+	Monthly Frequency = "MONTHLY"
+	// Yearly reports cover a calendar year.
+	Yearly Frequency = "YEARLY"
+)
+
+// ReportDate returns the filter[reportDate] of the report with this frequency that covers t. Weekly
+// reports are named after the Sunday that ends their week.
+func (f Frequency) ReportDate(t time.Time) string {
+	switch f {
+	case Weekly:
+		days := (7 - int(t.Weekday())) % 7
+
+		return t.AddDate(0, 0, days).Format("2006-01-02")
+	case Monthly:
+		return t.Format("2006-01")
+	case Yearly:
+		return t.Format("2006")
+	}
+
+	return t.Format("2006-01-02")
+}
+
+// ErrUnsupportedFrequency is returned by Download for a frequency a report is not available in.
+type ErrUnsupportedFrequency struct {
+	Type      ReportType
+	Frequency Frequency
+}
+
+func (e ErrUnsupportedFrequency) Error() string {
+	return fmt.Sprintf("%s reports are not available %s", e.Type, e.Frequency)
+}
+
+// Report identifies a kind of Sales and Trends report: its type, sub-type and version, and the
+// frequencies it is available in.
+type Report struct {
+	Type        ReportType
+	SubType     SubType
+	Version     string
+	Frequencies []Frequency
+}
+
+// The reports each read method parses, at the latest version this package knows. Older versions
+// are read too; copy a Report and change its Version to download them.
+var (
+	SalesSummary = Report{
+		Type:        ReportTypeSales,
+		SubType:     SubTypeSummary,
+		Version:     "1_1",
+		Frequencies: []Frequency{Daily, Weekly, Monthly, Yearly},
+	}
+	Subscription = Report{
+		Type:        ReportTypeSubscription,
+		SubType:     SubTypeSummary,
+		Version:     "1_3",
+		Frequencies: []Frequency{Daily},
+	}
+	SubscriptionEvent = Report{
+		Type:        ReportTypeSubscriptionEvent,
+		SubType:     SubTypeSummary,
+		Version:     "1_3",
+		Frequencies: []Frequency{Daily},
+	}
+	Subscriber = Report{
+		Type:        ReportTypeSubscriber,
+		SubType:     SubTypeDetailed,
+		Version:     "1_3",
+		Frequencies: []Frequency{Daily},
+	}
+	NewsstandDetailed = Report{
+		Type:        ReportTypeNewsstand,
+		SubType:     SubTypeDetailed,
+		Version:     "1_0",
+		Frequencies: []Frequency{Daily, Weekly},
+	}
+	PreOrderSummary = Report{
+		Type:        ReportTypePreOrder,
+		SubType:     SubTypeSummary,
+		Version:     "1_0",
+		Frequencies: []Frequency{Daily, Weekly, Monthly, Yearly},
+	}
+)
+
+// Supports reports whether the report is available in the frequency.
+func (r Report) Supports(frequency Frequency) bool {
+	for _, f := range r.Frequencies {
+		if f == frequency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Query returns the parameters that download the report of a vendor for the period covering date.
+func (r Report) Query(vendorNumber string, frequency Frequency, date time.Time) *asc.DownloadSalesAndTrendsReportsQuery {
+	query := &asc.DownloadSalesAndTrendsReportsQuery{
+		FilterFrequency:     []string{string(frequency)},
+		FilterReportDate:    []string{frequency.ReportDate(date)},
+		FilterReportSubType: []string{string(r.SubType)},
+		FilterReportType:    []string{string(r.Type)},
+		FilterVendorNumber:  []string{vendorNumber},
+	}
+
+	if r.Version != "" {
+		query.FilterVersion = []string{r.Version}
+	}
+
+	return query
+}
+
+// Download fetches the report of a vendor for the period covering date, and returns a Reader over
+// its rows. The report is read from the response as the rows are, rather than downloaded first; an
+// error that interrupts the download is returned by the read methods. Close the Reader to stop the
+// download early.
+func Download(ctx context.Context, client *asc.Client, report Report, vendorNumber string, frequency Frequency, date time.Time) (*Reader, error) {
+	if !report.Supports(frequency) {
+		return nil, ErrUnsupportedFrequency{Type: report.Type, Frequency: frequency}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	body, w := io.Pipe()
+
+	go func() {
+		_, err := client.Reporting.DownloadSalesAndTrendsReportsTo(ctx, report.Query(vendorNumber, frequency, date), w)
+		w.CloseWithError(err)
+	}()
+
+	closeBody := func() error {
+		cancel()
+
+		return body.Close()
+	}
+
+	reader, err := NewReader(body)
+	if err != nil {
+		_ = closeBody()
+
+		return nil, err
+	}
+
+	reader.close = closeBody
+
+	return reader, nil
+}
@@ -0,0 +1,118 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+	"github.com/tutorioapp/asc-go/internal/apitest"
+)
+
+func TestReportDate(t *testing.T) {
+	t.Parallel()
+
+	wednesday := time.Date(2020, time.March, 11, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2020, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2020-03-11", Daily.ReportDate(wednesday))
+	assert.Equal(t, "2020-03-15", Weekly.ReportDate(wednesday))
+	assert.Equal(t, "2020-03-15", Weekly.ReportDate(sunday))
+	assert.Equal(t, "2020-03", Monthly.ReportDate(wednesday))
+	assert.Equal(t, "2020", Yearly.ReportDate(wednesday))
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	query := Subscriber.Query("85012345", Daily, time.Date(2020, time.March, 11, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, &asc.DownloadSalesAndTrendsReportsQuery{
+		FilterFrequency:     []string{"DAILY"},
+		FilterReportDate:    []string{"2020-03-11"},
+		FilterReportSubType: []string{"DETAILED"},
+		FilterReportType:    []string{"SUBSCRIBER"},
+		FilterVendorNumber:  []string{"85012345"},
+		FilterVersion:       []string{"1_3"},
+	}, query)
+
+	report := SalesSummary
+	report.Version = ""
+	assert.Nil(t, report.Query("85012345", Daily, time.Now()).FilterVersion)
+}
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+
+	var query url.Values
+
+	client := apitest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/salesReports" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/a-gzip")
+		_, _ = w.Write(gzipped(t, salesReport))
+	}))
+
+	reader, err := Download(context.Background(), client, SalesSummary, "85012345", Weekly, time.Date(2020, time.March, 11, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	defer reader.Close()
+
+	assert.Equal(t, "WEEKLY", query.Get("filter[frequency]"))
+	assert.Equal(t, "2020-03-15", query.Get("filter[reportDate]"))
+	assert.Equal(t, "SALES", query.Get("filter[reportType]"))
+	assert.Equal(t, "SUMMARY", query.Get("filter[reportSubType]"))
+	assert.Equal(t, "1_1", query.Get("filter[version]"))
+
+	row, err := reader.ReadSales()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), row.Units)
+}
+
+func TestDownloadReturnsErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	client := apitest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":"404","code":"NOT_FOUND"}]}`)
+	}))
+
+	_, err := Download(context.Background(), client, SalesSummary, "85012345", Daily, time.Date(2020, time.March, 11, 0, 0, 0, 0, time.UTC))
+	assert.IsType(t, &asc.ErrorResponse{}, err)
+}
+
+func TestDownloadUnsupportedFrequency(t *testing.T) {
+	t.Parallel()
+
+	_, err := Download(context.Background(), asc.NewClient(nil), Subscription, "85012345", Monthly, time.Now())
+	assert.Equal(t, ErrUnsupportedFrequency{Type: ReportTypeSubscription, Frequency: Monthly}, err)
+}
@@ -0,0 +1,166 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDecimalDigits is the number of digits a Decimal can hold without overflowing an int64.
+const maxDecimalDigits = 18
+
+// dateLayouts are the date formats used across report types and versions.
+var dateLayouts = []string{
+	"01/02/2006",
+	"2006-01-02",
+	"01/02/2006 15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+var errInvalidDecimal = errors.New("not a decimal number")
+
+// Decimal is an exact decimal number, such as a price or proceeds. It is kept as an integer and a
+// number of digits after the decimal point so that amounts add up without rounding errors.
+type Decimal struct {
+	Unscaled int64
+	Scale    int
+}
+
+// ParseDecimal parses a decimal number such as "-1.99". An empty string is zero.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, nil
+	}
+
+	digits := s
+	if s[0] == '+' || s[0] == '-' {
+		digits = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+
+	if whole+fraction == "" || len(whole)+len(fraction) > maxDecimalDigits || strings.ContainsAny(whole+fraction, "+-") {
+		return Decimal{}, errInvalidDecimal
+	}
+
+	unscaled, err := strconv.ParseUint(whole+fraction, 10, 64)
+	if err != nil {
+		return Decimal{}, errInvalidDecimal
+	}
+
+	d := Decimal{Unscaled: int64(unscaled), Scale: len(fraction)}
+	if strings.HasPrefix(s, "-") {
+		d.Unscaled = -d.Unscaled
+	}
+
+	return d, nil
+}
+
+// IsZero reports whether the number is zero.
+func (d Decimal) IsZero() bool {
+	return d.Unscaled == 0
+}
+
+// Add returns the sum of two numbers, with the larger of their scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	for d.Scale < other.Scale {
+		d = Decimal{Unscaled: d.Unscaled * 10, Scale: d.Scale + 1}
+	}
+
+	for other.Scale < d.Scale {
+		other = Decimal{Unscaled: other.Unscaled * 10, Scale: other.Scale + 1}
+	}
+
+	return Decimal{Unscaled: d.Unscaled + other.Unscaled, Scale: d.Scale}
+}
+
+// Mul returns the number multiplied by an integer, such as a price by a number of units.
+func (d Decimal) Mul(n int64) Decimal {
+	return Decimal{Unscaled: d.Unscaled * n, Scale: d.Scale}
+}
+
+// Float64 returns the nearest floating-point number.
+func (d Decimal) Float64() float64 {
+	return float64(d.Unscaled) / math.Pow10(d.Scale)
+}
+
+func (d Decimal) String() string {
+	digits := strconv.FormatInt(d.Unscaled, 10)
+
+	sign := ""
+	if d.Unscaled < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	if d.Scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+}
+
+// Currency is an ISO 4217 currency code, such as "USD".
+type Currency string
+
+func parseCurrency(s string) (Currency, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", true
+	}
+
+	if len(s) != 3 {
+		return "", false
+	}
+
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return "", false
+		}
+	}
+
+	return Currency(s), true
+}
+
+// parseDate parses the dates of any report. They have no time zone; the result is in UTC. An empty
+// string is the zero time.
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, true
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,108 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sales
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want Decimal
+		str  string
+	}{
+		{"", Decimal{}, "0"},
+		{"12", Decimal{Unscaled: 12}, "12"},
+		{"1.99", Decimal{Unscaled: 199, Scale: 2}, "1.99"},
+		{"-0.70", Decimal{Unscaled: -70, Scale: 2}, "-0.70"},
+		{".5", Decimal{Unscaled: 5, Scale: 1}, "0.5"},
+		{" 3.000 ", Decimal{Unscaled: 3000, Scale: 3}, "3.000"},
+	}
+
+	for _, test := range tests {
+		got, err := ParseDecimal(test.in)
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.want, got, test.in)
+		assert.Equal(t, test.str, got.String(), test.in)
+	}
+
+	for _, in := range []string{"abc", "1.2.3", "--1", "1,99", ".", "1234567890123456789"} {
+		_, err := ParseDecimal(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	t.Parallel()
+
+	price, _ := ParseDecimal("0.99")
+	proceeds, _ := ParseDecimal("-0.7")
+
+	assert.Equal(t, "1.98", price.Mul(2).String())
+	assert.Equal(t, "0.29", price.Add(proceeds).String())
+	assert.Equal(t, "0.29", proceeds.Add(price).String())
+	assert.InDelta(t, 0.99, price.Float64(), 1e-9)
+	assert.True(t, Decimal{Scale: 2}.IsZero())
+}
+
+func TestParseCurrency(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]Currency{"USD": "USD", " EUR": "EUR", "": ""} {
+		got, ok := parseCurrency(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"usd", "US", "USDT", "U$D"} {
+		_, ok := parseCurrency(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2020, time.March, 9, 0, 0, 0, 0, time.UTC)
+
+	for _, in := range []string{"03/09/2020", "2020-03-09"} {
+		got, ok := parseDate(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	got, ok := parseDate("2020-03-09 13:04:05")
+	assert.True(t, ok)
+	assert.Equal(t, want.Add(13*time.Hour+4*time.Minute+5*time.Second), got)
+
+	got, ok = parseDate("")
+	assert.True(t, ok)
+	assert.True(t, got.IsZero())
+
+	_, ok = parseDate("9 March 2020")
+	assert.False(t, ok)
+}
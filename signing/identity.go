@@ -0,0 +1,193 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package signing creates code signing identities without macOS tooling.
+
+CreateIdentity generates a key pair, builds a certificate signing request for it, asks App Store
+Connect for a certificate and returns the key and certificate together. The identity can then be
+exported as a password-protected PKCS#12 bundle and imported into a keychain on the machine that
+signs:
+
+	identity, err := signing.CreateIdentity(ctx, client, signing.IdentityOptions{
+		CertificateType: asc.CertificateTypeiOSDistribution,
+		CommonName:      "CI distribution",
+	})
+	bundle, err := identity.PKCS12(password)
+	err = os.WriteFile("distribution.p12", bundle, 0o600)
+*/
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+// DefaultRSABits is the size of RSA keys generated when IdentityOptions.RSABits is zero. Apple
+// requires 2048-bit keys for most certificate types.
+const DefaultRSABits = 2048
+
+// ErrMissingCertificateContent happens when App Store Connect returns a certificate without its content.
+var ErrMissingCertificateContent = errors.New("certificate has no content")
+
+// KeyAlgorithm is the kind of key pair generated for an identity.
+type KeyAlgorithm string
+
+const (
+	// KeyAlgorithmRSA generates an RSA key pair.
+	KeyAlgorithmRSA KeyAlgorithm = "RSA"
+	// KeyAlgorithmECDSA generates an ECDSA key pair on the P-256 curve.
+	KeyAlgorithmECDSA KeyAlgorithm = "ECDSA"
+)
+
+// ErrUnsupportedKeyAlgorithm happens when a key algorithm is not one of the KeyAlgorithm constants.
+type ErrUnsupportedKeyAlgorithm struct {
+	Algorithm KeyAlgorithm
+}
+
+func (e ErrUnsupportedKeyAlgorithm) Error() string {
+	return fmt.Sprintf("unsupported key algorithm %q", e.Algorithm)
+}
+
+// GenerateKey generates a private key. bits is the size of RSA keys, and DefaultRSABits when zero. It
+// is ignored for ECDSA.
+func GenerateKey(algorithm KeyAlgorithm, bits int) (crypto.Signer, error) {
+	switch algorithm {
+	case KeyAlgorithmRSA, "":
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+
+		return rsa.GenerateKey(rand.Reader, bits)
+	case KeyAlgorithmECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, ErrUnsupportedKeyAlgorithm{Algorithm: algorithm}
+	}
+}
+
+// NewCSR returns a PEM-encoded certificate signing request for key.
+func NewCSR(key crypto.Signer, subject pkix.Name, emailAddress string) ([]byte, error) {
+	template := &x509.CertificateRequest{Subject: subject}
+	if emailAddress != "" {
+		template.EmailAddresses = []string{emailAddress}
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// DecodeCertificateContent parses the base64-encoded DER in asc.CertificateAttributes.CertificateContent.
+func DecodeCertificateContent(content string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+// IdentityOptions describes the identity created by CreateIdentity.
+type IdentityOptions struct {
+	CertificateType asc.CertificateType
+	// KeyAlgorithm defaults to KeyAlgorithmRSA.
+	KeyAlgorithm KeyAlgorithm
+	// RSABits defaults to DefaultRSABits.
+	RSABits int
+	// CommonName and EmailAddress are written to the certificate signing request. App Store
+	// Connect replaces the subject with the team's, but requires a common name.
+	CommonName   string
+	EmailAddress string
+}
+
+// Identity is a certificate issued by App Store Connect together with its private key.
+type Identity struct {
+	Resource    asc.Certificate
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+}
+
+// CreateIdentity generates a key pair, requests a certificate for it with
+// ProvisioningService.CreateCertificate, and returns both. The private key only ever exists in
+// memory, so the identity must be exported before it is discarded.
+func CreateIdentity(ctx context.Context, client *asc.Client, options IdentityOptions) (*Identity, error) {
+	key, err := GenerateKey(options.KeyAlgorithm, options.RSABits)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := NewCSR(key, pkix.Name{CommonName: options.CommonName}, options.EmailAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := client.Provisioning.CreateCertificate(ctx, options.CertificateType, strings.NewReader(string(csr)))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Data.Attributes == nil || res.Data.Attributes.CertificateContent == nil {
+		return nil, ErrMissingCertificateContent
+	}
+
+	certificate, err := DecodeCertificateContent(*res.Data.Attributes.CertificateContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{Resource: res.Data, Certificate: certificate, PrivateKey: key}, nil
+}
+
+// PKCS12 exports the identity as a PKCS#12 bundle protected by password.
+func (i *Identity) PKCS12(password string) ([]byte, error) {
+	return EncodePKCS12(i.PrivateKey, i.Certificate, password)
+}
+
+// PrivateKeyPEM returns the identity's private key as a PEM-encoded, unencrypted PKCS#8 block.
+func (i *Identity) PrivateKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(i.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// CertificatePEM returns the identity's certificate as a PEM block.
+func (i *Identity) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.Certificate.Raw})
+}
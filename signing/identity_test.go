@@ -0,0 +1,176 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tutorioapp/asc-go/asc"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newCertificateServer issues a certificate for every CSR posted to /v1/certificates, like App Store
+// Connect does, using a throwaway CA.
+func newCertificateServer(t *testing.T) *asc.Client {
+	t.Helper()
+
+	caKey, err := GenerateKey(KeyAlgorithmECDSA, 0)
+	assert.NoError(t, err)
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test WWDR"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data struct {
+				Attributes struct {
+					CertificateType string `json:"certificateType"`
+					CsrContent      string `json:"csrContent"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/certificates" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		block, _ := pem.Decode([]byte(body.Data.Attributes.CsrContent))
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil || csr.CheckSignature() != nil {
+			w.WriteHeader(http.StatusConflict)
+
+			return
+		}
+
+		der, _ := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "iPhone Distribution: Team", OrganizationalUnit: []string{"TEAMID"}},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		}, ca, csr.PublicKey, caKey)
+
+		fmt.Fprintf(w, `{"data":{"id":"cert","attributes":{"certificateType":%q,"certificateContent":%q}}}`,
+			body.Data.Attributes.CertificateType, base64.StdEncoding.EncodeToString(der))
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+
+	return asc.NewClient(&http.Client{Transport: rewriteTransport{target: target}})
+}
+
+func TestCreateIdentity(t *testing.T) {
+	t.Parallel()
+
+	client := newCertificateServer(t)
+
+	identity, err := CreateIdentity(context.Background(), client, IdentityOptions{
+		CertificateType: asc.CertificateTypeiOSDistribution,
+		CommonName:      "CI",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cert", identity.Resource.ID)
+	assert.Equal(t, "iPhone Distribution: Team", identity.Certificate.Subject.CommonName)
+	assert.IsType(t, &rsa.PrivateKey{}, identity.PrivateKey)
+	assert.Equal(t, DefaultRSABits, identity.PrivateKey.(*rsa.PrivateKey).N.BitLen())
+	assert.True(t, identity.PrivateKey.Public().(*rsa.PublicKey).Equal(identity.Certificate.PublicKey))
+
+	key, err := identity.PrivateKeyPEM()
+	assert.NoError(t, err)
+	assert.Contains(t, string(key), "BEGIN PRIVATE KEY")
+	assert.Contains(t, string(identity.CertificatePEM()), "BEGIN CERTIFICATE")
+}
+
+func TestCreateIdentityECDSA(t *testing.T) {
+	t.Parallel()
+
+	client := newCertificateServer(t)
+
+	identity, err := CreateIdentity(context.Background(), client, IdentityOptions{
+		CertificateType: asc.CertificateTypeDevelopment,
+		KeyAlgorithm:    KeyAlgorithmECDSA,
+		CommonName:      "CI",
+	})
+	assert.NoError(t, err)
+	assert.True(t, identity.PrivateKey.Public().(*ecdsa.PublicKey).Equal(identity.Certificate.PublicKey))
+}
+
+func TestGenerateKeyUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateKey("DSA", 0)
+	assert.Equal(t, ErrUnsupportedKeyAlgorithm{Algorithm: "DSA"}, err)
+}
+
+func TestNewCSR(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey(KeyAlgorithmECDSA, 0)
+	assert.NoError(t, err)
+
+	csrPEM, err := NewCSR(key, pkix.Name{CommonName: "CI"}, "ci@example.com")
+	assert.NoError(t, err)
+
+	block, _ := pem.Decode(csrPEM)
+	assert.Equal(t, "CERTIFICATE REQUEST", block.Type)
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	assert.NoError(t, err)
+	assert.NoError(t, csr.CheckSignature())
+	assert.Equal(t, "CI", csr.Subject.CommonName)
+	assert.Equal(t, []string{"ci@example.com"}, csr.EmailAddresses)
+}
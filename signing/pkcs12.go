@@ -0,0 +1,381 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint: gosec
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"unicode/utf16"
+)
+
+// The PKCS#12 encoder below follows RFC 7292 and uses the legacy algorithms that every consumer,
+// including the macOS keychain, can read: both bags are encrypted with pbeWithSHAAnd3-KeyTripleDES-CBC
+// and the integrity MAC is HMAC-SHA1.
+
+const (
+	pkcs12Iterations = 2048
+	pkcs12SaltLength = 8
+)
+
+var (
+	oidData                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedData         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidPBEWithSHAAnd3KeyTDES = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPKCS8ShroudedKeyBag   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidX509Certificate       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidSHA1                  = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+// ErrMissingCertificate happens when EncodePKCS12 is called without a certificate.
+var ErrMissingCertificate = errors.New("no certificate provided, could not encode a PKCS#12 bundle")
+
+type pfxPdu struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo encryptedContentInfo
+}
+
+type encryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm algorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type digestInfo struct {
+	Algorithm algorithmIdentifier
+	Digest    []byte
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue     `asn1:"tag:0,explicit"`
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type encryptedPrivateKeyInfo struct {
+	AlgorithmIdentifier algorithmIdentifier
+	EncryptedData       []byte
+}
+
+// EncodePKCS12 returns a PKCS#12 bundle holding a private key and its certificate, protected by
+// password. The certificate's common name is used as the friendly name shown by keychains.
+func EncodePKCS12(key interface{}, certificate *x509.Certificate, password string) ([]byte, error) {
+	if certificate == nil {
+		return nil, ErrMissingCertificate
+	}
+
+	encodedPassword := bmpString(password)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	localKeyID := sha1.Sum(certificate.Raw) // nolint: gosec
+
+	attributes, err := bagAttributes(certificate.Subject.CommonName, localKeyID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// The certificate goes in an encrypted SafeContents.
+	certBagDER, err := asn1.Marshal(certBag{ID: oidX509Certificate, Data: certificate.Raw})
+	if err != nil {
+		return nil, err
+	}
+
+	certContents, err := asn1.Marshal([]safeBag{{
+		ID:         oidCertBag,
+		Value:      explicitContent(certBagDER),
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, ciphertext, err := pbeEncrypt(encodedPassword, certContents)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedCerts, err := asn1.Marshal(encryptedData{
+		EncryptedContentInfo: encryptedContentInfo{
+			ContentType:                oidData,
+			ContentEncryptionAlgorithm: algorithm,
+			EncryptedContent:           ciphertext,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The key goes in a plain SafeContents, as a shrouded key bag that is encrypted on its own.
+	algorithm, ciphertext, err = pbeEncrypt(encodedPassword, keyDER)
+	if err != nil {
+		return nil, err
+	}
+
+	shroudedKey, err := asn1.Marshal(encryptedPrivateKeyInfo{AlgorithmIdentifier: algorithm, EncryptedData: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+
+	keyContents, err := asn1.Marshal([]safeBag{{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      explicitContent(shroudedKey),
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	keyContentsOctets, err := asn1.Marshal(keyContents)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatedSafe, err := asn1.Marshal([]contentInfo{
+		{ContentType: oidEncryptedData, Content: explicitContent(encryptedCerts)},
+		{ContentType: oidData, Content: explicitContent(keyContentsOctets)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatedSafeOctets, err := asn1.Marshal(authenticatedSafe)
+	if err != nil {
+		return nil, err
+	}
+
+	macSalt := make([]byte, pkcs12SaltLength)
+	if _, err := io.ReadFull(rand.Reader, macSalt); err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha1.New, pkcs12KDF(encodedPassword, macSalt, pkcs12Iterations, 3, sha1.Size))
+	mac.Write(authenticatedSafe)
+
+	return asn1.Marshal(pfxPdu{
+		Version:  3,
+		AuthSafe: contentInfo{ContentType: oidData, Content: explicitContent(authenticatedSafeOctets)},
+		MacData: macData{
+			Mac: digestInfo{
+				Algorithm: algorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+				Digest:    mac.Sum(nil),
+			},
+			MacSalt:    macSalt,
+			Iterations: pkcs12Iterations,
+		},
+	})
+}
+
+// explicitContent wraps DER in a [0] EXPLICIT tag. encoding/asn1 ignores the tag of a RawValue field
+// when marshaling, so the tag is added here.
+func explicitContent(der []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+}
+
+func bagAttributes(friendlyName string, localKeyID []byte) ([]pkcs12Attribute, error) {
+	keyID, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []pkcs12Attribute{{ID: oidLocalKeyID, Value: asn1.RawValue{FullBytes: setOf(keyID)}}}
+
+	if friendlyName != "" {
+		// BMPString has no Go type, so it is tagged by hand. The trailing NUL of bmpString is dropped.
+		name := bmpString(friendlyName)
+
+		nameDER, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: 30, Bytes: name[:len(name)-2]})
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, pkcs12Attribute{ID: oidFriendlyName, Value: asn1.RawValue{FullBytes: setOf(nameDER)}})
+	}
+
+	return attributes, nil
+}
+
+// setOf wraps a single DER value in a SET.
+func setOf(der []byte) []byte {
+	set, _ := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: der})
+
+	return set
+}
+
+// pbeEncrypt encrypts data with pbeWithSHAAnd3-KeyTripleDES-CBC and a random salt.
+func pbeEncrypt(password []byte, data []byte) (algorithmIdentifier, []byte, error) {
+	salt := make([]byte, pkcs12SaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+
+	params, err := asn1.Marshal(pbeParams{Salt: salt, Iterations: pkcs12Iterations})
+	if err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+
+	block, err := des.NewTripleDESCipher(pkcs12KDF(password, salt, pkcs12Iterations, 1, 24))
+	if err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+
+	iv := pkcs12KDF(password, salt, pkcs12Iterations, 2, block.BlockSize())
+
+	padding := block.BlockSize() - len(data)%block.BlockSize()
+	ciphertext := make([]byte, len(data)+padding)
+	copy(ciphertext, data)
+
+	for i := len(data); i < len(ciphertext); i++ {
+		ciphertext[i] = byte(padding)
+	}
+
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+
+	return algorithmIdentifier{Algorithm: oidPBEWithSHAAnd3KeyTDES, Parameters: asn1.RawValue{FullBytes: params}}, ciphertext, nil
+}
+
+// pkcs12KDF derives size bytes of key material from a password, following RFC 7292, appendix B.2,
+// with SHA-1. id is 1 for encryption keys, 2 for IVs and 3 for MAC keys.
+func pkcs12KDF(password []byte, salt []byte, iterations int, id byte, size int) []byte {
+	const (
+		u = sha1.Size
+		v = 64
+	)
+
+	d := make([]byte, v)
+	for i := range d {
+		d[i] = id
+	}
+
+	fill := func(b []byte) []byte {
+		if len(b) == 0 {
+			return nil
+		}
+
+		out := make([]byte, v*((len(b)+v-1)/v))
+		for i := range out {
+			out[i] = b[i%len(b)]
+		}
+
+		return out
+	}
+
+	i := append(fill(salt), fill(password)...)
+
+	var out []byte
+
+	for len(out) < size {
+		h := sha1.New() // nolint: gosec
+		h.Write(d)
+		h.Write(i)
+		a := h.Sum(nil)
+
+		for r := 1; r < iterations; r++ {
+			sum := sha1.Sum(a) // nolint: gosec
+			a = sum[:]
+		}
+
+		out = append(out, a...)
+
+		if len(out) >= size {
+			break
+		}
+
+		// Each v-byte block of i becomes (block + b + 1) mod 2^(8v), where b repeats a.
+		b := make([]byte, v)
+		for j := range b {
+			b[j] = a[j%u]
+		}
+
+		for j := 0; j < len(i); j += v {
+			carry := 1
+
+			for k := v - 1; k >= 0; k-- {
+				carry += int(i[j+k]) + int(b[k])
+				i[j+k] = byte(carry)
+				carry >>= 8
+			}
+		}
+	}
+
+	return out[:size]
+}
+
+// bmpString encodes a password as big-endian UTF-16 with a terminating NUL, as PKCS#12 requires.
+func bmpString(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	out := make([]byte, 0, 2*len(encoded)+2)
+
+	for _, c := range encoded {
+		out = append(out, byte(c>>8), byte(c))
+	}
+
+	return append(out, 0, 0)
+}
@@ -0,0 +1,163 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint: gosec
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCertificate(t *testing.T) (interface{}, *x509.Certificate) {
+	t.Helper()
+
+	key, err := GenerateKey(KeyAlgorithmECDSA, 0)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Tëam"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return key, certificate
+}
+
+func pbeDecrypt(t *testing.T, algorithm algorithmIdentifier, password []byte, ciphertext []byte) []byte {
+	t.Helper()
+
+	assert.Equal(t, oidPBEWithSHAAnd3KeyTDES, algorithm.Algorithm)
+
+	var params pbeParams
+	_, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &params)
+	assert.NoError(t, err)
+
+	block, err := des.NewTripleDESCipher(pkcs12KDF(password, params.Salt, params.Iterations, 1, 24))
+	assert.NoError(t, err)
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, pkcs12KDF(password, params.Salt, params.Iterations, 2, 8)).CryptBlocks(plaintext, ciphertext)
+
+	return plaintext[:len(plaintext)-int(plaintext[len(plaintext)-1])]
+}
+
+func TestEncodePKCS12(t *testing.T) {
+	t.Parallel()
+
+	key, certificate := testCertificate(t)
+
+	bundle, err := EncodePKCS12(key, certificate, "hunter2")
+	assert.NoError(t, err)
+
+	var pfx pfxPdu
+	_, err = asn1.Unmarshal(bundle, &pfx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pfx.Version)
+
+	var authenticatedSafe []byte
+	_, err = asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authenticatedSafe)
+	assert.NoError(t, err)
+
+	password := bmpString("hunter2")
+	mac := hmac.New(sha1.New, pkcs12KDF(password, pfx.MacData.MacSalt, pfx.MacData.Iterations, 3, 20))
+	mac.Write(authenticatedSafe)
+	assert.Equal(t, mac.Sum(nil), pfx.MacData.Mac.Digest)
+
+	var contents []contentInfo
+	_, err = asn1.Unmarshal(authenticatedSafe, &contents)
+	assert.NoError(t, err)
+	assert.Len(t, contents, 2)
+
+	var certs encryptedData
+	_, err = asn1.Unmarshal(contents[0].Content.Bytes, &certs)
+	assert.NoError(t, err)
+
+	var certBags []safeBag
+	_, err = asn1.Unmarshal(pbeDecrypt(t, certs.EncryptedContentInfo.ContentEncryptionAlgorithm, password, certs.EncryptedContentInfo.EncryptedContent), &certBags)
+	assert.NoError(t, err)
+
+	var bag certBag
+	_, err = asn1.Unmarshal(certBags[0].Value.Bytes, &bag)
+	assert.NoError(t, err)
+	assert.Equal(t, certificate.Raw, bag.Data)
+
+	var keyContents []byte
+	_, err = asn1.Unmarshal(contents[1].Content.Bytes, &keyContents)
+	assert.NoError(t, err)
+
+	var keyBags []safeBag
+	_, err = asn1.Unmarshal(keyContents, &keyBags)
+	assert.NoError(t, err)
+	assert.Equal(t, oidPKCS8ShroudedKeyBag, keyBags[0].ID)
+	assert.Equal(t, certBags[0].Attributes, keyBags[0].Attributes)
+
+	var shrouded encryptedPrivateKeyInfo
+	_, err = asn1.Unmarshal(keyBags[0].Value.Bytes, &shrouded)
+	assert.NoError(t, err)
+
+	decoded, err := x509.ParsePKCS8PrivateKey(pbeDecrypt(t, shrouded.AlgorithmIdentifier, password, shrouded.EncryptedData))
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+}
+
+func TestEncodePKCS12RequiresCertificate(t *testing.T) {
+	t.Parallel()
+
+	key, _ := testCertificate(t)
+
+	_, err := EncodePKCS12(key, nil, "")
+	assert.Equal(t, ErrMissingCertificate, err)
+}
+
+func TestPKCS12KDF(t *testing.T) {
+	t.Parallel()
+
+	// Test vector from the PKCS#12 KDF tests of Bouncy Castle and OpenSSL, for the password
+	// "smeg" and salt 0A58CF64530D823F.
+	salt, _ := hex.DecodeString("0A58CF64530D823F")
+	assert.Equal(t, "8aaae6297b6cb04642ab5b077851284eb7128f1a2a7fbca3", hex.EncodeToString(pkcs12KDF(bmpString("smeg"), salt, 1, 1, 24)))
+	assert.Equal(t, "79993dfe048d3b76", hex.EncodeToString(pkcs12KDF(bmpString("smeg"), salt, 1, 2, 8)))
+}
+
+func TestBMPString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{0, 'a', 0xd8, 0x3d, 0xde, 0x00, 0, 0}, bmpString("a😀"))
+	assert.Equal(t, []byte{0, 0}, bmpString(""))
+}
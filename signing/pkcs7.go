@@ -0,0 +1,405 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// The CMS (PKCS#7) parser below only understands what provisioning profiles use: SignedData with an
+// embedded content, and signers identified by issuer and serial number.
+
+var (
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+
+	digestAlgorithms = map[string]crypto.Hash{
+		oidSHA1.String(): crypto.SHA1,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}.String(): crypto.SHA256,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}.String(): crypto.SHA384,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}.String(): crypto.SHA512,
+	}
+)
+
+var (
+	// ErrNotSignedData happens when data is not a CMS SignedData message with embedded content.
+	ErrNotSignedData = errors.New("not a signed message with embedded content")
+	// ErrNoSigner happens when a signed message has no signer, or the signer's certificate is not embedded.
+	ErrNoSigner = errors.New("signer certificate not found in signed message")
+	// ErrDigestMismatch happens when the content of a signed message does not match the signed digest.
+	ErrDigestMismatch = errors.New("content does not match the signed digest")
+)
+
+// ErrUnsupportedAlgorithm happens when a signed message uses a digest or key algorithm that cannot be verified.
+type ErrUnsupportedAlgorithm struct {
+	Algorithm string
+}
+
+func (e ErrUnsupportedAlgorithm) Error() string {
+	return fmt.Sprintf("unsupported signature algorithm %s", e.Algorithm)
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerialNumber
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// signedMessage is a parsed CMS SignedData message.
+type signedMessage struct {
+	content      []byte
+	certificates []*x509.Certificate
+	signer       signerInfo
+}
+
+func parseSignedMessage(data []byte) (*signedMessage, error) {
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var info contentInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, ErrNotSignedData
+	}
+
+	var signed signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signed); err != nil {
+		return nil, err
+	}
+
+	if len(signed.ContentInfo.Content.Bytes) == 0 {
+		return nil, ErrNotSignedData
+	}
+
+	message := &signedMessage{}
+	if _, err := asn1.Unmarshal(signed.ContentInfo.Content.Bytes, &message.content); err != nil {
+		return nil, err
+	}
+
+	if len(signed.Certificates.Bytes) > 0 {
+		if message.certificates, err = x509.ParseCertificates(signed.Certificates.Bytes); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(signed.SignerInfos) == 0 {
+		return nil, ErrNoSigner
+	}
+
+	message.signer = signed.SignerInfos[0]
+
+	return message, nil
+}
+
+// signerCertificate returns the embedded certificate of the message's signer.
+func (m *signedMessage) signerCertificate() (*x509.Certificate, error) {
+	id := m.signer.IssuerAndSerialNumber
+
+	for _, certificate := range m.certificates {
+		if certificate.SerialNumber.Cmp(id.SerialNumber) == 0 && bytes.Equal(certificate.RawIssuer, id.Issuer.FullBytes) {
+			return certificate, nil
+		}
+	}
+
+	return nil, ErrNoSigner
+}
+
+// verify checks the message's signature, and that the signer's certificate chains up to roots at
+// the given time. Other embedded certificates are used as intermediates.
+func (m *signedMessage) verify(roots *x509.CertPool, at time.Time) error {
+	signer, err := m.signerCertificate()
+	if err != nil {
+		return err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range m.certificates {
+		if certificate != signer {
+			intermediates.AddCert(certificate)
+		}
+	}
+
+	_, err = signer.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return err
+	}
+
+	hash, ok := digestAlgorithms[m.signer.DigestAlgorithm.Algorithm.String()]
+	if !ok {
+		return ErrUnsupportedAlgorithm{Algorithm: m.signer.DigestAlgorithm.Algorithm.String()}
+	}
+
+	algorithm, err := signatureAlgorithm(signer, hash)
+	if err != nil {
+		return err
+	}
+
+	signedBytes := m.content
+
+	if len(m.signer.AuthenticatedAttributes.FullBytes) > 0 {
+		// The signature covers the attributes encoded as a SET, not with their [0] IMPLICIT tag.
+		signedBytes = append([]byte{0x31}, m.signer.AuthenticatedAttributes.FullBytes[1:]...)
+
+		digest, err := messageDigest(signedBytes)
+		if err != nil {
+			return err
+		}
+
+		h := hash.New()
+		h.Write(m.content)
+
+		if !bytes.Equal(h.Sum(nil), digest) {
+			return ErrDigestMismatch
+		}
+	}
+
+	return signer.CheckSignature(algorithm, signedBytes, m.signer.EncryptedDigest)
+}
+
+// messageDigest returns the value of the message digest attribute.
+func messageDigest(attributes []byte) ([]byte, error) {
+	var parsed []cmsAttribute
+	if _, err := asn1.UnmarshalWithParams(attributes, &parsed, "set"); err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range parsed {
+		if !attribute.Type.Equal(oidMessageDigest) {
+			continue
+		}
+
+		var digest []byte
+		if _, err := asn1.Unmarshal(attribute.Values.Bytes, &digest); err != nil {
+			return nil, err
+		}
+
+		return digest, nil
+	}
+
+	return nil, ErrDigestMismatch
+}
+
+func signatureAlgorithm(certificate *x509.Certificate, hash crypto.Hash) (x509.SignatureAlgorithm, error) {
+	switch certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		switch hash {
+		case crypto.SHA1:
+			return x509.SHA1WithRSA, nil
+		case crypto.SHA256:
+			return x509.SHA256WithRSA, nil
+		case crypto.SHA384:
+			return x509.SHA384WithRSA, nil
+		case crypto.SHA512:
+			return x509.SHA512WithRSA, nil
+		}
+	case *ecdsa.PublicKey:
+		switch hash {
+		case crypto.SHA1:
+			return x509.ECDSAWithSHA1, nil
+		case crypto.SHA256:
+			return x509.ECDSAWithSHA256, nil
+		case crypto.SHA384:
+			return x509.ECDSAWithSHA384, nil
+		case crypto.SHA512:
+			return x509.ECDSAWithSHA512, nil
+		}
+	}
+
+	return x509.UnknownSignatureAlgorithm, ErrUnsupportedAlgorithm{Algorithm: fmt.Sprintf("%T with %s", certificate.PublicKey, hash)}
+}
+
+// berToDER rewrites a BER encoding in the DER form encoding/asn1 expects: indefinite lengths become
+// definite, and constructed OCTET STRINGs are flattened into primitive ones.
+func berToDER(ber []byte) ([]byte, error) {
+	der, rest, err := convertBER(ber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, asn1.SyntaxError{Msg: "trailing data"}
+	}
+
+	return der, nil
+}
+
+func convertBER(ber []byte) (der []byte, rest []byte, err error) {
+	errTruncated := asn1.SyntaxError{Msg: "data truncated"}
+
+	if len(ber) < 2 {
+		return nil, nil, errTruncated
+	}
+
+	idLength := 1
+	if ber[0]&0x1f == 0x1f {
+		for idLength < len(ber) && ber[idLength]&0x80 != 0 {
+			idLength++
+		}
+
+		idLength++
+	}
+
+	if idLength >= len(ber) {
+		return nil, nil, errTruncated
+	}
+
+	identifier := ber[:idLength]
+	constructed := ber[0]&0x20 != 0
+	lengthByte := ber[idLength]
+	body := ber[idLength+1:]
+	indefinite := lengthByte == 0x80
+
+	if !indefinite {
+		length := int(lengthByte)
+
+		if lengthByte > 0x80 {
+			n := int(lengthByte & 0x7f)
+			if n > 4 || n > len(body) {
+				return nil, nil, errTruncated
+			}
+
+			length = 0
+			for _, b := range body[:n] {
+				length = length<<8 | int(b)
+			}
+
+			body = body[n:]
+		}
+
+		if length > len(body) {
+			return nil, nil, errTruncated
+		}
+
+		body, rest = body[:length], body[length:]
+
+		if !constructed {
+			return encodeTLV(identifier, body), rest, nil
+		}
+	} else if !constructed {
+		return nil, nil, asn1.SyntaxError{Msg: "indefinite length primitive"}
+	}
+
+	var children [][]byte
+
+	for {
+		if indefinite {
+			if len(body) < 2 {
+				return nil, nil, errTruncated
+			}
+
+			if body[0] == 0 && body[1] == 0 {
+				rest = body[2:]
+
+				break
+			}
+		} else if len(body) == 0 {
+			break
+		}
+
+		var child []byte
+
+		child, body, err = convertBER(body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		children = append(children, child)
+	}
+
+	if identifier[0] == 0x24 && len(identifier) == 1 {
+		// A constructed OCTET STRING is the concatenation of its segments.
+		var content []byte
+
+		for _, child := range children {
+			var segment []byte
+			if _, err := asn1.Unmarshal(child, &segment); err != nil {
+				return nil, nil, err
+			}
+
+			content = append(content, segment...)
+		}
+
+		return encodeTLV([]byte{0x04}, content), rest, nil
+	}
+
+	return encodeTLV(identifier, bytes.Join(children, nil)), rest, nil
+}
+
+func encodeTLV(identifier []byte, content []byte) []byte {
+	out := append([]byte{}, identifier...)
+
+	if len(content) < 0x80 {
+		return append(append(out, byte(len(content))), content...)
+	}
+
+	var length []byte
+	for n := len(content); n > 0; n >>= 8 {
+		length = append([]byte{byte(n)}, length...)
+	}
+
+	out = append(out, 0x80|byte(len(length)))
+	out = append(out, length...)
+
+	return append(out, content...)
+}
@@ -0,0 +1,288 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tutorioapp/asc-go/plist"
+)
+
+// ErrInvalidProfile happens when the payload of a provisioning profile is not the expected dictionary.
+type ErrInvalidProfile struct {
+	Key string
+}
+
+func (e ErrInvalidProfile) Error() string {
+	if e.Key == "" {
+		return "provisioning profile payload is not a dictionary"
+	}
+
+	return fmt.Sprintf("provisioning profile payload has an invalid %s", e.Key)
+}
+
+// ProfilePayload is the property list inside a provisioning profile.
+type ProfilePayload struct {
+	AppIDName                   string
+	ApplicationIdentifierPrefix []string
+	CreationDate                time.Time
+	ExpirationDate              time.Time
+	// DeveloperCertificates are the certificates whose identities can sign with the profile.
+	DeveloperCertificates []*x509.Certificate
+	Entitlements          map[string]interface{}
+	IsXcodeManaged        bool
+	Name                  string
+	// Platform lists the platforms the profile is valid for, such as "iOS" or "OSX".
+	Platform []string
+	// ProvisionedDevices are the UDIDs of the devices that can run apps signed with a development or
+	// ad hoc profile.
+	ProvisionedDevices   []string
+	ProvisionsAllDevices bool
+	TeamIdentifier       []string
+	TeamName             string
+	TimeToLive           int
+	UUID                 string
+	Version              int
+}
+
+// TeamID returns the ID of the team that owns the profile.
+func (p *ProfilePayload) TeamID() string {
+	if len(p.TeamIdentifier) == 0 {
+		return ""
+	}
+
+	return p.TeamIdentifier[0]
+}
+
+// ApplicationIdentifier returns the application identifier entitlement, such as
+// "TEAMID.com.example.app". macOS profiles name it com.apple.application-identifier.
+func (p *ProfilePayload) ApplicationIdentifier() string {
+	for _, key := range []string{"application-identifier", "com.apple.application-identifier"} {
+		if id, ok := p.Entitlements[key].(string); ok {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// BundleID returns the application identifier without its team prefix. It is "*" or ends in ".*"
+// for wildcard profiles.
+func (p *ProfilePayload) BundleID() string {
+	id := p.ApplicationIdentifier()
+
+	for _, prefix := range append(p.ApplicationIdentifierPrefix, p.TeamIdentifier...) {
+		if strings.HasPrefix(id, prefix+".") {
+			return strings.TrimPrefix(id, prefix+".")
+		}
+	}
+
+	return id
+}
+
+// Profile is a decoded provisioning profile: a property list signed by Apple.
+type Profile struct {
+	Payload ProfilePayload
+	// Plist is the signed property list.
+	Plist []byte
+	// Certificates are the certificates embedded in the signature.
+	Certificates []*x509.Certificate
+
+	message *signedMessage
+}
+
+// ParseProfileContent decodes asc.ProfileAttributes.ProfileContent, which is the base64 encoding of a
+// .mobileprovision or .provisionprofile file.
+func ParseProfileContent(content string) (*Profile, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProfile(data)
+}
+
+// ParseProfile decodes the content of a .mobileprovision or .provisionprofile file. It does not
+// check the signature; call Verify for that.
+func ParseProfile(data []byte) (*Profile, error) {
+	message, err := parseSignedMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := parseProfilePayload(message.content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Profile{
+		Payload:      *payload,
+		Plist:        message.content,
+		Certificates: message.certificates,
+		message:      message,
+	}, nil
+}
+
+// Verify checks that the profile was signed by a certificate that chains up to roots, such as a
+// pool holding the Apple Root CA certificate. The chain is checked as of the profile's creation
+// date, since the certificates that sign profiles are rotated more often than profiles expire.
+func (p *Profile) Verify(roots *x509.CertPool) error {
+	at := p.Payload.CreationDate
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return p.message.verify(roots, at)
+}
+
+func parseProfilePayload(data []byte) (*ProfilePayload, error) {
+	value, err := plist.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidProfile{}
+	}
+
+	p := payloadReader{dict: dict}
+	payload := &ProfilePayload{
+		AppIDName:                   p.string("AppIDName"),
+		ApplicationIdentifierPrefix: p.strings("ApplicationIdentifierPrefix"),
+		CreationDate:                p.date("CreationDate"),
+		ExpirationDate:              p.date("ExpirationDate"),
+		IsXcodeManaged:              p.bool("IsXcodeManaged"),
+		Name:                        p.string("Name"),
+		Platform:                    p.strings("Platform"),
+		ProvisionedDevices:          p.strings("ProvisionedDevices"),
+		ProvisionsAllDevices:        p.bool("ProvisionsAllDevices"),
+		TeamIdentifier:              p.strings("TeamIdentifier"),
+		TeamName:                    p.string("TeamName"),
+		TimeToLive:                  p.int("TimeToLive"),
+		UUID:                        p.string("UUID"),
+		Version:                     p.int("Version"),
+	}
+
+	if entitlements, ok := dict["Entitlements"]; ok {
+		if payload.Entitlements, ok = entitlements.(map[string]interface{}); !ok {
+			p.fail("Entitlements")
+		}
+	}
+
+	for _, der := range p.array("DeveloperCertificates") {
+		data, ok := der.([]byte)
+		if !ok {
+			p.fail("DeveloperCertificates")
+
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(data)
+		if err != nil {
+			return nil, err
+		}
+
+		payload.DeveloperCertificates = append(payload.DeveloperCertificates, certificate)
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return payload, nil
+}
+
+// payloadReader reads typed values from a dictionary, and remembers the first key whose value has
+// the wrong type. Missing keys read as zero values.
+type payloadReader struct {
+	dict map[string]interface{}
+	err  error
+}
+
+func (r *payloadReader) fail(key string) {
+	if r.err == nil {
+		r.err = ErrInvalidProfile{Key: key}
+	}
+}
+
+// check records key as invalid when it is present but its value did not have the expected type.
+func (r *payloadReader) check(key string, ok bool) {
+	if _, present := r.dict[key]; present && !ok {
+		r.fail(key)
+	}
+}
+
+func (r *payloadReader) string(key string) string {
+	s, ok := r.dict[key].(string)
+	r.check(key, ok)
+
+	return s
+}
+
+func (r *payloadReader) bool(key string) bool {
+	b, ok := r.dict[key].(bool)
+	r.check(key, ok)
+
+	return b
+}
+
+func (r *payloadReader) int(key string) int {
+	i, ok := r.dict[key].(int64)
+	r.check(key, ok)
+
+	return int(i)
+}
+
+func (r *payloadReader) date(key string) time.Time {
+	t, ok := r.dict[key].(time.Time)
+	r.check(key, ok)
+
+	return t
+}
+
+func (r *payloadReader) array(key string) []interface{} {
+	a, ok := r.dict[key].([]interface{})
+	r.check(key, ok)
+
+	return a
+}
+
+func (r *payloadReader) strings(key string) []string {
+	var out []string
+
+	for _, value := range r.array(key) {
+		s, ok := value.(string)
+		if !ok {
+			r.fail(key)
+
+			continue
+		}
+
+		out = append(out, s)
+	}
+
+	return out
+}
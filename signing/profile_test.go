@@ -0,0 +1,281 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of asc-go, a package for working with Apple's
+App Store Connect API.
+
+asc-go is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+asc-go is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with asc-go.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package signing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var oidContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+
+type testProfileSigner struct {
+	roots       *x509.CertPool
+	certificate *x509.Certificate
+	key         crypto.Signer
+}
+
+func newTestProfileSigner(t *testing.T) *testProfileSigner {
+	t.Helper()
+
+	rootKey, err := GenerateKey(KeyAlgorithmECDSA, 0)
+	assert.NoError(t, err)
+
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	assert.NoError(t, err)
+
+	root, err := x509.ParseCertificate(rootDER)
+	assert.NoError(t, err)
+
+	key, err := GenerateKey(KeyAlgorithmRSA, 0)
+	assert.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test Provisioning Profile Signing"},
+		NotBefore:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, root, key.Public(), rootKey)
+	assert.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	return &testProfileSigner{roots: roots, certificate: certificate, key: key}
+}
+
+// sign wraps content in a CMS SignedData message with signed attributes, like Apple's profiles.
+func (s *testProfileSigner) sign(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(content)
+
+	contentTypeValue, _ := asn1.Marshal(oidData)
+	digestValue, _ := asn1.Marshal(digest[:])
+	contentTypeAttribute, _ := asn1.Marshal(cmsAttribute{Type: oidContentType, Values: asn1.RawValue{FullBytes: setOf(contentTypeValue)}})
+	digestAttribute, _ := asn1.Marshal(cmsAttribute{Type: oidMessageDigest, Values: asn1.RawValue{FullBytes: setOf(digestValue)}})
+	attributes := append(contentTypeAttribute, digestAttribute...)
+
+	signedAttributes := setOf(attributes)
+	attributesDigest := sha256.Sum256(signedAttributes)
+
+	signature, err := s.key.Sign(rand.Reader, attributesDigest[:], crypto.SHA256)
+	assert.NoError(t, err)
+
+	octets, _ := asn1.Marshal(content)
+	sha256Algorithm := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}}
+
+	signed, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256Algorithm},
+		ContentInfo:      contentInfo{ContentType: oidData, Content: explicitContent(octets)},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: s.certificate.Raw},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			IssuerAndSerialNumber: issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: s.certificate.RawIssuer},
+				SerialNumber: s.certificate.SerialNumber,
+			},
+			DigestAlgorithm:           sha256Algorithm,
+			AuthenticatedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attributes},
+			DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}},
+			EncryptedDigest:           signature,
+		}},
+	})
+	assert.NoError(t, err)
+
+	message, err := asn1.Marshal(contentInfo{ContentType: oidSignedData, Content: explicitContent(signed)})
+	assert.NoError(t, err)
+
+	return message
+}
+
+func testProfilePlist(t *testing.T) []byte {
+	t.Helper()
+
+	_, developer := testCertificate(t)
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>AppIDName</key>
+	<string>Example</string>
+	<key>ApplicationIdentifierPrefix</key>
+	<array><string>TEAMID</string></array>
+	<key>CreationDate</key>
+	<date>2024-03-01T12:00:00Z</date>
+	<key>Platform</key>
+	<array><string>iOS</string></array>
+	<key>IsXcodeManaged</key>
+	<false/>
+	<key>DeveloperCertificates</key>
+	<array><data>%s</data></array>
+	<key>Entitlements</key>
+	<dict>
+		<key>application-identifier</key>
+		<string>TEAMID.com.example.app</string>
+		<key>get-task-allow</key>
+		<true/>
+	</dict>
+	<key>ExpirationDate</key>
+	<date>2025-03-01T12:00:00Z</date>
+	<key>Name</key>
+	<string>Example Development</string>
+	<key>ProvisionedDevices</key>
+	<array>
+		<string>00008030-000000000000001E</string>
+		<string>00008030-000000000000002E</string>
+	</array>
+	<key>TeamIdentifier</key>
+	<array><string>TEAMID</string></array>
+	<key>TeamName</key>
+	<string>Example Inc.</string>
+	<key>TimeToLive</key>
+	<integer>365</integer>
+	<key>UUID</key>
+	<string>3f0e6e1c-0000-4000-8000-000000000000</string>
+	<key>Version</key>
+	<integer>1</integer>
+</dict>
+</plist>`, base64.StdEncoding.EncodeToString(developer.Raw)))
+}
+
+func TestParseProfileContent(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestProfileSigner(t)
+	content := testProfilePlist(t)
+
+	profile, err := ParseProfileContent(base64.StdEncoding.EncodeToString(signer.sign(t, content)))
+	assert.NoError(t, err)
+	assert.Equal(t, content, profile.Plist)
+	assert.Equal(t, []*x509.Certificate{signer.certificate}, profile.Certificates)
+
+	payload := profile.Payload
+	assert.Equal(t, "Example Development", payload.Name)
+	assert.Equal(t, "TEAMID", payload.TeamID())
+	assert.Equal(t, "TEAMID.com.example.app", payload.ApplicationIdentifier())
+	assert.Equal(t, "com.example.app", payload.BundleID())
+	assert.Equal(t, []string{"00008030-000000000000001E", "00008030-000000000000002E"}, payload.ProvisionedDevices)
+	assert.Equal(t, []string{"iOS"}, payload.Platform)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), payload.CreationDate)
+	assert.Equal(t, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), payload.ExpirationDate)
+	assert.Equal(t, true, payload.Entitlements["get-task-allow"])
+	assert.Equal(t, 365, payload.TimeToLive)
+	assert.Len(t, payload.DeveloperCertificates, 1)
+	assert.Equal(t, "Apple Distribution: Tëam", payload.DeveloperCertificates[0].Subject.CommonName)
+
+	assert.NoError(t, profile.Verify(signer.roots))
+}
+
+func TestProfileVerifyRejectsOtherRoots(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestProfileSigner(t)
+	other := newTestProfileSigner(t)
+
+	profile, err := ParseProfile(signer.sign(t, testProfilePlist(t)))
+	assert.NoError(t, err)
+	assert.IsType(t, x509.UnknownAuthorityError{}, profile.Verify(other.roots))
+}
+
+func TestProfileVerifyRejectsTamperedContent(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestProfileSigner(t)
+
+	profile, err := ParseProfile(signer.sign(t, testProfilePlist(t)))
+	assert.NoError(t, err)
+
+	profile.message.content = append([]byte{}, profile.message.content...)
+	profile.message.content[len(profile.message.content)-2] = ' '
+	assert.Equal(t, ErrDigestMismatch, profile.Verify(signer.roots))
+}
+
+func TestParseProfileRejectsInvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestProfileSigner(t)
+
+	_, err := ParseProfile(signer.sign(t, []byte(`<plist><array/></plist>`)))
+	assert.Equal(t, ErrInvalidProfile{}, err)
+
+	_, err = ParseProfile(signer.sign(t, []byte(`<plist><dict><key>TeamIdentifier</key><string>TEAMID</string></dict></plist>`)))
+	assert.Equal(t, ErrInvalidProfile{Key: "TeamIdentifier"}, err)
+
+	_, err = ParseProfile([]byte{0x30, 0x03, 0x06, 0x01, 0x00})
+	assert.Error(t, err)
+}
+
+func TestBERToDER(t *testing.T) {
+	t.Parallel()
+
+	// An indefinite-length SEQUENCE holding a constructed, indefinite-length OCTET STRING made of two
+	// segments, followed by an INTEGER.
+	ber := []byte{
+		0x30, 0x80,
+		0x24, 0x80, 0x04, 0x02, 'a', 'b', 0x04, 0x01, 'c', 0x00, 0x00,
+		0x02, 0x01, 0x05,
+		0x00, 0x00,
+	}
+
+	der, err := berToDER(ber)
+	assert.NoError(t, err)
+
+	var parsed struct {
+		Octets []byte
+		Number int
+	}
+
+	_, err = asn1.Unmarshal(der, &parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("abc"), parsed.Octets)
+	assert.Equal(t, 5, parsed.Number)
+
+	_, err = berToDER([]byte{0x30, 0x80, 0x02, 0x01})
+	assert.Error(t, err)
+}